@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
+	"event-booking/internal/config"
+	"event-booking/internal/entity"
+	"event-booking/internal/event"
+	"event-booking/internal/postgres"
+	"event-booking/internal/reconciliation"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func eventCmd() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "event",
+		Short: "Inspect and repair events",
+	}
+
+	command.AddCommand(eventListCmd())
+	command.AddCommand(eventRecountSeatsCmd())
+	command.AddCommand(eventReconcileSeatsCmd())
+	return command
+}
+
+func eventService() *event.Service {
+	cfg := config.Load()
+	db := postgres.NewGORM(cfg.Database)
+	notificationSvc, webhookSvc := newNotificationService(cfg, db)
+
+	return event.NewService(event.NewRepository(db), notificationSvc, webhookSvc)
+}
+
+func eventListCmd() *cobra.Command {
+	var opts = query.Options{
+		Sort:    []query.SortField{{Field: event.SortStartDate}},
+		Filters: map[string]string{},
+	}
+	var status, category string
+	var command = &cobra.Command{
+		Use:   "list",
+		Short: "List events of every organizer, including drafts",
+		Run: func(cmd *cobra.Command, args []string) {
+			if status != "" {
+				opts.Filters["status"] = status
+			}
+			if category != "" {
+				opts.Filters["category"] = category
+			}
+
+			events, total, err := eventService().FindAllEventService(tenant.Scope{}, opts)
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not list events")
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tSTART\tSTATUS\tSEATS")
+			for _, e := range events {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%d\n",
+					e.ID, e.Name, e.StartDate.Format(time.DateTime), e.Status, e.AvailableSeat, e.TotalSeat)
+			}
+			w.Flush()
+			fmt.Printf("%d of %d events\n", len(events), total)
+		},
+	}
+
+	command.Flags().IntVar(&opts.Limit, "limit", query.DefaultLimit, "Maximum number of events, 0 for all")
+	command.Flags().IntVar(&opts.Offset, "offset", 0, "Number of events to skip")
+	command.Flags().StringVar(&status, "status", "", "Only events with this stored status: draft, published or cancelled")
+	command.Flags().StringVar(&category, "category", "", "Only events in this category")
+	return command
+}
+
+func eventRecountSeatsCmd() *cobra.Command {
+	var all bool
+	var command = &cobra.Command{
+		Use:   "recount-seats [<id>...]",
+		Short: "Recompute available seats from confirmed bookings",
+		Long: "Recompute the available seats of events from their confirmed bookings, " +
+			"for the given event IDs or every event with --all.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) > 0) {
+				return fmt.Errorf("pass either event IDs or --all")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			svc := eventService()
+
+			ids := args
+			if all {
+				events, _, err := svc.FindAllEventService(tenant.Scope{}, query.Options{})
+				if err != nil {
+					log.Fatal().Err(err).Msg("could not list events")
+				}
+				for _, e := range events {
+					ids = append(ids, e.ID.String())
+				}
+			}
+
+			fixed := 0
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tBEFORE\tAFTER")
+			for _, id := range ids {
+				e, previous, err := svc.RecountSeatsService(id)
+				if err != nil {
+					w.Flush()
+					log.Fatal().Err(err).Msgf("could not recount seats of event %s", id)
+				}
+				if previous != e.AvailableSeat {
+					fixed++
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", e.ID, e.Name, previous, e.AvailableSeat)
+			}
+			w.Flush()
+			fmt.Printf("fixed %d of %d events\n", fixed, len(ids))
+		},
+	}
+
+	command.Flags().BoolVar(&all, "all", false, "Recount every event")
+	return command
+}
+
+func eventReconcileSeatsCmd() *cobra.Command {
+	var correct bool
+	var command = &cobra.Command{
+		Use:   "reconcile-seats",
+		Short: "Report events whose available seats drifted from their bookings",
+		Long: "Compare the available seats of every event with its confirmed bookings and store the " +
+			"result as a seat reconciliation report. With --correct drifted events are recounted.",
+		Run: func(cmd *cobra.Command, args []string) {
+			db := postgres.NewGORM(config.Load().Database)
+			svc := reconciliation.NewService(reconciliation.NewRepository(db), event.NewRepository(db))
+
+			report, err := svc.ReconcileSeatsService(entity.ReconciliationTriggerCLI, correct)
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not reconcile seats")
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "EVENT\tNAME\tTOTAL\tBOOKED\tRECORDED\tEXPECTED\tCORRECTED")
+			for _, d := range report.Drifts {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%t\n",
+					d.EventID, d.EventName, d.TotalSeat, d.BookedSeat, d.RecordedSeat, d.ExpectedSeat, d.Corrected)
+			}
+			w.Flush()
+			fmt.Printf("report %s: checked %d events, %d drifted, corrected %d\n",
+				report.ID, report.EventsChecked, report.DriftCount, report.CorrectedCount)
+		},
+	}
+
+	command.Flags().BoolVar(&correct, "correct", false, "Recount the available seats of drifted events")
+	return command
+}
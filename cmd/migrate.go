@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"event-booking/internal/config"
+	"event-booking/internal/postgres"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func migrateCmd() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage database migrations",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(migrateUpCmd(), migrateDownCmd(), migrateStatusCmd(), migrateCreateCmd())
+	return command
+}
+
+func newMigrator() *postgres.Migrator {
+	cfg := config.Load()
+	db := postgres.NewGORM(cfg.Database)
+
+	migrator, err := postgres.NewMigrator(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load migrations")
+	}
+
+	return migrator
+}
+
+func migrateUpCmd() *cobra.Command {
+	var steps int
+	var command = &cobra.Command{
+		Use:   "up",
+		Short: "Apply pending migrations",
+		Run: func(cmd *cobra.Command, args []string) {
+			applied, err := newMigrator().Up(steps)
+			for _, migration := range applied {
+				log.Info().Msgf("applied %s", migration)
+			}
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not migrate database")
+			}
+			log.Info().Msgf("database is up to date, applied %d migrations", len(applied))
+		},
+	}
+
+	command.Flags().IntVar(&steps, "steps", 0, "Apply at most this many migrations, 0 for all")
+	return command
+}
+
+func migrateDownCmd() *cobra.Command {
+	var steps int
+	var command = &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the latest migrations",
+		Run: func(cmd *cobra.Command, args []string) {
+			reverted, err := newMigrator().Down(steps)
+			for _, migration := range reverted {
+				log.Info().Msgf("rolled back %s", migration)
+			}
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not roll back database")
+			}
+		},
+	}
+
+	command.Flags().IntVar(&steps, "steps", 1, "Roll back this many migrations")
+	return command
+}
+
+func migrateStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "List migrations and whether they are applied",
+		Run: func(cmd *cobra.Command, args []string) {
+			statuses, err := newMigrator().Status()
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not read migration status")
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+			for _, status := range statuses {
+				appliedAt := "pending"
+				if status.AppliedAt != nil {
+					appliedAt = status.AppliedAt.Format(time.RFC3339)
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+			}
+			w.Flush()
+		},
+	}
+}
+
+func migrateCreateCmd() *cobra.Command {
+	var dir string
+	var command = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create empty up and down migration files",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			up, down, err := postgres.CreateMigration(dir, args[0], time.Now())
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not create migration")
+			}
+			log.Info().Msgf("created %s and %s", up, down)
+		},
+	}
+
+	command.Flags().StringVar(&dir, "dir", postgres.MigrationsDir, "Directory of the migration files")
+	return command
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"event-booking/internal/config"
+	"event-booking/internal/postgres"
+	"event-booking/internal/trash"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func purgeCmd() *cobra.Command {
+	var olderThan time.Duration
+	var command = &cobra.Command{
+		Use:   "purge",
+		Short: "Permanently delete soft deleted records",
+		Long: "Permanently delete the users, event series, events, bookings and reviews soft deleted longer " +
+			"than --older-than ago, defaulting to TRASH_RETENTION, together with the rows that depend on them.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := config.Load()
+			if olderThan <= 0 {
+				olderThan = cfg.Trash.Retention
+			}
+			if olderThan <= 0 {
+				log.Info().Msg("TRASH_RETENTION is 0, deleted records are kept forever")
+				return
+			}
+
+			db := postgres.NewGORM(cfg.Database)
+			svc := trash.NewService(trash.NewRepository(db), olderThan)
+
+			purged, err := svc.PurgeService(time.Now())
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not purge deleted records")
+			}
+
+			for _, kind := range trash.Kinds {
+				fmt.Printf("%s: %d\n", kind, purged[kind])
+			}
+		},
+	}
+
+	command.Flags().DurationVar(&olderThan, "older-than", 0, "Purge records deleted longer ago than this, e.g. 720h (default TRASH_RETENTION)")
+	return command
+}
@@ -15,6 +15,12 @@ func Execute() {
 	}
 
 	command.AddCommand(apiCmd())
+	command.AddCommand(schedulerCmd())
+	command.AddCommand(migrateCmd())
+	command.AddCommand(seedCmd())
+	command.AddCommand(userCmd())
+	command.AddCommand(eventCmd())
+	command.AddCommand(purgeCmd())
 
 	if err := command.Execute(); err != nil {
 		log.Fatal().Err(err).Msg("could not execute command")
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"event-booking/internal/audit"
+	"event-booking/internal/config"
+	"event-booking/internal/entity"
+	"event-booking/internal/event"
+	"event-booking/internal/postgres"
+	"event-booking/internal/reconciliation"
+	"event-booking/internal/reminder"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func schedulerCmd() *cobra.Command {
+	var once bool
+	var command = &cobra.Command{
+		Use:   "scheduler",
+		Short: "Run scheduled jobs: event reminders, seat reconciliation and audit retention",
+		Long: "Run scheduled jobs: event reminders, seat reconciliation and audit retention. Several schedulers may run at once; " +
+			"each job runs on one of them at a time.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := config.Load()
+
+			db := postgres.NewGORM(cfg.Database)
+
+			notificationSvc, _ := newNotificationService(cfg, db)
+
+			reminders := reminder.NewScheduler(reminder.NewRepository(db), notificationSvc, cfg.Reminder.Offsets)
+			seats := reconciliation.NewService(reconciliation.NewRepository(db), event.NewRepository(db))
+			audits := audit.NewService(audit.NewRepository(db), cfg.Audit.Retention)
+
+			if once {
+				sent, err := reminders.SendDueReminders(time.Now())
+				if err != nil {
+					log.Fatal().Err(err).Msg("could not send event reminders")
+				}
+				log.Info().Msgf("sent %d event reminders", sent)
+
+				report, err := seats.ReconcileSeatsService(entity.ReconciliationTriggerSchedule, cfg.Seats.ReconcileCorrect)
+				switch {
+				case errors.Is(err, reconciliation.ErrRunning):
+					log.Info().Msg("seats are being reconciled by another process")
+				case err != nil:
+					log.Fatal().Err(err).Msg("could not reconcile seats")
+				default:
+					log.Info().Msgf("checked %d events, %d drifted, corrected %d", report.EventsChecked, report.DriftCount, report.CorrectedCount)
+				}
+
+				purged, err := audits.PurgeService(time.Now())
+				if err != nil {
+					log.Fatal().Err(err).Msg("could not purge audit entries")
+				}
+				log.Info().Msgf("purged %d audit entries", purged)
+				return
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			log.Info().Msgf("scheduler running reminders every %s, seat reconciliation every %s and audit purge every %s",
+				cfg.Reminder.Interval, cfg.Seats.ReconcileInterval, cfg.Audit.PurgeInterval)
+
+			var wg sync.WaitGroup
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				reminders.Run(ctx, cfg.Reminder.Interval)
+			}()
+			go func() {
+				defer wg.Done()
+				seats.Run(ctx, cfg.Seats.ReconcileInterval, cfg.Seats.ReconcileCorrect)
+			}()
+			go func() {
+				defer wg.Done()
+				audits.Run(ctx, cfg.Audit.PurgeInterval)
+			}()
+			wg.Wait()
+
+			log.Info().Msg("scheduler stopped")
+		},
+	}
+
+	command.Flags().BoolVar(&once, "once", false, "Run every job once and exit, e.g. from cron")
+	return command
+}
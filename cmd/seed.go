@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"event-booking/internal/config"
+	"event-booking/internal/postgres"
+	"event-booking/internal/seed"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// printedUsers is how many regular accounts are listed after seeding.
+const printedUsers = 5
+
+func seedCmd() *cobra.Command {
+	var cfg seed.Config
+	var command = &cobra.Command{
+		Use:   "seed",
+		Short: "Fill the database with fake users, events, bookings and reviews",
+		Long: "Fill a migrated, empty database with fake users, events, bookings and reviews. " +
+			"The same --seed always generates the same data, with event dates relative to today.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg.Now = time.Now().Truncate(24 * time.Hour)
+
+			ds, err := seed.Generate(cfg)
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not generate seed data")
+			}
+
+			db := postgres.NewGORM(config.Load().Database)
+			if err := seed.Insert(db, ds); err != nil {
+				log.Fatal().Err(err).Msg("could not seed database")
+			}
+
+			log.Info().Msgf("seeded %d users, %d events, %d bookings and %d reviews",
+				len(ds.Users), len(ds.Events), len(ds.Bookings), len(ds.Reviews))
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ROLE\tEMAIL\tPASSWORD")
+			users := 0
+			for _, credential := range ds.Credentials {
+				if credential.Role == "user" {
+					if users++; users > printedUsers {
+						continue
+					}
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", credential.Role, credential.Email, credential.Password)
+			}
+			w.Flush()
+			if users > printedUsers {
+				fmt.Printf("... and %d more users up to %s, all with the same password\n", users-printedUsers, ds.Credentials[len(ds.Credentials)-1].Email)
+			}
+		},
+	}
+
+	command.Flags().Int64Var(&cfg.Seed, "seed", 1, "Random seed, the same seed generates the same data")
+	command.Flags().IntVar(&cfg.Scale, "scale", 1, "Multiplies the 20 users and 10 events generated at scale 1")
+	command.Flags().StringVar(&cfg.Password, "password", "password", "Password of every generated account")
+	return command
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"event-booking/internal/config"
+	"event-booking/internal/email"
+	"event-booking/internal/notification"
+	"event-booking/internal/webhook"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// newNotificationService wires notifications the way the API server does.
+// Emails are queued and sent by whichever process runs the email worker.
+func newNotificationService(cfg *config.Config, db *gorm.DB) (*notification.Service, *webhook.Service) {
+	emailQueue := email.NewQueue(email.NewRepository(db), email.NewEmailService(&cfg.Smtp), cfg.Email.MaxAttempts, cfg.Email.PollInterval)
+	webhookSvc := webhook.NewService(webhook.NewRepository(db))
+
+	notificationRepo := notification.NewRepository(db)
+	notificationRegistry, err := notification.NewRegistry()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load notification templates")
+	}
+
+	return notification.NewService(notificationRepo, notificationRegistry,
+		notification.NewEmailChannel(emailQueue),
+		notification.NewInAppChannel(notificationRepo),
+		notification.NewWebhookChannel(webhookSvc),
+	), webhookSvc
+}
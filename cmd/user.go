@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"event-booking/internal/account"
+	"event-booking/internal/api/validator"
+	"event-booking/internal/audit"
+	"event-booking/internal/config"
+	"event-booking/internal/entity"
+	"event-booking/internal/postgres"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func userCmd() *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "user",
+		Short: "Manage user accounts",
+	}
+
+	command.AddCommand(userCreateCmd())
+	command.AddCommand(userPromoteCmd())
+	command.AddCommand(userVerifyCmd())
+	command.AddCommand(userDisableCmd())
+	command.AddCommand(userDeleteCmd())
+	return command
+}
+
+func accountService() (*account.Service, *audit.Service) {
+	cfg := config.Load()
+	db := postgres.NewGORM(cfg.Database)
+	notificationSvc, _ := newNotificationService(cfg, db)
+
+	return account.NewService(account.NewRepository(db), notificationSvc),
+		audit.NewService(audit.NewRepository(db), cfg.Audit.Retention)
+}
+
+// updateUser runs update on the account registered with email and audits
+// the change.
+func updateUser(email, action string, update func(svc *account.Service) (*entity.User, error)) (*entity.User, error) {
+	svc, auditSvc := accountService()
+
+	before, err := svc.FindByEmailService(email)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := update(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := audit.FromCommand(action, audit.TargetUser, user.ID.String())
+	entry.Changes = audit.Diff(before, user)
+	auditSvc.Record(entry)
+
+	return user, nil
+}
+
+func userCreateCmd() *cobra.Command {
+	var payload account.SignUpPayload
+	var role string
+	var unverified bool
+	var command = &cobra.Command{
+		Use:   "create",
+		Short: "Create an account, e.g. the first admin",
+		Long: "Create an account with the same rules as sign-up. The account is verified unless " +
+			"--unverified is given. Without --password a random password is generated and printed.",
+		Run: func(cmd *cobra.Command, args []string) {
+			generated := payload.Password == ""
+			if generated {
+				payload.Password = randomPassword()
+			}
+
+			if err := validator.NewValidator().ValidateStruct(payload); err != nil {
+				log.Fatal().Err(err).Msg("could not create user")
+			}
+			if !slices.Contains(account.Roles, role) {
+				log.Fatal().Err(account.ErrInvalidRole).Msg("could not create user")
+			}
+
+			user := &entity.User{
+				Name:       payload.Name,
+				Email:      payload.Email,
+				Password:   payload.Password,
+				Role:       role,
+				IsVerified: !unverified,
+			}
+			svc, auditSvc := accountService()
+			if err := svc.SignUpUserService(user); err != nil {
+				log.Fatal().Err(err).Msg("could not create user")
+			}
+
+			entry := audit.FromCommand(audit.ActionAccountCreate, audit.TargetUser, user.ID.String())
+			entry.Changes = audit.Diff(nil, user)
+			auditSvc.Record(entry)
+
+			log.Info().Msgf("created %s account %s (%s)", role, user.Email, user.ID)
+			if generated {
+				fmt.Printf("password: %s\n", payload.Password)
+			}
+		},
+	}
+
+	command.Flags().StringVar(&payload.Name, "name", "", "Display name")
+	command.Flags().StringVar(&payload.Email, "email", "", "Email address used to sign in")
+	command.Flags().StringVar(&payload.Password, "password", "", "Password, generated when empty")
+	command.Flags().StringVar(&role, "role", "user", "Role of the account, admin or user")
+	command.Flags().BoolVar(&unverified, "unverified", false, "Require the user to verify their email before signing in")
+	command.MarkFlagRequired("name")
+	command.MarkFlagRequired("email")
+	return command
+}
+
+// randomPassword returns 16 URL-safe characters.
+func randomPassword() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal().Err(err).Msg("could not generate password")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func userPromoteCmd() *cobra.Command {
+	var role string
+	var command = &cobra.Command{
+		Use:   "promote <email>",
+		Short: "Change the role of an account, admin by default",
+		Long: "Change the role of an account, admin by default. Use --role user to demote an admin. " +
+			"The new role applies from the user's next request.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			user, err := updateUser(args[0], audit.ActionAccountRole, func(svc *account.Service) (*entity.User, error) {
+				return svc.SetRoleService(args[0], role)
+			})
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not change role")
+			}
+
+			log.Info().Msgf("%s is now %s", user.Email, user.Role)
+		},
+	}
+
+	command.Flags().StringVar(&role, "role", "admin", "New role, admin or user")
+	return command
+}
+
+func userVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify <email>",
+		Short: "Mark an account as verified without a verification code",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			user, err := updateUser(args[0], audit.ActionAccountVerify, func(svc *account.Service) (*entity.User, error) {
+				return svc.VerifyUserService(args[0])
+			})
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not verify user")
+			}
+
+			log.Info().Msgf("%s is verified", user.Email)
+		},
+	}
+}
+
+func userDisableCmd() *cobra.Command {
+	var enable bool
+	var command = &cobra.Command{
+		Use:   "disable <email>",
+		Short: "Stop an account from signing in",
+		Long: "Stop an account from signing in. Requests with tokens issued before are refused too. " +
+			"Use --enable to let the account sign in again.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			action := audit.ActionAccountDisable
+			if enable {
+				action = audit.ActionAccountEnable
+			}
+
+			user, err := updateUser(args[0], action, func(svc *account.Service) (*entity.User, error) {
+				return svc.DisableUserService(args[0], !enable)
+			})
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not disable user")
+			}
+
+			if user.DisabledAt == nil {
+				log.Info().Msgf("%s is enabled", user.Email)
+				return
+			}
+			log.Info().Msgf("%s is disabled since %s", user.Email, user.DisabledAt.Format(time.RFC3339))
+		},
+	}
+
+	command.Flags().BoolVar(&enable, "enable", false, "Enable a disabled account instead")
+	return command
+}
+
+func userDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <email>",
+		Short: "Soft delete an account",
+		Long: "Soft delete an account so it can no longer sign in. Accounts with bookings for upcoming events are refused. " +
+			"The account can be restored from the trash until it is purged.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			user, err := updateUser(args[0], audit.ActionAccountDelete, func(svc *account.Service) (*entity.User, error) {
+				return svc.DeleteUserService(args[0])
+			})
+			if err != nil {
+				log.Fatal().Err(err).Msg("could not delete user")
+			}
+
+			log.Info().Msgf("%s is deleted", user.Email)
+		},
+	}
+}
@@ -1,10 +1,14 @@
 package account
 
 import (
+	"event-booking/internal/api/query"
 	"event-booking/internal/api/responses"
 	"event-booking/internal/api/validator"
+	"event-booking/internal/audit"
 	"event-booking/internal/auth"
 	"event-booking/internal/entity"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,13 +17,15 @@ import (
 type httpHandler struct {
 	svc       *Service
 	jwt       *auth.JwtService
+	audit     *audit.Service
 	validator *validator.Validator
 }
 
-func NewHttpHandler(svc *Service, jwt *auth.JwtService, validator *validator.Validator) *httpHandler {
+func NewHttpHandler(svc *Service, jwt *auth.JwtService, audit *audit.Service, validator *validator.Validator) *httpHandler {
 	return &httpHandler{
 		svc:       svc,
 		jwt:       jwt,
+		audit:     audit,
 		validator: validator,
 	}
 }
@@ -28,17 +34,16 @@ type SignUpPayload struct {
 	Name     string `json:"name" validate:"required,min=3,max=50,name"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6,max=50"`
-	Role     string `json:"role" validate:"omitempty,oneof=admin user" default:"user"`
 }
 
 func (h *httpHandler) SignUpUserHandler(c *fiber.Ctx) error {
 	user := new(SignUpPayload)
 	if err := c.BodyParser(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	newUser := &entity.User{
@@ -47,13 +52,8 @@ func (h *httpHandler) SignUpUserHandler(c *fiber.Ctx) error {
 		Password: user.Password,
 	}
 
-	if user.Role != "" {
-		newUser.Role = user.Role
-	}
-
-	err := h.svc.SignUpUserService(newUser)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+	if err := h.svc.SignUpUserService(newUser); err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.NewSuccessResponse("User created successfully"))
@@ -67,11 +67,11 @@ type SignInPayload struct {
 func (h *httpHandler) SignInUserHandler(c *fiber.Ctx) error {
 	user := new(SignInPayload)
 	if err := c.BodyParser(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	userEntity := &entity.User{
@@ -81,16 +81,18 @@ func (h *httpHandler) SignInUserHandler(c *fiber.Ctx) error {
 
 	authenticatedUser, err := h.svc.SignInUserService(userEntity)
 	if err != nil {
-		if err.Error() == "user is not verified" {
-			return c.Status(fiber.StatusUnauthorized).JSON(responses.NewErrorResponse("Please verify your email"))
-		} else {
-			return c.Status(fiber.StatusUnauthorized).JSON(responses.NewErrorResponse("Invalid email or password"))
-		}
+		h.audit.Record(audit.FromRequest(c, audit.ActionSignInFailed, audit.TargetUser, user.Email))
+		return err
 	}
 
+	entry := audit.FromRequest(c, audit.ActionSignIn, audit.TargetUser, authenticatedUser.ID.String())
+	entry.ActorID = &authenticatedUser.ID
+	entry.ActorRole = authenticatedUser.Role
+	h.audit.Record(entry)
+
 	token, err := h.jwt.CreateToken(authenticatedUser.ID, authenticatedUser.Role)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
 	c.Cookie(&fiber.Cookie{
@@ -122,23 +124,33 @@ func (h *httpHandler) GetUserByIDHandler(c *fiber.Ctx) error {
 
 	user, err := h.svc.FindByIDService(userID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.NewErrorResponse("User not found"))
+		return err
 	}
 
-	userDTO := responses.UserResponseObject{ID: user.ID, Email: user.Email, Role: user.Role}
+	h.audit.Record(audit.FromRequest(c, audit.ActionAccountView, audit.TargetUser, user.ID.String()))
 
 	return c.Status(fiber.StatusOK).JSON(responses.DataResponse{
 		Message: "User found",
-		Data:    userDTO,
+		Data:    newAccountResponse(user),
 	})
 }
 
 func (h *httpHandler) RefreshTokenHandler(c *fiber.Ctx) error {
-	token := c.Cookies("jwt")
+	claims, err := h.jwt.ValidateToken(c.Cookies("jwt"))
+	if err != nil {
+		return auth.ErrUnauthorized
+	}
+
+	// The new token carries the role stored on the user, and users that were
+	// deleted, disabled or erased since signing in cannot refresh.
+	user, err := h.svc.FindActiveUserService(claims.UserID)
+	if err != nil {
+		return auth.ErrUnauthorized
+	}
 
-	newToken, err := h.jwt.RefreshToken(token)
+	newToken, err := h.jwt.CreateToken(user.ID, user.Role)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return err
 	}
 
 	c.Cookie(&fiber.Cookie{
@@ -159,11 +171,11 @@ type UpdateUserPayload struct {
 func (h *httpHandler) UpdateUserHandler(c *fiber.Ctx) error {
 	user := new(UpdateUserPayload)
 	if err := c.BodyParser(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	newUser := &entity.User{
@@ -172,11 +184,19 @@ func (h *httpHandler) UpdateUserHandler(c *fiber.Ctx) error {
 		Password: user.Password,
 	}
 
-	err := h.svc.UpdateUserService(newUser)
+	before, err := h.svc.FindByEmailService(user.Email)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
+	if err := h.svc.UpdateUserService(newUser); err != nil {
+		return err
+	}
+
+	entry := audit.FromRequest(c, audit.ActionAccountUpdate, audit.TargetUser, newUser.ID.String())
+	entry.Changes = audit.Diff(before, newUser)
+	h.audit.Record(entry)
+
 	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("User updated successfully"))
 }
 
@@ -187,16 +207,15 @@ type RequestVerificationCodePayload struct {
 func (h *httpHandler) RequestVerificationCodeHandler(c *fiber.Ctx) error {
 	email := new(RequestVerificationCodePayload)
 	if err := c.BodyParser(email); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(email); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
-	err := h.svc.GenerateVerificationCode(email.Email)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+	if err := h.svc.GenerateVerificationCode(email.Email); err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Verification code sent to your email successfully"))
@@ -210,13 +229,185 @@ type ValidateVerificationCodePayload struct {
 func (h *httpHandler) ValidateVerificationCodeHandler(c *fiber.Ctx) error {
 	var payload ValidateVerificationCodePayload
 	if err := c.BodyParser(&payload); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
-	err := h.svc.ValidateVerificationCode(payload.Email, payload.Code)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+	if err := h.validator.ValidateStruct(&payload); err != nil {
+		return err
+	}
+
+	if err := h.svc.ValidateVerificationCode(payload.Email, payload.Code); err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Verification code validated successfully"))
 }
+
+func (h *httpHandler) FindOwnAccountHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+
+	user, err := h.svc.FindByIDService(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("User found", newAccountResponse(user)))
+}
+
+type UpdateProfilePayload struct {
+	Name     *string `json:"name" validate:"omitempty,min=3,max=50,name"`
+	Email    *string `json:"email" validate:"omitempty,email"`
+	Password *string `json:"password" validate:"omitempty,min=6,max=50"`
+	// CurrentPassword is required with Password
+	CurrentPassword string `json:"current_password" validate:"max=50"`
+}
+
+// UpdateOwnAccountHandler changes the name, email or password of the
+// signed-in user. A new email only replaces the current one once the code
+// sent to it is validated at POST /api/account/validate.
+func (h *httpHandler) UpdateOwnAccountHandler(c *fiber.Ctx) error {
+	payload := new(UpdateProfilePayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("userID").(string)
+
+	before, err := h.svc.FindByIDService(userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.svc.UpdateProfileService(userID, ProfileUpdate{
+		Name:            payload.Name,
+		Email:           payload.Email,
+		Password:        payload.Password,
+		CurrentPassword: payload.CurrentPassword,
+	})
+	if err != nil {
+		return err
+	}
+
+	entry := audit.FromRequest(c, audit.ActionAccountUpdate, audit.TargetUser, user.ID.String())
+	entry.Changes = audit.Diff(before, user)
+	h.audit.Record(entry)
+
+	message := "Account updated successfully"
+	if user.PendingEmail != "" && user.PendingEmail != before.PendingEmail {
+		message = "Account updated, verify your new email with the code sent to it"
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse(message, newAccountResponse(user)))
+}
+
+var userQueryConfig = query.Config{
+	Sorts:       []string{"created_at", "name", "email"},
+	Filters:     []string{"role", "is_verified"},
+	DefaultSort: "-created_at",
+}
+
+// FindAllUserHandler lists the accounts for admins, e.g.
+// ?q=john&role=admin&disabled=true.
+func (h *httpHandler) FindAllUserHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, userQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	search := entity.UserSearch{Query: c.Query("q")}
+	if v := c.Query("disabled"); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%w: disabled must be true or false", query.ErrInvalidOptions)
+		}
+		search.Disabled = &disabled
+	}
+
+	users, total, err := h.svc.FindAllUserService(search, opts)
+	if err != nil {
+		return err
+	}
+
+	userResponses := []responses.AccountResponseObject{}
+	for i := range users {
+		userResponses = append(userResponses, newAccountResponse(&users[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Users found", userResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) DisableUserHandler(c *fiber.Ctx) error {
+	return h.updateUser(c, audit.ActionAccountDisable, func(email string) (*entity.User, error) {
+		return h.svc.DisableUserService(email, true)
+	})
+}
+
+func (h *httpHandler) EnableUserHandler(c *fiber.Ctx) error {
+	return h.updateUser(c, audit.ActionAccountEnable, func(email string) (*entity.User, error) {
+		return h.svc.DisableUserService(email, false)
+	})
+}
+
+type SetRolePayload struct {
+	Role string `json:"role" validate:"required"`
+}
+
+// SetRoleHandler changes the role of a user; it applies from their next
+// request.
+func (h *httpHandler) SetRoleHandler(c *fiber.Ctx) error {
+	payload := new(SetRolePayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	return h.updateUser(c, audit.ActionAccountRole, func(email string) (*entity.User, error) {
+		return h.svc.SetRoleService(email, payload.Role)
+	})
+}
+
+// updateUser runs an admin change on the user with the id in the path and
+// audits it. Admins cannot change their own account this way, so they
+// cannot lock themselves out.
+func (h *httpHandler) updateUser(c *fiber.Ctx, action string, update func(email string) (*entity.User, error)) error {
+	before, err := h.svc.FindByIDService(c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	if userID, _ := c.Locals("userID").(string); before.ID.String() == userID {
+		return ErrOwnAccount
+	}
+
+	user, err := update(before.Email)
+	if err != nil {
+		return err
+	}
+
+	entry := audit.FromRequest(c, action, audit.TargetUser, user.ID.String())
+	entry.Changes = audit.Diff(before, user)
+	h.audit.Record(entry)
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("User updated successfully", newAccountResponse(user)))
+}
+
+func newAccountResponse(user *entity.User) responses.AccountResponseObject {
+	return responses.AccountResponseObject{
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		PendingEmail: user.PendingEmail,
+		Role:         user.Role,
+		IsVerified:   user.IsVerified,
+		DisabledAt:   user.DisabledAt,
+		ErasedAt:     user.ErasedAt,
+		CreatedAt:    user.CreatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package account
+
+import (
+	"event-booking/internal/account/mocks"
+	"event-booking/internal/api/validator"
+	"event-booking/internal/audit"
+	auditmocks "event-booking/internal/audit/mocks"
+	"event-booking/internal/entity"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDisableUserHandler(t *testing.T) {
+	adminID := uuid.New()
+	disabledAt := time.Now()
+
+	tests := []struct {
+		name       string
+		path       string
+		handler    func(*httpHandler, *fiber.Ctx) error
+		disabledAt *time.Time
+		action     string
+	}{
+		{"disable", "disable", (*httpHandler).DisableUserHandler, nil, audit.ActionAccountDisable},
+		{"enable", "enable", (*httpHandler).EnableUserHandler, &disabledAt, audit.ActionAccountEnable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			newUser := func() *entity.User {
+				return &entity.User{ID: userID, Email: "johndoe@gmail.com", Role: "user", DisabledAt: tt.disabledAt}
+			}
+
+			mockRepo := mocks.NewRepository(t)
+			mockRepo.On("FindByID", userID.String()).Return(newUser(), nil).Once()
+			mockRepo.On("FindByEmail", "johndoe@gmail.com").Return(newUser(), nil).Once()
+			mockRepo.On("SaveUser", mock.Anything).Return(nil).Once()
+
+			mockAuditRepo := auditmocks.NewRepository(t)
+			mockAuditRepo.On("Create", mock.MatchedBy(func(entry *entity.AuditEntry) bool {
+				return entry.Action == tt.action && entry.TargetID == userID.String() && *entry.ActorID == adminID
+			})).Return(nil).Once()
+
+			h := NewHttpHandler(NewService(mockRepo, nil), nil, audit.NewService(mockAuditRepo, 0), validator.NewValidator())
+
+			app := fiber.New()
+			app.Post("/api/admin/users/:id/"+tt.path, func(c *fiber.Ctx) error {
+				c.Locals("userID", adminID.String())
+				c.Locals("role", "admin")
+				return c.Next()
+			}, func(c *fiber.Ctx) error {
+				return tt.handler(h, c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("POST", "/api/admin/users/"+userID.String()+"/"+tt.path, nil))
+
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		})
+	}
+}
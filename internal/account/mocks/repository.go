@@ -5,6 +5,8 @@ package mocks
 import (
 	entity "event-booking/internal/entity"
 
+	query "event-booking/internal/api/query"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,6 +15,34 @@ type Repository struct {
 	mock.Mock
 }
 
+// CountUpcomingBookings provides a mock function with given fields: userID
+func (_m *Repository) CountUpcomingBookings(userID string) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUpcomingBookings")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateAccount provides a mock function with given fields: user
 func (_m *Repository) CreateAccount(user *entity.User) error {
 	ret := _m.Called(user)
@@ -31,6 +61,89 @@ func (_m *Repository) CreateAccount(user *entity.User) error {
 	return r0
 }
 
+// Delete provides a mock function with given fields: user
+func (_m *Repository) Delete(user *entity.User) error {
+	ret := _m.Called(user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.User) error); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Erase provides a mock function with given fields: user, addresses
+func (_m *Repository) Erase(user *entity.User, addresses []string) (int64, error) {
+	ret := _m.Called(user, addresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Erase")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entity.User, []string) (int64, error)); ok {
+		return rf(user, addresses)
+	}
+	if rf, ok := ret.Get(0).(func(*entity.User, []string) int64); ok {
+		r0 = rf(user, addresses)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*entity.User, []string) error); ok {
+		r1 = rf(user, addresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAll provides a mock function with given fields: search, opts
+func (_m *Repository) FindAll(search entity.UserSearch, opts query.Options) ([]entity.User, int64, error) {
+	ret := _m.Called(search, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAll")
+	}
+
+	var r0 []entity.User
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(entity.UserSearch, query.Options) ([]entity.User, int64, error)); ok {
+		return rf(search, opts)
+	}
+	if rf, ok := ret.Get(0).(func(entity.UserSearch, query.Options) []entity.User); ok {
+		r0 = rf(search, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.UserSearch, query.Options) int64); ok {
+		r1 = rf(search, opts)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(entity.UserSearch, query.Options) error); ok {
+		r2 = rf(search, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FindByEmail provides a mock function with given fields: email
 func (_m *Repository) FindByEmail(email string) (*entity.User, error) {
 	ret := _m.Called(email)
@@ -91,6 +204,36 @@ func (_m *Repository) FindByID(id string) (*entity.User, error) {
 	return r0, r1
 }
 
+// FindByPendingEmail provides a mock function with given fields: email
+func (_m *Repository) FindByPendingEmail(email string) (*entity.User, error) {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByPendingEmail")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entity.User, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entity.User); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveUser provides a mock function with given fields: user
 func (_m *Repository) SaveUser(user *entity.User) error {
 	ret := _m.Called(user)
@@ -1,8 +1,13 @@
 package account
 
 import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/audit"
 	"event-booking/internal/entity"
+	"event-booking/internal/review"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -41,12 +46,142 @@ func (r *repo) FindByEmail(email string) (*entity.User, error) {
 	return user, nil
 }
 
-func (r *repo) DeleteByEmail(email string) error {
-	if err := r.db.Where("email = ?", email).Delete(&entity.User{}).Error; err != nil {
-		return err
+// FindByPendingEmail finds the user changing their email to email.
+func (r *repo) FindByPendingEmail(email string) (*entity.User, error) {
+	user := new(entity.User)
+	if err := r.db.Where("pending_email = ?", email).First(user).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return user, nil
+}
+
+func (r *repo) FindAll(search entity.UserSearch, opts query.Options) ([]entity.User, int64, error) {
+	db := r.db.Model(&entity.User{}).Scopes(opts.Filter)
+	if search.Query != "" {
+		pattern := "%" + search.Query + "%"
+		db = db.Where("name ILIKE ? OR email ILIKE ?", pattern, pattern)
+	}
+	if search.Disabled != nil {
+		if *search.Disabled {
+			db = db.Where("disabled_at IS NOT NULL")
+		} else {
+			db = db.Where("disabled_at IS NULL")
+		}
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []entity.User
+	if err := db.Scopes(opts.Paginate).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// CountUpcomingBookings counts the user's confirmed bookings for events that
+// have not ended yet.
+func (r *repo) CountUpcomingBookings(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Booking{}).
+		Joins("JOIN events ON events.id = bookings.event_id").
+		Where("bookings.user_id = ? AND bookings.status = ? AND events.end_date > ?", userID, entity.BookingStatusConfirmed, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Delete soft deletes the user.
+func (r *repo) Delete(user *entity.User) error {
+	return r.db.Delete(user).Error
+}
+
+// Erase saves the anonymized user and deletes the rows that only hold
+// personal data: the user's reviews with their reports, votes and replies,
+// the user's own votes, reports and replies, notifications, preferences,
+// reminders, calendar feeds, organization memberships, and the emails sent
+// to any of addresses with their attachments. The audit entries about the
+// user are redacted, see audit.Redact. Bookings are kept for accounting and
+// stay linked to the anonymized user; Erase returns how many there are.
+// Ratings and vote counts the deleted rows counted towards are recomputed.
+func (r *repo) Erase(user *entity.User, addresses []string) (int64, error) {
+	var bookings int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(user).Error; err != nil {
+			return err
+		}
+
+		reviews := tx.Unscoped().Model(&entity.Review{}).Select("id").Where("user_id = ?", user.ID)
+
+		var ratedEventIDs, votedReviewIDs []uuid.UUID
+		err := tx.Unscoped().Model(&entity.Review{}).Distinct("event_id").
+			Where("user_id = ?", user.ID).
+			Pluck("event_id", &ratedEventIDs).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entity.ReviewVote{}).Distinct("review_id").
+			Where("user_id = ? AND review_id NOT IN (?)", user.ID, reviews).
+			Pluck("review_id", &votedReviewIDs).Error
+		if err != nil {
+			return err
+		}
+
+		steps := []struct {
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			{&entity.ReviewReport{}, "review_id IN (?) OR user_id = ?", []interface{}{reviews, user.ID}},
+			{&entity.ReviewVote{}, "review_id IN (?) OR user_id = ?", []interface{}{reviews, user.ID}},
+			{&entity.ReviewReply{}, "review_id IN (?) OR user_id = ?", []interface{}{reviews, user.ID}},
+			{&entity.Review{}, "user_id = ?", []interface{}{user.ID}},
+			{&entity.EventReminder{}, "user_id = ?", []interface{}{user.ID}},
+			{&entity.Notification{}, "user_id = ?", []interface{}{user.ID}},
+			{&entity.NotificationPreference{}, "user_id = ?", []interface{}{user.ID}},
+			{&entity.CalendarFeed{}, "user_id = ?", []interface{}{user.ID}},
+			{&entity.OrganizationMember{}, "user_id = ?", []interface{}{user.ID}},
+			// attachments, like the data export ZIP, cascade with the emails
+			{&entity.EmailMessage{}, `"to" IN ?`, []interface{}{addresses}},
+		}
+		for _, step := range steps {
+			if err := tx.Unscoped().Where(step.query, step.args...).Delete(step.model).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, eventID := range ratedEventIDs {
+			if err := review.RefreshRating(tx, eventID); err != nil {
+				return err
+			}
+		}
+
+		for _, reviewID := range votedReviewIDs {
+			if err := review.RefreshVotes(tx, reviewID); err != nil {
+				return err
+			}
+		}
+
+		if err := audit.Redact(tx, user.ID, addresses, user.Email); err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.Booking{}).Where("user_id = ?", user.ID).Count(&bookings).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return bookings, nil
 }
 
 func (r *repo) FindByID(id string) (*entity.User, error) {
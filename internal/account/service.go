@@ -1,14 +1,19 @@
 package account
 
 import (
-	"event-booking/internal/email"
+	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/apperror"
 	"event-booking/internal/entity"
+	"event-booking/internal/notification"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/exp/rand"
+	"gorm.io/gorm"
 )
 
 //go:generate mockery --case snake --name Repository
@@ -16,19 +21,49 @@ import (
 type Repository interface {
 	CreateAccount(user *entity.User) error
 	FindByEmail(email string) (*entity.User, error)
+	FindByPendingEmail(email string) (*entity.User, error)
 	FindByID(id string) (*entity.User, error)
+	FindAll(search entity.UserSearch, opts query.Options) ([]entity.User, int64, error)
 	SaveUser(user *entity.User) error
+	CountUpcomingBookings(userID string) (int64, error)
+	Delete(user *entity.User) error
+	Erase(user *entity.User, addresses []string) (int64, error)
 }
 
+// Notifier sends transactional messages such as verification codes.
+//
+//go:generate mockery --case snake --name Notifier
+type Notifier interface {
+	SendTransactional(notification notification.Notification) error
+}
+
+var (
+	ErrUserNotFound       = apperror.NotFound("user_not_found", "user not found")
+	ErrEmailTaken         = apperror.Conflict("email_taken", "email is already registered")
+	ErrInvalidCredentials = apperror.Unauthorized("invalid_credentials", "invalid email or password")
+	ErrNotVerified        = apperror.Forbidden("email_not_verified", "please verify your email")
+	ErrAccountDisabled    = apperror.Forbidden("account_disabled", "account has been disabled")
+	ErrInvalidRole        = apperror.Validation("invalid_role", "role must be admin or user")
+	ErrUserHasBookings    = apperror.Conflict("user_has_bookings", "user has confirmed bookings for upcoming events")
+	ErrAccountErased      = apperror.Conflict("account_erased", "account has already been erased")
+	ErrOwnAccount         = apperror.Forbidden("own_account", "admins cannot disable or demote their own account")
+	ErrPasswordRequired   = apperror.Validation("current_password_required", "the current password is required to change the password")
+	ErrWrongPassword      = apperror.Validation("invalid_current_password", "current password is incorrect")
+	ErrInvalidCode        = apperror.Validation("invalid_verification_code", "verification code is invalid")
+	ErrCodeExpired        = apperror.Validation("verification_code_expired", "verification code has expired")
+	// ErrNoAttemptsLeft means a new code has to be requested.
+	ErrNoAttemptsLeft = apperror.RateLimited("verification_attempts_exhausted", "no verification attempts left")
+)
+
 type Service struct {
-	repo         Repository
-	emailService *email.EmailService
+	repo     Repository
+	notifier Notifier
 }
 
-func NewService(repo Repository, emailService *email.EmailService) *Service {
+func NewService(repo Repository, notifier Notifier) *Service {
 	return &Service{
-		repo:         repo,
-		emailService: emailService,
+		repo:     repo,
+		notifier: notifier,
 	}
 }
 
@@ -44,6 +79,9 @@ func (s *Service) SignUpUserService(user *entity.User) error {
 	err = s.repo.CreateAccount(user)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrEmailTaken.Wrap(err)
+		}
 		return err
 	}
 
@@ -54,7 +92,7 @@ func (s *Service) UpdateUserService(user *entity.User) error {
 	userDB, err := s.repo.FindByEmail(user.Email)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return err
+		return apperror.IfNotFound(err, ErrUserNotFound)
 	}
 
 	user.ID = userDB.ID
@@ -77,52 +115,379 @@ func (s *Service) UpdateUserService(user *entity.User) error {
 }
 
 func (s *Service) SignInUserService(user *entity.User) (*entity.User, error) {
+	// unknown emails and wrong passwords look the same to the client
 	userDB, err := s.repo.FindByEmail(user.Email)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials.Wrap(err)
+		}
 		return nil, err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(userDB.Password), []byte(user.Password))
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, ErrInvalidCredentials.Wrap(err)
+	}
+
+	if userDB.DisabledAt != nil {
+		return nil, ErrAccountDisabled
 	}
 
 	if !userDB.IsVerified {
-		return nil, fmt.Errorf("user is not verified")
+		return nil, ErrNotVerified
 	}
 
 	return userDB, nil
 }
 
-func (s *Service) FindByIDService(id string) (*entity.User, error) {
-	user, err := s.repo.FindByID(id)
+// ProfileUpdate changes the signed-in user's own account; nil fields are
+// left as they are.
+type ProfileUpdate struct {
+	Name     *string
+	Email    *string
+	Password *string
+	// CurrentPassword is required to change the password
+	CurrentPassword string
+}
+
+// UpdateProfileService applies the user's changes to their own account. A
+// new email is only pending until it is verified: a code is sent to it, and
+// validating the code for the new address through the verification flow
+// replaces the old one. Until then the user keeps signing in with the old
+// address. Setting the email back to the current one cancels the change.
+func (s *Service) UpdateProfileService(userID string, update ProfileUpdate) (*entity.User, error) {
+	user, err := s.repo.FindByID(userID)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrUserNotFound)
+	}
+
+	if user.ErasedAt != nil {
+		return nil, ErrAccountErased
+	}
+
+	if update.Password != nil {
+		if update.CurrentPassword == "" {
+			return nil, ErrPasswordRequired
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(update.CurrentPassword)); err != nil {
+			return nil, ErrWrongPassword.Wrap(err)
+		}
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(*update.Password), bcrypt.DefaultCost)
+		if err != nil {
+			log.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		user.Password = string(hashedPassword)
+	}
+
+	if update.Name != nil {
+		user.Name = *update.Name
+	}
+
+	var code string
+	if update.Email != nil {
+		switch {
+		case *update.Email == user.Email:
+			user.PendingEmail = ""
+		case *update.Email != user.PendingEmail:
+			_, err := s.repo.FindByEmail(*update.Email)
+			if err == nil {
+				return nil, ErrEmailTaken
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Error().Err(err).Msg(err.Error())
+				return nil, err
+			}
+
+			user.PendingEmail = *update.Email
+			code = newVerificationCode(user)
+		}
+	}
+
+	if err := s.repo.SaveUser(user); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEmailTaken.Wrap(err)
+		}
 		return nil, err
 	}
 
+	if code != "" {
+		if err := s.sendVerificationCode(user, code); err != nil {
+			return nil, err
+		}
+	}
+
 	return user, nil
 }
 
-func (s *Service) GenerateVerificationCode(email string) error {
+// FindAllUserService lists the accounts for admins.
+func (s *Service) FindAllUserService(search entity.UserSearch, opts query.Options) ([]entity.User, int64, error) {
+	users, total, err := s.repo.FindAll(search, opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// Roles are the values accepted by SetRoleService.
+var Roles = []string{"admin", "user"}
+
+// SetRoleService changes the role of the account registered with email. The
+// new role applies from the user's next request.
+func (s *Service) SetRoleService(email, role string) (*entity.User, error) {
+	if !slices.Contains(Roles, role) {
+		return nil, ErrInvalidRole
+	}
+
+	return s.updateUser(email, func(user *entity.User) {
+		user.Role = role
+	})
+}
+
+// VerifyUserService marks the account as verified without a verification code.
+func (s *Service) VerifyUserService(email string) (*entity.User, error) {
+	return s.updateUser(email, func(user *entity.User) {
+		user.IsVerified = true
+		user.EmailVerificationCode = ""
+		user.VerificationExpiry = time.Time{}
+		user.VerificationAttemptsLeft = 0
+	})
+}
+
+// DisableUserService stops the account from signing in, or lets it sign in
+// again when disabled is false. Disabling an already disabled account keeps
+// the original time.
+func (s *Service) DisableUserService(email string, disabled bool) (*entity.User, error) {
+	return s.updateUser(email, func(user *entity.User) {
+		switch {
+		case !disabled:
+			user.DisabledAt = nil
+		case user.DisabledAt == nil:
+			now := time.Now()
+			user.DisabledAt = &now
+		}
+	})
+}
+
+// DeleteUserService soft deletes the account so it can no longer sign in.
+// Its bookings and reviews stay with their events until the account is
+// purged; accounts still holding tickets for upcoming events are refused.
+func (s *Service) DeleteUserService(email string) (*entity.User, error) {
 	user, err := s.repo.FindByEmail(email)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrUserNotFound)
+	}
+
+	upcoming, err := s.repo.CountUpcomingBookings(user.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if upcoming > 0 {
+		return nil, fmt.Errorf("%w: %d bookings", ErrUserHasBookings, upcoming)
+	}
+
+	if err := s.repo.Delete(user); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// CheckErasureService refuses to erase accounts that are already erased or
+// still hold tickets for upcoming events, so the request can be turned down
+// before it is queued.
+func (s *Service) CheckErasureService(userID string) (*entity.User, error) {
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrUserNotFound)
+	}
+
+	if user.ErasedAt != nil {
+		return nil, ErrAccountErased
+	}
+
+	upcoming, err := s.repo.CountUpcomingBookings(user.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if upcoming > 0 {
+		return nil, fmt.Errorf("%w: %d bookings", ErrUserHasBookings, upcoming)
+	}
+
+	return user, nil
+}
+
+// ErasedName is the name of every erased account.
+const ErasedName = "Deleted user"
+
+// EraseUserService anonymizes the account and deletes its personal data, see
+// Repository.Erase. The row stays, disabled and without a usable password,
+// so the bookings kept for accounting point to a pseudonym instead of a
+// person. Emails to the address the account had, and to a pending one, are
+// deleted, and the user is then told at the address the account had.
+func (s *Service) EraseUserService(userID string) error {
+	user, err := s.CheckErasureService(userID)
+	if err != nil {
+		return err
+	}
+
+	address := user.Email
+	addresses := []string{user.Email}
+	if user.PendingEmail != "" {
+		addresses = append(addresses, user.PendingEmail)
+	}
+	now := time.Now()
+
+	user.Name = ErasedName
+	user.Email = fmt.Sprintf("erased-%s@users.invalid", user.ID)
+	user.PendingEmail = ""
+	user.Password = ""
+	user.Role = "user"
+	user.EmailVerificationCode = ""
+	user.VerificationExpiry = time.Time{}
+	user.VerificationAttemptsLeft = 0
+	user.IsVerified = false
+	if user.DisabledAt == nil {
+		user.DisabledAt = &now
+	}
+	user.ErasedAt = &now
+
+	bookings, err := s.repo.Erase(user, addresses)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return err
 	}
 
+	err = s.notifier.SendTransactional(notification.Notification{
+		Type:   notification.TypeAccountErased,
+		UserID: user.ID,
+		Data:   notification.AccountErasedData{Bookings: int(bookings)},
+		To:     address,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) updateUser(email string, update func(user *entity.User)) (*entity.User, error) {
+	user, err := s.repo.FindByEmail(email)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrUserNotFound)
+	}
+
+	if user.ErasedAt != nil {
+		return nil, ErrAccountErased
+	}
+
+	update(user)
+
+	if err := s.repo.SaveUser(user); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *Service) FindByIDService(id string) (*entity.User, error) {
+	user, err := s.repo.FindByID(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrUserNotFound)
+	}
+
+	return user, nil
+}
+
+// FindActiveUserService loads the user behind a token. Deleted users are not
+// found, and disabled or erased users are rejected, so their tokens stop
+// working before they expire.
+func (s *Service) FindActiveUserService(id string) (*entity.User, error) {
+	user, err := s.repo.FindByID(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrUserNotFound)
+	}
+
+	if user.ErasedAt != nil {
+		return nil, ErrAccountErased
+	}
+
+	if user.DisabledAt != nil {
+		return nil, ErrAccountDisabled
+	}
+
+	return user, nil
+}
+
+func (s *Service) FindByEmailService(email string) (*entity.User, error) {
+	user, err := s.repo.FindByEmail(email)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrUserNotFound)
+	}
+
+	return user, nil
+}
+
+// findForVerification finds the user whose email or pending email is email,
+// and reports whether it was the pending one.
+func (s *Service) findForVerification(email string) (*entity.User, bool, error) {
+	user, err := s.repo.FindByEmail(email)
+	if err == nil {
+		return user, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, false, err
+	}
+
+	user, err = s.repo.FindByPendingEmail(email)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, false, apperror.IfNotFound(err, ErrUserNotFound)
+	}
+
+	return user, true, nil
+}
+
+// newVerificationCode gives the user a fresh code with a fresh set of
+// attempts.
+func newVerificationCode(user *entity.User) string {
 	code := fmt.Sprintf("%06d", rand.Intn(1000000))
 	user.EmailVerificationCode = code
 	user.VerificationExpiry = time.Now().Add(1 * time.Hour)
 	user.VerificationAttemptsLeft = 3
 
-	if err := s.repo.SaveUser(user); err != nil {
-		return fmt.Errorf("failed to save verification code: %v", err)
-	}
+	return code
+}
 
-	err = s.emailService.SendVerificationEmail(user.Email, code)
+// sendVerificationCode emails the code to the pending email when there is
+// one, otherwise to the user's email.
+func (s *Service) sendVerificationCode(user *entity.User, code string) error {
+	err := s.notifier.SendTransactional(notification.Notification{
+		Type:   notification.TypeVerification,
+		UserID: user.ID,
+		Data:   notification.VerificationData{Code: code},
+		To:     user.PendingEmail,
+	})
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return err
@@ -131,19 +496,42 @@ func (s *Service) GenerateVerificationCode(email string) error {
 	return nil
 }
 
+// GenerateVerificationCode sends a code to email, which is either the email
+// of an account or the pending email of an account changing its email. A
+// code is only good for the address it was sent to, so asking for one for
+// the current email cancels a pending change.
+func (s *Service) GenerateVerificationCode(email string) error {
+	user, pending, err := s.findForVerification(email)
+	if err != nil {
+		return err
+	}
+
+	if !pending {
+		user.PendingEmail = ""
+	}
+	code := newVerificationCode(user)
+
+	if err := s.repo.SaveUser(user); err != nil {
+		return fmt.Errorf("failed to save verification code: %v", err)
+	}
+
+	return s.sendVerificationCode(user, code)
+}
+
+// ValidateVerificationCode verifies the account registered with email, or
+// makes email the account's email when it was pending.
 func (s *Service) ValidateVerificationCode(email, code string) error {
-	user, err := s.repo.FindByEmail(email)
+	user, pending, err := s.findForVerification(email)
 	if err != nil {
-		log.Error().Err(err).Msg(err.Error())
 		return err
 	}
 
 	if time.Now().After(user.VerificationExpiry) {
-		return fmt.Errorf("verification code has expired")
+		return ErrCodeExpired
 	}
 
 	if user.VerificationAttemptsLeft == 0 {
-		return fmt.Errorf("no attempts left")
+		return ErrNoAttemptsLeft
 	}
 
 	if user.EmailVerificationCode != code {
@@ -151,8 +539,13 @@ func (s *Service) ValidateVerificationCode(email, code string) error {
 		if err := s.repo.SaveUser(user); err != nil {
 			return fmt.Errorf("failed to save verification attempts: %v", err)
 		}
+		return fmt.Errorf("%w: %d attempts left", ErrInvalidCode, user.VerificationAttemptsLeft)
 	}
 
+	if pending {
+		user.Email = user.PendingEmail
+		user.PendingEmail = ""
+	}
 	user.IsVerified = true
 	user.EmailVerificationCode = ""
 	user.VerificationExpiry = time.Time{}
@@ -160,6 +553,9 @@ func (s *Service) ValidateVerificationCode(email, code string) error {
 	err = s.repo.SaveUser(user)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrEmailTaken.Wrap(err)
+		}
 		return err
 	}
 
@@ -3,11 +3,15 @@ package account
 import (
 	"event-booking/internal/account/mocks"
 	"event-booking/internal/entity"
+	"event-booking/internal/notification"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 func TestSignUpUser(t *testing.T) {
@@ -57,8 +61,9 @@ func TestSignInUserService(t *testing.T) {
 			email:    "johndoe@gmail.com",
 			password: "password",
 			mockReturn: &entity.User{
-				Email:    "johndoe@gmail.com",
-				Password: string(hashedPassword),
+				Email:      "johndoe@gmail.com",
+				Password:   string(hashedPassword),
+				IsVerified: true,
 			},
 			mockError:   nil,
 			expectedErr: false,
@@ -119,9 +124,16 @@ func TestUpdateUserService(t *testing.T) {
 		Password: "newpassword",
 	}
 
+	hashedAs := func(password string) interface{} {
+		return mock.MatchedBy(func(user *entity.User) bool {
+			return user.ID == mockUser.ID &&
+				bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) == nil
+		})
+	}
+
 	t.Run("update user successfully", func(t *testing.T) {
 		mockRepo.On("FindByEmail", mockUser.Email).Return(mockUser, nil).Once()
-		mockRepo.On("UpdateUser", mockNewUser).Return(nil).Once()
+		mockRepo.On("SaveUser", hashedAs("newpassword")).Return(nil).Once()
 
 		svc := NewService(mockRepo, nil)
 		err := svc.UpdateUserService(mockNewUser)
@@ -134,12 +146,522 @@ func TestUpdateUserService(t *testing.T) {
 
 	t.Run("update user error", func(t *testing.T) {
 		mockRepo.On("FindByEmail", mockUser.Email).Return(mockUser, nil).Once()
-		mockRepo.On("UpdateUser", mockNewUser).Return(assert.AnError).Once()
+		mockRepo.On("SaveUser", mock.Anything).Return(assert.AnError).Once()
 
 		svc := NewService(mockRepo, nil)
-		err := svc.UpdateUserService(mockNewUser)
+		err := svc.UpdateUserService(&entity.User{Email: mockUser.Email, Password: "newpassword"})
 		assert.Equal(t, assert.AnError, err)
 
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestValidateVerificationCode(t *testing.T) {
+	newUser := func() *entity.User {
+		return &entity.User{
+			ID:                       uuid.New(),
+			Email:                    "johndoe@gmail.com",
+			EmailVerificationCode:    "123456",
+			VerificationExpiry:       time.Now().Add(time.Hour),
+			VerificationAttemptsLeft: 3,
+		}
+	}
+
+	t.Run("valid code verifies the user", func(t *testing.T) {
+		user := newUser()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.ValidateVerificationCode(user.Email, "123456")
+
+		assert.NoError(t, err)
+		assert.True(t, user.IsVerified)
+	})
+
+	t.Run("wrong code uses an attempt", func(t *testing.T) {
+		user := newUser()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.ValidateVerificationCode(user.Email, "000000")
+
+		assert.ErrorIs(t, err, ErrInvalidCode)
+		assert.False(t, user.IsVerified)
+		assert.Equal(t, 2, user.VerificationAttemptsLeft)
+	})
+
+	t.Run("no attempts left", func(t *testing.T) {
+		user := newUser()
+		user.VerificationAttemptsLeft = 0
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.ValidateVerificationCode(user.Email, "123456")
+
+		assert.ErrorIs(t, err, ErrNoAttemptsLeft)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", "nobody@gmail.com").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("FindByPendingEmail", "nobody@gmail.com").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.ValidateVerificationCode("nobody@gmail.com", "123456")
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("valid code for the pending email changes the email", func(t *testing.T) {
+		user := newUser()
+		user.IsVerified = true
+		user.PendingEmail = "john@example.com"
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", "john@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("FindByPendingEmail", "john@example.com").Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.ValidateVerificationCode("john@example.com", "123456")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "john@example.com", user.Email)
+		assert.Empty(t, user.PendingEmail)
+		assert.True(t, user.IsVerified)
+	})
+
+	t.Run("pending email taken in the meantime", func(t *testing.T) {
+		user := newUser()
+		user.PendingEmail = "john@example.com"
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", "john@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("FindByPendingEmail", "john@example.com").Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(gorm.ErrDuplicatedKey).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.ValidateVerificationCode("john@example.com", "123456")
+
+		assert.ErrorIs(t, err, ErrEmailTaken)
+	})
+}
+
+func TestGenerateVerificationCode(t *testing.T) {
+	t.Run("code for the current email cancels a pending change", func(t *testing.T) {
+		user := &entity.User{ID: uuid.New(), Email: "johndoe@gmail.com", PendingEmail: "john@example.com"}
+
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+		mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+		mockNotifier.On("SendTransactional", mock.MatchedBy(func(n notification.Notification) bool {
+			return n.Type == notification.TypeVerification && n.To == ""
+		})).Return(nil).Once()
+
+		svc := NewService(mockRepo, mockNotifier)
+		err := svc.GenerateVerificationCode(user.Email)
+
+		assert.NoError(t, err)
+		assert.Empty(t, user.PendingEmail)
+		assert.Len(t, user.EmailVerificationCode, 6)
+	})
+
+	t.Run("code for the pending email goes to it", func(t *testing.T) {
+		user := &entity.User{ID: uuid.New(), Email: "johndoe@gmail.com", PendingEmail: "john@example.com"}
+
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+		mockRepo.On("FindByEmail", user.PendingEmail).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("FindByPendingEmail", user.PendingEmail).Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+		mockNotifier.On("SendTransactional", mock.MatchedBy(func(n notification.Notification) bool {
+			return n.To == "john@example.com"
+		})).Return(nil).Once()
+
+		svc := NewService(mockRepo, mockNotifier)
+		err := svc.GenerateVerificationCode(user.PendingEmail)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "john@example.com", user.PendingEmail)
+	})
+}
+
+func TestUpdateProfileService(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	newUser := func() *entity.User {
+		return &entity.User{ID: uuid.New(), Name: "John Doe", Email: "johndoe@gmail.com", Password: string(hashedPassword), IsVerified: true}
+	}
+	ptr := func(s string) *string { return &s }
+
+	t.Run("changes the name", func(t *testing.T) {
+		user := newUser()
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		updated, err := svc.UpdateProfileService(user.ID.String(), ProfileUpdate{Name: ptr("Johnny")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Johnny", updated.Name)
+	})
+
+	t.Run("password change needs the current password", func(t *testing.T) {
+		user := newUser()
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.UpdateProfileService(user.ID.String(), ProfileUpdate{Password: ptr("newpassword")})
+
+		assert.ErrorIs(t, err, ErrPasswordRequired)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		user := newUser()
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.UpdateProfileService(user.ID.String(), ProfileUpdate{Password: ptr("newpassword"), CurrentPassword: "wrong"})
+
+		assert.ErrorIs(t, err, ErrWrongPassword)
+	})
+
+	t.Run("changes the password", func(t *testing.T) {
+		user := newUser()
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.UpdateProfileService(user.ID.String(), ProfileUpdate{Password: ptr("newpassword"), CurrentPassword: "password"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("newpassword")))
+	})
+
+	t.Run("new email is pending until verified", func(t *testing.T) {
+		user := newUser()
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("FindByEmail", "john@example.com").Return(nil, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+		mockNotifier.On("SendTransactional", mock.MatchedBy(func(n notification.Notification) bool {
+			return n.Type == notification.TypeVerification && n.To == "john@example.com"
+		})).Return(nil).Once()
+
+		svc := NewService(mockRepo, mockNotifier)
+		updated, err := svc.UpdateProfileService(user.ID.String(), ProfileUpdate{Email: ptr("john@example.com")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "johndoe@gmail.com", updated.Email)
+		assert.Equal(t, "john@example.com", updated.PendingEmail)
+		assert.True(t, updated.IsVerified)
+		assert.Len(t, updated.EmailVerificationCode, 6)
+	})
+
+	t.Run("new email taken", func(t *testing.T) {
+		user := newUser()
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("FindByEmail", "jane@gmail.com").Return(&entity.User{ID: uuid.New()}, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.UpdateProfileService(user.ID.String(), ProfileUpdate{Email: ptr("jane@gmail.com")})
+
+		assert.ErrorIs(t, err, ErrEmailTaken)
+	})
+
+	t.Run("current email cancels the pending one", func(t *testing.T) {
+		user := newUser()
+		user.PendingEmail = "john@example.com"
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		updated, err := svc.UpdateProfileService(user.ID.String(), ProfileUpdate{Email: ptr(user.Email)})
+
+		assert.NoError(t, err)
+		assert.Empty(t, updated.PendingEmail)
+	})
+}
+
+func TestSignInDisabledUser(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	disabledAt := time.Now()
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FindByEmail", "johndoe@gmail.com").Return(&entity.User{
+		Email:      "johndoe@gmail.com",
+		Password:   string(hashedPassword),
+		IsVerified: true,
+		DisabledAt: &disabledAt,
+	}, nil).Once()
+
+	svc := NewService(mockRepo, nil)
+	_, err := svc.SignInUserService(&entity.User{Email: "johndoe@gmail.com", Password: "password"})
+
+	assert.ErrorIs(t, err, ErrAccountDisabled)
+}
+
+func TestFindActiveUserService(t *testing.T) {
+	t.Run("active user", func(t *testing.T) {
+		user := &entity.User{ID: uuid.New(), Role: "admin"}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		found, err := svc.FindActiveUserService(user.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, found)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", "deleted").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.FindActiveUserService("deleted")
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("disabled user", func(t *testing.T) {
+		disabledAt := time.Now()
+		user := &entity.User{ID: uuid.New(), DisabledAt: &disabledAt}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.FindActiveUserService(user.ID.String())
+
+		assert.ErrorIs(t, err, ErrAccountDisabled)
+	})
+
+	t.Run("erased user", func(t *testing.T) {
+		erasedAt := time.Now()
+		user := &entity.User{ID: uuid.New(), DisabledAt: &erasedAt, ErasedAt: &erasedAt}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.FindActiveUserService(user.ID.String())
+
+		assert.ErrorIs(t, err, ErrAccountErased)
+	})
+}
+
+func TestSetRoleService(t *testing.T) {
+	t.Run("promotes the user", func(t *testing.T) {
+		user := &entity.User{Email: "johndoe@gmail.com", Role: "user"}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+		mockRepo.On("SaveUser", user).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.SetRoleService(user.Email, "admin")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", user.Role)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		svc := NewService(mocks.NewRepository(t), nil)
+		_, err := svc.SetRoleService("johndoe@gmail.com", "root")
+
+		assert.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", "nobody@gmail.com").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.SetRoleService("nobody@gmail.com", "admin")
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("erased user", func(t *testing.T) {
+		erasedAt := time.Now()
+		user := &entity.User{Email: "erased@users.invalid", Role: "user", ErasedAt: &erasedAt}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.SetRoleService(user.Email, "admin")
+
+		assert.ErrorIs(t, err, ErrAccountErased)
+		assert.Equal(t, "user", user.Role)
+	})
+}
+
+func TestVerifyUserService(t *testing.T) {
+	user := &entity.User{
+		Email:                    "johndoe@gmail.com",
+		EmailVerificationCode:    "123456",
+		VerificationAttemptsLeft: 3,
+	}
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+	mockRepo.On("SaveUser", user).Return(nil).Once()
+
+	svc := NewService(mockRepo, nil)
+	_, err := svc.VerifyUserService(user.Email)
+
+	assert.NoError(t, err)
+	assert.True(t, user.IsVerified)
+	assert.Empty(t, user.EmailVerificationCode)
+}
+
+func TestDisableUserService(t *testing.T) {
+	user := &entity.User{Email: "johndoe@gmail.com"}
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FindByEmail", user.Email).Return(user, nil)
+	mockRepo.On("SaveUser", user).Return(nil)
+
+	svc := NewService(mockRepo, nil)
+
+	_, err := svc.DisableUserService(user.Email, true)
+	assert.NoError(t, err)
+	if assert.NotNil(t, user.DisabledAt) {
+		disabledAt := *user.DisabledAt
+
+		_, err = svc.DisableUserService(user.Email, true)
+		assert.NoError(t, err)
+		assert.Equal(t, disabledAt, *user.DisabledAt)
+	}
+
+	_, err = svc.DisableUserService(user.Email, false)
+	assert.NoError(t, err)
+	assert.Nil(t, user.DisabledAt)
+}
+
+func TestDeleteUserService(t *testing.T) {
+	user := &entity.User{ID: uuid.New(), Email: "johndoe@gmail.com"}
+
+	t.Run("deletes user", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+		mockRepo.On("CountUpcomingBookings", user.ID.String()).Return(int64(0), nil).Once()
+		mockRepo.On("Delete", user).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		deleted, err := svc.DeleteUserService(user.Email)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, deleted)
+	})
+
+	t.Run("user with upcoming bookings", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", user.Email).Return(user, nil).Once()
+		mockRepo.On("CountUpcomingBookings", user.ID.String()).Return(int64(2), nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.DeleteUserService(user.Email)
+
+		assert.ErrorIs(t, err, ErrUserHasBookings)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByEmail", user.Email).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.DeleteUserService(user.Email)
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestEraseUserService(t *testing.T) {
+	newUser := func() *entity.User {
+		return &entity.User{ID: uuid.New(), Name: "John Doe", Email: "johndoe@gmail.com", Password: "hash", Role: "admin", IsVerified: true}
+	}
+
+	t.Run("anonymizes user and emails the old address", func(t *testing.T) {
+		user := newUser()
+		user.PendingEmail = "johnny@gmail.com"
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("CountUpcomingBookings", user.ID.String()).Return(int64(0), nil).Once()
+		mockRepo.On("Erase", user, []string{"johndoe@gmail.com", "johnny@gmail.com"}).Return(int64(3), nil).Once()
+		mockNotifier.On("SendTransactional", notification.Notification{
+			Type:   notification.TypeAccountErased,
+			UserID: user.ID,
+			Data:   notification.AccountErasedData{Bookings: 3},
+			To:     "johndoe@gmail.com",
+		}).Return(nil).Once()
+
+		svc := NewService(mockRepo, mockNotifier)
+		err := svc.EraseUserService(user.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, ErasedName, user.Name)
+		assert.Equal(t, "erased-"+user.ID.String()+"@users.invalid", user.Email)
+		assert.Empty(t, user.PendingEmail)
+		assert.Empty(t, user.Password)
+		assert.Equal(t, "user", user.Role)
+		assert.False(t, user.IsVerified)
+		assert.NotNil(t, user.DisabledAt)
+		assert.NotNil(t, user.ErasedAt)
+	})
+
+	t.Run("already erased", func(t *testing.T) {
+		user := newUser()
+		erasedAt := time.Now()
+		user.ErasedAt = &erasedAt
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.EraseUserService(user.ID.String())
+
+		assert.ErrorIs(t, err, ErrAccountErased)
+	})
+
+	t.Run("user with upcoming bookings", func(t *testing.T) {
+		user := newUser()
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("CountUpcomingBookings", user.ID.String()).Return(int64(1), nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.EraseUserService(user.ID.String())
+
+		assert.ErrorIs(t, err, ErrUserHasBookings)
+		assert.Equal(t, "johndoe@gmail.com", user.Email)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindByID", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.EraseUserService("missing")
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"errors"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/apperror"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+var statusByKind = map[apperror.Kind]int{
+	apperror.KindNotFound:     fiber.StatusNotFound,
+	apperror.KindConflict:     fiber.StatusConflict,
+	apperror.KindValidation:   fiber.StatusBadRequest,
+	apperror.KindUnauthorized: fiber.StatusUnauthorized,
+	apperror.KindForbidden:    fiber.StatusForbidden,
+	apperror.KindRateLimited:  fiber.StatusTooManyRequests,
+}
+
+// ErrorHandler writes every error a handler returns as an RFC 7807 problem.
+// Domain errors keep their code and message, fiber errors such as unknown
+// routes keep their status and anything else is an internal error whose
+// details only go to the log.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	code := "internal_error"
+	detail := http.StatusText(status)
+
+	var appErr *apperror.Error
+	var fiberErr *fiber.Error
+	if errors.As(err, &appErr) {
+		status = statusByKind[appErr.Kind]
+		code = appErr.Code
+		detail = err.Error()
+		// a wrapped cause, e.g. the storage error behind a not found, is
+		// for the log only
+		if appErr.Err != nil {
+			detail = appErr.Message
+		}
+	} else if errors.As(err, &fiberErr) {
+		status = fiberErr.Code
+		code = strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+		detail = fiberErr.Message
+	}
+
+	if status >= fiber.StatusInternalServerError {
+		log.Error().Err(err).Str("path", c.Path()).Msg(err.Error())
+	}
+
+	return c.Status(status).JSON(responses.NewProblemResponse(status, code, detail, c.OriginalURL()), MIMEApplicationProblemJSON)
+}
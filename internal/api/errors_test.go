@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/apperror"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestErrorHandler(t *testing.T) {
+	errEventNotFound := apperror.NotFound("event_not_found", "event not found")
+	errSalesClosed := apperror.Conflict("sales_closed", "ticket sales for this event are closed")
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+	}{
+		{"not found", errEventNotFound.Wrap(gorm.ErrRecordNotFound), fiber.StatusNotFound, "event_not_found", "event not found"},
+		{"conflict with detail", fmt.Errorf("%w: event has ended", errSalesClosed), fiber.StatusConflict, "sales_closed", "ticket sales for this event are closed: event has ended"},
+		{"validation", apperror.Validation("invalid_body", "request body could not be parsed"), fiber.StatusBadRequest, "invalid_body", "request body could not be parsed"},
+		{"unauthorized", apperror.Unauthorized("unauthorized", "authentication required"), fiber.StatusUnauthorized, "unauthorized", "authentication required"},
+		{"forbidden", apperror.Forbidden("admin_required", "admin access only"), fiber.StatusForbidden, "admin_required", "admin access only"},
+		{"rate limited", apperror.RateLimited("verification_attempts_exhausted", "no verification attempts left"), fiber.StatusTooManyRequests, "verification_attempts_exhausted", "no verification attempts left"},
+		{"fiber error", fiber.ErrMethodNotAllowed, fiber.StatusMethodNotAllowed, "method_not_allowed", "Method Not Allowed"},
+		{"internal", errors.New("pq: connection refused"), fiber.StatusInternalServerError, "internal_error", "Internal Server Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			app.Get("/api/event/:id", func(c *fiber.Ctx) error {
+				return tt.err
+			})
+
+			res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/event/42", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+			assert.Equal(t, MIMEApplicationProblemJSON, res.Header.Get(fiber.HeaderContentType))
+
+			var problem responses.ProblemResponse
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&problem))
+			assert.Equal(t, tt.status, problem.Status)
+			assert.Equal(t, tt.code, problem.Code)
+			assert.Equal(t, tt.detail, problem.Detail)
+			assert.Equal(t, "/api/event/42", problem.Instance)
+		})
+	}
+}
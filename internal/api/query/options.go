@@ -0,0 +1,147 @@
+package query
+
+import (
+	"event-booking/internal/api/responses"
+	"event-booking/internal/apperror"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var ErrInvalidOptions = apperror.Validation("invalid_query", "invalid query options")
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Options describes how a list endpoint should page, sort and filter its rows.
+// The zero value means "no limit, no filters, storage order" which is what
+// internal callers such as exports want.
+type Options struct {
+	Limit   int
+	Offset  int
+	Sort    []SortField
+	Filters map[string]string
+}
+
+// Config whitelists the sortable and filterable columns of an endpoint.
+type Config struct {
+	Sorts       []string
+	Filters     []string
+	DefaultSort string
+}
+
+// Parse reads limit, offset (or page), sort and filters from the query string.
+//
+//	?limit=20&offset=40
+//	?limit=20&page=3
+//	?sort=-start_date,name
+//	?category=Music
+func Parse(c *fiber.Ctx, cfg Config) (Options, error) {
+	opts := Options{
+		Limit:   DefaultLimit,
+		Filters: map[string]string{},
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return Options{}, fmt.Errorf("%w: limit must be a positive integer", ErrInvalidOptions)
+		}
+		opts.Limit = min(limit, MaxLimit)
+	}
+
+	if v := c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return Options{}, fmt.Errorf("%w: offset must be a non-negative integer", ErrInvalidOptions)
+		}
+		opts.Offset = offset
+	} else if v := c.Query("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return Options{}, fmt.Errorf("%w: page must be a positive integer", ErrInvalidOptions)
+		}
+		opts.Offset = (page - 1) * opts.Limit
+	}
+
+	sort := c.Query("sort", cfg.DefaultSort)
+	if sort != "" {
+		for _, s := range strings.Split(sort, ",") {
+			field := SortField{Field: strings.TrimSpace(s)}
+			if strings.HasPrefix(field.Field, "-") {
+				field.Field = field.Field[1:]
+				field.Desc = true
+			}
+
+			if !slices.Contains(cfg.Sorts, field.Field) {
+				return Options{}, fmt.Errorf("%w: cannot sort by %q", ErrInvalidOptions, field.Field)
+			}
+			opts.Sort = append(opts.Sort, field)
+		}
+	}
+
+	for _, f := range cfg.Filters {
+		if v := c.Query(f); v != "" {
+			opts.Filters[f] = v
+		}
+	}
+
+	return opts, nil
+}
+
+// Filter is a GORM scope applying the equality filters. Filter keys are
+// column names that Parse has already checked against the endpoint whitelist.
+func (o Options) Filter(db *gorm.DB) *gorm.DB {
+	keys := make([]string, 0, len(o.Filters))
+	for k := range o.Filters {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		db = db.Where(k+" = ?", o.Filters[k])
+	}
+
+	return db
+}
+
+// Paginate is a GORM scope applying ordering, offset and limit. The primary
+// key is always used as a tie-breaker so pages are stable.
+func (o Options) Paginate(db *gorm.DB) *gorm.DB {
+	for _, s := range o.Sort {
+		if s.Desc {
+			db = db.Order(s.Field + " DESC")
+		} else {
+			db = db.Order(s.Field + " ASC")
+		}
+	}
+	db = db.Order("id")
+
+	if o.Offset > 0 {
+		db = db.Offset(o.Offset)
+	}
+	if o.Limit > 0 {
+		db = db.Limit(o.Limit)
+	}
+
+	return db
+}
+
+func (o Options) Pagination(total int64) *responses.Pagination {
+	return &responses.Pagination{
+		Limit:  o.Limit,
+		Offset: o.Offset,
+		Total:  total,
+	}
+}
@@ -0,0 +1,102 @@
+package query
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func parse(t *testing.T, target string, cfg Config) (Options, error) {
+	t.Helper()
+
+	var opts Options
+	var parseErr error
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		opts, parseErr = Parse(c, cfg)
+		return nil
+	})
+
+	_, err := app.Test(httptest.NewRequest("GET", target, nil))
+	if err != nil {
+		t.Fatalf("expected error to be nil; got %v", err)
+	}
+
+	return opts, parseErr
+}
+
+func TestParse(t *testing.T) {
+	cfg := Config{
+		Sorts:       []string{"price", "start_date"},
+		Filters:     []string{"category"},
+		DefaultSort: "start_date",
+	}
+
+	tests := []struct {
+		name        string
+		target      string
+		expected    Options
+		expectedErr bool
+	}{
+		{
+			name:   "defaults",
+			target: "/",
+			expected: Options{
+				Limit:   DefaultLimit,
+				Sort:    []SortField{{Field: "start_date"}},
+				Filters: map[string]string{},
+			},
+		},
+		{
+			name:   "page is converted to offset",
+			target: "/?limit=10&page=3",
+			expected: Options{
+				Limit:   10,
+				Offset:  20,
+				Sort:    []SortField{{Field: "start_date"}},
+				Filters: map[string]string{},
+			},
+		},
+		{
+			name:   "limit is capped and sort and filters are read",
+			target: "/?limit=1000&offset=5&sort=-price,start_date&category=Music&location=ignored",
+			expected: Options{
+				Limit:   MaxLimit,
+				Offset:  5,
+				Sort:    []SortField{{Field: "price", Desc: true}, {Field: "start_date"}},
+				Filters: map[string]string{"category": "Music"},
+			},
+		},
+		{
+			name:        "unknown sort field",
+			target:      "/?sort=password",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid limit",
+			target:      "/?limit=abc",
+			expectedErr: true,
+		},
+		{
+			name:        "negative offset",
+			target:      "/?offset=-1",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parse(t, tt.target, cfg)
+			if tt.expectedErr {
+				assert.ErrorIs(t, err, ErrInvalidOptions)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, opts)
+		})
+	}
+}
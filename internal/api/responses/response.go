@@ -1,6 +1,8 @@
 package responses
 
 import (
+	"encoding/json"
+	"net/http"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,13 +12,27 @@ type SuccessResponse struct {
 	Message string `json:"message"`
 }
 
-type ErrorResponse struct {
-	Message string `json:"message"`
+// ProblemResponse is an RFC 7807 problem details body. Code identifies the
+// error for clients, Detail is the human readable explanation.
+type ProblemResponse struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
 }
 
 type DataResponse struct {
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+type Pagination struct {
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+	Total  int64 `json:"total"`
 }
 
 type UserResponseObject struct {
@@ -26,47 +42,232 @@ type UserResponseObject struct {
 	Role  string    `json:"role"`
 }
 
+// AccountResponseObject is a user as the user and admins see it.
+type AccountResponseObject struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	PendingEmail string     `json:"pending_email,omitempty"`
+	Role         string     `json:"role"`
+	IsVerified   bool       `json:"is_verified"`
+	DisabledAt   *time.Time `json:"disabled_at"`
+	ErasedAt     *time.Time `json:"erased_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
 type BookingResponseObject struct {
-	ID         uuid.UUID `json:"id"`
-	UserID     uuid.UUID `json:"user_id"`
-	EventID    uuid.UUID `json:"event_id"`
-	Quantity   int       `json:"quantity"`
-	TotalPrice float64   `json:"total_price"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uuid.UUID   `json:"id"`
+	UserID     uuid.UUID   `json:"user_id"`
+	EventID    uuid.UUID   `json:"event_id"`
+	Quantity   int         `json:"quantity"`
+	TotalPrice float64     `json:"total_price"`
+	SeatIDs    []uuid.UUID `json:"seat_ids,omitempty"`
+	Status     string      `json:"status,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
 }
 
 type EventResponseObject struct {
-	ID            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	Location      string    `json:"location"`
-	StartDate     time.Time `json:"start_date"`
-	EndDate       time.Time `json:"end_date"`
-	Price         float64   `json:"price"`
-	TotalSeat     int       `json:"total_seat"`
-	AvailableSeat int       `json:"available_seat"`
-	Category      string    `json:"category"`
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	Location      string     `json:"location"`
+	StartDate     time.Time  `json:"start_date"`
+	EndDate       time.Time  `json:"end_date"`
+	Price         float64    `json:"price"`
+	TotalSeat     int        `json:"total_seat"`
+	AvailableSeat int        `json:"available_seat"`
+	Category      string     `json:"category"`
+	SeriesID      *uuid.UUID `json:"series_id,omitempty"`
+	Detached      bool       `json:"detached,omitempty"`
+	VenueID       *uuid.UUID `json:"venue_id,omitempty"`
+	OrganizerID   *uuid.UUID `json:"organizer_id,omitempty"`
+	// Status is the lifecycle status: draft, scheduled, published,
+	// cancelled or completed
+	Status             string     `json:"status"`
+	PublishAt          *time.Time `json:"publish_at,omitempty"`
+	SalesStartAt       *time.Time `json:"sales_start_at,omitempty"`
+	SalesEndAt         *time.Time `json:"sales_end_at,omitempty"`
+	SalesOpen          bool       `json:"sales_open"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	Rating             float64    `json:"rating"`
+	RatingCount        int        `json:"rating_count"`
+}
+
+// RatingResponseObject summarizes the reviews of an event; Distribution
+// maps each star value from 1 to 5 to the number of reviews giving it.
+type RatingResponseObject struct {
+	EventID      uuid.UUID   `json:"event_id"`
+	Rating       float64     `json:"rating"`
+	Count        int         `json:"count"`
+	Distribution map[int]int `json:"distribution"`
+}
+
+type RefundResponseObject struct {
+	ID          uuid.UUID  `json:"id"`
+	BookingID   uuid.UUID  `json:"booking_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	EventID     uuid.UUID  `json:"event_id"`
+	Amount      float64    `json:"amount"`
+	Reason      string     `json:"reason,omitempty"`
+	Status      string     `json:"status"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type EventSeriesResponseObject struct {
+	ID          uuid.UUID             `json:"id"`
+	Name        string                `json:"name"`
+	Location    string                `json:"location"`
+	StartDate   time.Time             `json:"start_date"`
+	EndDate     time.Time             `json:"end_date"`
+	Price       float64               `json:"price"`
+	TotalSeat   int                   `json:"total_seat"`
+	Category    string                `json:"category"`
+	RRule       string                `json:"rrule"`
+	Occurrences []EventResponseObject `json:"occurrences,omitempty"`
+}
+
+type VenueResponseObject struct {
+	ID        uuid.UUID               `json:"id"`
+	Name      string                  `json:"name"`
+	Address   string                  `json:"address"`
+	TotalSeat int                     `json:"total_seat,omitempty"`
+	Sections  []SectionResponseObject `json:"sections,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+type SectionResponseObject struct {
+	ID   uuid.UUID               `json:"id"`
+	Name string                  `json:"name"`
+	Rows []SeatRowResponseObject `json:"rows"`
+}
+
+type SeatRowResponseObject struct {
+	Row   string               `json:"row"`
+	Seats []SeatResponseObject `json:"seats"`
+}
+
+type SeatResponseObject struct {
+	ID        uuid.UUID `json:"id"`
+	Number    int       `json:"number"`
+	Available *bool     `json:"available,omitempty"`
+}
+
+type SeatMapResponseObject struct {
+	EventID       uuid.UUID               `json:"event_id"`
+	VenueID       uuid.UUID               `json:"venue_id"`
+	VenueName     string                  `json:"venue_name"`
+	TotalSeat     int                     `json:"total_seat"`
+	AvailableSeat int                     `json:"available_seat"`
+	Sections      []SectionResponseObject `json:"sections"`
 }
 
 type ReviewResponseObject struct {
+	ID      uuid.UUID `json:"id"`
+	EventID uuid.UUID `json:"event_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Review  string    `json:"review"`
+	Rating  int       `json:"rating"`
+	// Status is the moderation status: pending, approved, rejected or
+	// flagged
+	Status           string                     `json:"status"`
+	ModerationReason string                     `json:"moderation_reason,omitempty"`
+	HelpfulCount     int                        `json:"helpful_count"`
+	UnhelpfulCount   int                        `json:"unhelpful_count"`
+	Helpfulness      int                        `json:"helpfulness"`
+	Reply            *ReviewReplyResponseObject `json:"reply,omitempty"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
+}
+
+type ReviewReplyResponseObject struct {
 	ID        uuid.UUID `json:"id"`
-	EventID   uuid.UUID `json:"event_id"`
+	ReviewID  uuid.UUID `json:"review_id"`
 	UserID    uuid.UUID `json:"user_id"`
-	Review    string    `json:"review"`
-	Rating    int       `json:"rating"`
+	Reply     string    `json:"reply"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type NotificationResponseObject struct {
+	ID        uuid.UUID  `json:"id"`
+	Type      string     `json:"type"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type NotificationPreferenceResponseObject struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel"`
+	Enabled bool   `json:"enabled"`
+}
+
+type ReviewVoteResponseObject struct {
+	ReviewID  uuid.UUID `json:"review_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Helpful   bool      `json:"helpful"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ModerationReviewResponseObject is a review in the moderation queue with
+// the reports that are still open.
+type ModerationReviewResponseObject struct {
+	ReviewResponseObject
+	Reports []ReviewReportResponseObject `json:"reports"`
+}
+
+type ReviewReportResponseObject struct {
+	ID        uuid.UUID `json:"id"`
+	ReviewID  uuid.UUID `json:"review_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookResponseObject struct {
+	ID           uuid.UUID  `json:"id"`
+	URL          string     `json:"url"`
+	EventTypes   []string   `json:"event_types"`
+	Active       bool       `json:"active"`
+	FailureCount int        `json:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type WebhookDeliveryResponseObject struct {
+	ID            uuid.UUID  `json:"id"`
+	WebhookID     uuid.UUID  `json:"webhook_id"`
+	EventID       uuid.UUID  `json:"event_id"`
+	EventType     string     `json:"event_type"`
+	Attempt       int        `json:"attempt"`
+	StatusCode    int        `json:"status_code"`
+	Success       bool       `json:"success"`
+	Error         string     `json:"error"`
+	ResponseBody  string     `json:"response_body"`
+	DurationMs    int64      `json:"duration_ms"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
 func NewSuccessResponse(message string) *SuccessResponse {
 	return &SuccessResponse{
 		Message: message,
 	}
 }
 
-func NewErrorResponse(message string) *ErrorResponse {
-	return &ErrorResponse{
-		Message: message,
+func NewProblemResponse(status int, code, detail, instance string) *ProblemResponse {
+	return &ProblemResponse{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     code,
 	}
 }
 
@@ -76,3 +277,92 @@ func NewDataResponse(message string, data interface{}) *DataResponse {
 		Data:    data,
 	}
 }
+
+func NewPaginatedResponse(message string, data interface{}, pagination *Pagination) *DataResponse {
+	return &DataResponse{
+		Message:    message,
+		Data:       data,
+		Pagination: pagination,
+	}
+}
+
+type OrganizationResponseObject struct {
+	ID        uuid.UUID                          `json:"id"`
+	Name      string                             `json:"name"`
+	Members   []OrganizationMemberResponseObject `json:"members,omitempty"`
+	CreatedAt time.Time                          `json:"created_at"`
+	UpdatedAt time.Time                          `json:"updated_at"`
+}
+
+type OrganizationMemberResponseObject struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type EmailResponseObject struct {
+	ID            uuid.UUID  `json:"id"`
+	To            string     `json:"to"`
+	Subject       string     `json:"subject"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type CalendarFeedResponseObject struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+type SeatReconciliationResponseObject struct {
+	ID             uuid.UUID                 `json:"id"`
+	Trigger        string                    `json:"trigger"`
+	Correct        bool                      `json:"correct"`
+	EventsChecked  int                       `json:"events_checked"`
+	DriftCount     int                       `json:"drift_count"`
+	CorrectedCount int                       `json:"corrected_count"`
+	StartedAt      time.Time                 `json:"started_at"`
+	FinishedAt     time.Time                 `json:"finished_at"`
+	Drifts         []SeatDriftResponseObject `json:"drifts,omitempty"`
+}
+
+type SeatDriftResponseObject struct {
+	EventID      uuid.UUID `json:"event_id"`
+	EventName    string    `json:"event_name"`
+	TotalSeat    int       `json:"total_seat"`
+	BookedSeat   int       `json:"booked_seat"`
+	RecordedSeat int       `json:"recorded_seat"`
+	ExpectedSeat int       `json:"expected_seat"`
+	Corrected    bool      `json:"corrected"`
+}
+
+type AuditEntryResponseObject struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id"`
+	ActorRole  string          `json:"actor_role"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+	IP         string          `json:"ip"`
+	UserAgent  string          `json:"user_agent"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// TrashedRecordResponseObject is a soft deleted record; Name is the email of
+// a user, the name of a series or event, or the event name of a booking or
+// review.
+type TrashedRecordResponseObject struct {
+	Kind      string     `json:"kind"`
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	SeriesID  *uuid.UUID `json:"series_id,omitempty"`
+	EventID   *uuid.UUID `json:"event_id,omitempty"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	DeletedAt time.Time  `json:"deleted_at"`
+}
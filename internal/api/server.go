@@ -5,16 +5,25 @@ import (
 	"errors"
 	"event-booking/internal/account"
 	"event-booking/internal/api/validator"
+	"event-booking/internal/audit"
 	"event-booking/internal/auth"
 	"event-booking/internal/booking"
+	"event-booking/internal/calendar"
 	"event-booking/internal/config"
 	"event-booking/internal/email"
 	"event-booking/internal/event"
 	"event-booking/internal/export"
 	"event-booking/internal/health"
+	"event-booking/internal/notification"
+	"event-booking/internal/organization"
 	"event-booking/internal/postgres"
 	"event-booking/internal/rabbitmq"
+	"event-booking/internal/reconciliation"
 	"event-booking/internal/review"
+	"event-booking/internal/series"
+	"event-booking/internal/trash"
+	"event-booking/internal/venue"
+	"event-booking/internal/webhook"
 	"fmt"
 	"os"
 	"os/signal"
@@ -24,6 +33,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 func NewServer() *Server {
@@ -31,51 +41,107 @@ func NewServer() *Server {
 
 	// Database Connection
 	db := postgres.NewGORM(cfg.Database)
-	postgres.Migrate(db)
+	warnPendingMigrations(db)
 
 	// Email Service
 	emailService := email.NewEmailService(&cfg.Smtp)
+	emailQueue := email.NewQueue(email.NewRepository(db), emailService, cfg.Email.MaxAttempts, cfg.Email.PollInterval)
+	emailHandler := email.NewHttpHandler(emailQueue)
 
 	// RabbitMQ
 	rabbitCon := rabbitmq.InitRabbitMQ(&cfg.RabbitMQ)
 
-	// middleware
+	// Organization
+	organizationRepo := organization.NewRepository(db)
+	organizationSvc := organization.NewService(organizationRepo)
+
+	// jwt
 	jwtService := auth.NewJwtService(cfg.App.JwtSecretKey)
-	middleware := auth.NewMiddleware(jwtService)
 
 	// validator
 	validatorService := validator.NewValidator()
 
+	// Audit
+	auditSvc := audit.NewService(audit.NewRepository(db), cfg.Audit.Retention)
+	auditHandler := audit.NewHttpHandler(auditSvc)
+
 	// Health
 	healthRepo := health.NewRepository(db)
 	healthSvc := health.NewService(healthRepo)
 	healthHandler := health.NewHttpHandler(healthSvc)
 
+	organizationHandler := organization.NewHttpHandler(organizationSvc, validatorService)
+
+	// Webhook
+	webhookRepo := webhook.NewRepository(db)
+	webhookSvc := webhook.NewService(webhookRepo)
+	webhookHandler := webhook.NewHttpHandler(webhookSvc, validatorService)
+
+	// Notification
+	notificationRepo := notification.NewRepository(db)
+	notificationRegistry, err := notification.NewRegistry()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load notification templates")
+	}
+	notificationSvc := notification.NewService(notificationRepo, notificationRegistry,
+		notification.NewEmailChannel(emailQueue),
+		notification.NewInAppChannel(notificationRepo),
+		notification.NewWebhookChannel(webhookSvc),
+	)
+	notificationHandler := notification.NewHttpHandler(notificationSvc, validatorService)
+
 	// Account
 	accountRepo := account.NewRepository(db)
-	accountSvc := account.NewService(accountRepo, emailService)
-	accountHandler := account.NewHttpHandler(accountSvc, jwtService, validatorService)
+	accountSvc := account.NewService(accountRepo, notificationSvc)
+	accountHandler := account.NewHttpHandler(accountSvc, jwtService, auditSvc, validatorService)
+	middleware := auth.NewMiddleware(jwtService, organizationSvc, accountSvc)
 
 	// Event
 	eventRepo := event.NewRepository(db)
-	eventSvc := event.NewService(eventRepo)
-	eventHandler := event.NewHttpHandler(eventSvc, validatorService)
+	eventSvc := event.NewService(eventRepo, notificationSvc, webhookSvc)
+	eventHandler := event.NewHttpHandler(eventSvc, auditSvc, validatorService)
+
+	// Event Series
+	seriesRepo := series.NewRepository(db)
+	seriesSvc := series.NewService(seriesRepo)
+	seriesHandler := series.NewHttpHandler(seriesSvc, validatorService)
+
+	// Venue
+	venueRepo := venue.NewRepository(db)
+	venueSvc := venue.NewService(venueRepo, eventRepo)
+	venueHandler := venue.NewHttpHandler(venueSvc, validatorService)
 
 	// Booking
 	bookingRepo := booking.NewRepository(db)
-	bookingSvc := booking.NewService(bookingRepo, eventRepo)
+	bookingSvc := booking.NewService(bookingRepo, eventRepo, venueRepo, webhookSvc, notificationSvc)
 	bookingHandler := booking.NewHttpHandler(bookingSvc, validatorService)
 
 	// Review
 	reviewRepo := review.NewRepository(db)
-	reviewSvc := review.NewService(reviewRepo)
+	reviewModerator := review.NewWordListModerator(cfg.Review.BlockedWords)
+	reviewSvc := review.NewService(reviewRepo, eventRepo, reviewModerator, cfg.Review.ReportThreshold)
 	reviewHandler := review.NewHttpHandler(reviewSvc, validatorService)
 
+	// Seat Reconciliation
+	reconciliationSvc := reconciliation.NewService(reconciliation.NewRepository(db), eventRepo)
+	reconciliationHandler := reconciliation.NewHttpHandler(reconciliationSvc)
+
+	// Trash
+	trashSvc := trash.NewService(trash.NewRepository(db), cfg.Trash.Retention)
+	trashHandler := trash.NewHttpHandler(trashSvc, auditSvc)
+
+	// Calendar
+	calendarSvc := calendar.NewService(calendar.NewRepository(db))
+	calendarHandler := calendar.NewHttpHandler(calendarSvc)
+
 	// Export
-	exportSvc := export.NewService(eventRepo, bookingRepo)
-	exportHandler := export.NewHttpHandler(exportSvc, rabbitCon)
+	exportSvc := export.NewService(eventRepo, bookingRepo, reviewRepo, accountRepo, audit.NewRepository(db), notificationSvc)
+	exportHandler := export.NewHttpHandler(exportSvc, rabbitCon, accountSvc, auditSvc)
+	accountWorker := export.NewWorker(rabbitCon, exportSvc, accountSvc)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		ErrorHandler: ErrorHandler,
+	})
 
 	app.Use(
 		logger.New(),
@@ -99,20 +165,70 @@ func NewServer() *Server {
 	app.Post("/api/logout", accountHandler.SignOutUserHandler)
 	app.Post("/api/refresh", accountHandler.RefreshTokenHandler)
 	app.Put("/api/account", middleware.AdminRequired, accountHandler.UpdateUserHandler)
+	app.Get("/api/account/me", middleware.AuthRequired, accountHandler.FindOwnAccountHandler)
+	app.Patch("/api/account/me", middleware.AuthRequired, accountHandler.UpdateOwnAccountHandler)
+	app.Get("/api/account/me/export", middleware.AuthRequired, exportHandler.ExportAccountHandler)
+	app.Delete("/api/account/me", middleware.AuthRequired, exportHandler.EraseAccountHandler)
 	app.Get("/api/account/:id", middleware.AdminRequired, accountHandler.GetUserByIDHandler)
 
-	// Event Admin routes
-	app.Post("/api/admin/event", middleware.AdminRequired, eventHandler.CreateEventHandler)
-	app.Get("/api/admin/event", middleware.AdminRequired, eventHandler.FindAllEventHandler)
-	app.Get("/api/admin/event/:id", middleware.AdminRequired, eventHandler.FindEventHandler)
-	app.Put("/api/admin/event/:id", middleware.AdminRequired, eventHandler.SaveEventHandler)
-	app.Delete("/api/admin/event/:id", middleware.AdminRequired, eventHandler.DeleteEventHandler)
-	app.Get("/api/admin/event/:id/bookings", middleware.AdminRequired, eventHandler.GetEventBookingsHandler)
+	// User Admin routes
+	app.Get("/api/admin/users", middleware.AdminRequired, accountHandler.FindAllUserHandler)
+	app.Get("/api/admin/users/:id", middleware.AdminRequired, accountHandler.GetUserByIDHandler)
+	app.Post("/api/admin/users/:id/disable", middleware.AdminRequired, accountHandler.DisableUserHandler)
+	app.Post("/api/admin/users/:id/enable", middleware.AdminRequired, accountHandler.EnableUserHandler)
+	app.Put("/api/admin/users/:id/role", middleware.AdminRequired, accountHandler.SetRoleHandler)
+
+	// Organization Admin routes
+	app.Post("/api/admin/organizations", middleware.AdminRequired, organizationHandler.CreateOrganizationHandler)
+	app.Get("/api/admin/organizations", middleware.AdminRequired, organizationHandler.FindAllOrganizationHandler)
+	app.Get("/api/admin/organizations/:id", middleware.AdminRequired, organizationHandler.FindOrganizationHandler)
+	app.Delete("/api/admin/organizations/:id", middleware.AdminRequired, organizationHandler.DeleteOrganizationHandler)
+	app.Post("/api/admin/organizations/:id/members", middleware.AdminRequired, organizationHandler.AddMemberHandler)
+	app.Delete("/api/admin/organizations/:id/members/:userId", middleware.AdminRequired, organizationHandler.RemoveMemberHandler)
+	app.Get("/api/organization", middleware.OrganizerRequired, organizationHandler.FindOwnOrganizationHandler)
+
+	// Event Admin routes, organizers only reach their own events
+	app.Post("/api/admin/event", middleware.OrganizerRequired, eventHandler.CreateEventHandler)
+	app.Get("/api/admin/event", middleware.OrganizerRequired, eventHandler.FindAllEventHandler)
+	app.Get("/api/admin/event/:id", middleware.OrganizerRequired, eventHandler.FindEventHandler)
+	app.Put("/api/admin/event/:id", middleware.OrganizerRequired, eventHandler.SaveEventHandler)
+	app.Delete("/api/admin/event/:id", middleware.OrganizerRequired, eventHandler.CancelEventHandler)
+	app.Post("/api/admin/event/:id/publish", middleware.OrganizerRequired, eventHandler.PublishEventHandler)
+	app.Post("/api/admin/event/:id/unpublish", middleware.OrganizerRequired, eventHandler.UnpublishEventHandler)
+	app.Post("/api/admin/event/:id/cancel", middleware.OrganizerRequired, eventHandler.CancelEventHandler)
+	app.Post("/api/admin/event/:id/delete", middleware.AdminRequired, eventHandler.DeleteEventHandler)
+	app.Get("/api/admin/event/:id/refunds", middleware.OrganizerRequired, eventHandler.FindRefundsHandler)
+	app.Get("/api/admin/event/:id/bookings", middleware.OrganizerRequired, eventHandler.GetEventBookingsHandler)
+	app.Get("/api/admin/bookings", middleware.OrganizerRequired, bookingHandler.GetBookedEventsHandler)
+	app.Get("/api/admin/reviews", middleware.OrganizerRequired, reviewHandler.FindAllReviewHandler)
+	app.Delete("/api/admin/reviews/:id", middleware.OrganizerRequired, reviewHandler.DeleteReviewHandler)
+	app.Put("/api/admin/reviews/:id/reply", middleware.OrganizerRequired, reviewHandler.ReplyReviewHandler)
+	app.Delete("/api/admin/reviews/:id/reply", middleware.OrganizerRequired, reviewHandler.DeleteReplyHandler)
+	app.Get("/api/admin/reviews/moderation", middleware.AdminRequired, reviewHandler.FindModerationQueueHandler)
+	app.Post("/api/admin/reviews/moderation/:id/approve", middleware.AdminRequired, reviewHandler.ApproveReviewHandler)
+	app.Post("/api/admin/reviews/moderation/:id/reject", middleware.AdminRequired, reviewHandler.RejectReviewHandler)
+
+	// Event Series Admin routes
+	app.Post("/api/admin/series", middleware.AdminRequired, seriesHandler.CreateSeriesHandler)
+	app.Get("/api/admin/series", middleware.AdminRequired, seriesHandler.FindAllSeriesHandler)
+	app.Get("/api/admin/series/:id", middleware.AdminRequired, seriesHandler.FindSeriesHandler)
+	app.Delete("/api/admin/series/:id", middleware.AdminRequired, seriesHandler.DeleteSeriesHandler)
+	app.Post("/api/admin/series/:id/publish", middleware.AdminRequired, seriesHandler.PublishSeriesHandler)
+	app.Put("/api/admin/series/:id/occurrences/:eventId", middleware.AdminRequired, seriesHandler.UpdateOccurrenceHandler)
+
+	// Venue Admin routes
+	app.Post("/api/admin/venues", middleware.AdminRequired, venueHandler.CreateVenueHandler)
+	app.Get("/api/admin/venues", middleware.AdminRequired, venueHandler.FindAllVenueHandler)
+	app.Get("/api/admin/venues/:id", middleware.AdminRequired, venueHandler.FindVenueHandler)
+	app.Delete("/api/admin/venues/:id", middleware.AdminRequired, venueHandler.DeleteVenueHandler)
 
 	// Event routes
 	app.Get("/api/event", middleware.AuthRequired, eventHandler.FindAllEventHandler)
+	app.Get("/api/event/search", middleware.AuthRequired, eventHandler.SearchEventHandler)
 	app.Get("/api/event/:id", middleware.AuthRequired, eventHandler.FindEventHandler)
-	app.Get("/api/event/filter", middleware.AuthRequired, eventHandler.FilterByCriteria)
+	app.Get("/api/event/:id/rating", middleware.AuthRequired, eventHandler.FindEventRatingHandler)
+	app.Get("/api/event/:id/ics", middleware.AuthRequired, eventHandler.FindEventICSHandler)
+	app.Get("/api/event/:id/seats", middleware.AuthRequired, venueHandler.SeatMapHandler)
 
 	// Booking routes
 	app.Post("/api/booking", middleware.AuthRequired, bookingHandler.BookEventHandler)
@@ -125,20 +241,81 @@ func NewServer() *Server {
 	app.Post("/api/review", middleware.AuthRequired, reviewHandler.CreateReviewHandler)
 	app.Get("/api/review", middleware.AuthRequired, reviewHandler.FindAllReviewHandler)
 	app.Get("/api/review/:id", middleware.AuthRequired, reviewHandler.FindReviewHandler)
+	app.Post("/api/review/:id/report", middleware.AuthRequired, reviewHandler.ReportReviewHandler)
+	app.Put("/api/review/:id/vote", middleware.AuthRequired, reviewHandler.VoteReviewHandler)
+	app.Delete("/api/review/:id/vote", middleware.AuthRequired, reviewHandler.DeleteVoteHandler)
 	app.Get("/api/review/event/:id", middleware.AuthRequired, reviewHandler.FindReviewByEventIDHandler)
 	app.Get("/api/review/user/:id", middleware.AuthRequired, reviewHandler.FindReviewByUserIDHandler)
 	app.Put("/api/review/:id", middleware.AuthRequired, reviewHandler.UpdateReviewHandler)
 	app.Delete("/api/review/:id", middleware.AuthRequired, reviewHandler.DeleteReviewHandler)
 
+	// Notification routes
+	app.Get("/api/notifications", middleware.AuthRequired, notificationHandler.FindNotificationsHandler)
+	app.Put("/api/notifications/read", middleware.AuthRequired, notificationHandler.MarkAllReadHandler)
+	app.Put("/api/notifications/:id/read", middleware.AuthRequired, notificationHandler.MarkReadHandler)
+	app.Get("/api/notifications/preferences", middleware.AuthRequired, notificationHandler.FindPreferencesHandler)
+	app.Put("/api/notifications/preferences", middleware.AuthRequired, notificationHandler.SavePreferencesHandler)
+
+	// Calendar routes, the feed is authenticated by its token
+	app.Post("/api/calendar/feed", middleware.AuthRequired, calendarHandler.CreateFeedHandler)
+	app.Delete("/api/calendar/feed", middleware.AuthRequired, calendarHandler.RevokeFeedHandler)
+	app.Get("/api/calendar/feed/:token", calendarHandler.FeedHandler)
+
 	// Export routes
-	app.Get("/api/export/event", middleware.AdminRequired, exportHandler.ExportAllEventHandler)
-	app.Get("/api/export/booking/:id", middleware.AdminRequired, exportHandler.ExportBookingHandler)
+	app.Get("/api/export/event", middleware.OrganizerRequired, exportHandler.ExportAllEventHandler)
+	app.Get("/api/export/booking/:id", middleware.OrganizerRequired, exportHandler.ExportBookingHandler)
+
+	// Webhook Admin routes
+	app.Post("/api/admin/webhooks", middleware.AdminRequired, webhookHandler.CreateWebhookHandler)
+	app.Get("/api/admin/webhooks", middleware.AdminRequired, webhookHandler.FindAllWebhookHandler)
+	app.Get("/api/admin/webhooks/:id", middleware.AdminRequired, webhookHandler.FindWebhookHandler)
+	app.Put("/api/admin/webhooks/:id", middleware.AdminRequired, webhookHandler.UpdateWebhookHandler)
+	app.Delete("/api/admin/webhooks/:id", middleware.AdminRequired, webhookHandler.DeleteWebhookHandler)
+	app.Get("/api/admin/webhooks/:id/deliveries", middleware.AdminRequired, webhookHandler.FindDeliveriesHandler)
+
+	// Email Admin routes
+	app.Get("/api/admin/emails", middleware.AdminRequired, emailHandler.FindAllEmailHandler)
+	app.Get("/api/admin/emails/:id", middleware.AdminRequired, emailHandler.FindEmailHandler)
+	app.Post("/api/admin/emails/:id/resend", middleware.AdminRequired, emailHandler.ResendEmailHandler)
+
+	// Audit Admin routes
+	app.Get("/api/admin/audit", middleware.AdminRequired, auditHandler.FindAllEntryHandler)
+
+	// Seat Reconciliation Admin routes
+	app.Post("/api/admin/seat-reconciliations", middleware.AdminRequired, reconciliationHandler.ReconcileSeatsHandler)
+	app.Get("/api/admin/seat-reconciliations", middleware.AdminRequired, reconciliationHandler.FindAllReportHandler)
+	app.Get("/api/admin/seat-reconciliations/:id", middleware.AdminRequired, reconciliationHandler.FindReportHandler)
+
+	// Trash Admin routes
+	app.Get("/api/admin/trash/:kind", middleware.AdminRequired, trashHandler.FindAllRecordHandler)
+	app.Post("/api/admin/trash/:kind/:id/restore", middleware.AdminRequired, trashHandler.RestoreRecordHandler)
+
+	return &Server{fiber: app, emailQueue: emailQueue, webhooks: webhookSvc, accountWorker: accountWorker}
+}
 
-	return &Server{fiber: app}
+// warnPendingMigrations logs when the schema is behind the code. The server
+// never migrates by itself; run `migrate up` before deploying.
+func warnPendingMigrations(db *gorm.DB) {
+	migrator, err := postgres.NewMigrator(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load migrations")
+	}
+
+	pending, err := migrator.CheckPending()
+	if err != nil {
+		log.Error().Err(err).Msg("could not check database migrations")
+		return
+	}
+	if len(pending) > 0 {
+		log.Warn().Msgf("database has %d pending migrations, the first is %s; run `migrate up`", len(pending), pending[0])
+	}
 }
 
 type Server struct {
-	fiber *fiber.App
+	fiber         *fiber.App
+	emailQueue    *email.Queue
+	webhooks      *webhook.Service
+	accountWorker *export.Worker
 }
 
 // Run method of the Server struct runs the Fiber server on the specified port.
@@ -149,9 +326,15 @@ func (s *Server) Run(port int) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	go s.emailQueue.Run(workerCtx)
+	go s.webhooks.Run(workerCtx)
+	go s.accountWorker.Run(workerCtx)
+
 	go func() {
 		<-quit
 		log.Info().Msg("server is shutting down...")
+		stopWorkers()
 
 		_, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -0,0 +1,68 @@
+package tenant
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Scope limits queries to the data of one organization. The zero value is
+// the global scope platform admins and internal callers use.
+type Scope struct {
+	OrganizationID *uuid.UUID
+}
+
+// Of returns the scope of a single organization.
+func Of(organizationID uuid.UUID) Scope {
+	return Scope{OrganizationID: &organizationID}
+}
+
+// FromContext reads the organization set by the organizer middleware. Requests
+// without one, e.g. from platform admins, get the global scope.
+func FromContext(c *fiber.Ctx) Scope {
+	id, ok := c.Locals("organizationID").(string)
+	if !ok || id == "" {
+		return Scope{}
+	}
+
+	organizationID, err := uuid.Parse(id)
+	if err != nil {
+		return Scope{}
+	}
+
+	return Of(organizationID)
+}
+
+func (s Scope) Global() bool {
+	return s.OrganizationID == nil
+}
+
+// Owns reports whether a row with the given organizer belongs to the scope.
+// Events without an organizer belong to the platform and are global only.
+func (s Scope) Owns(organizerID *uuid.UUID) bool {
+	if s.Global() {
+		return true
+	}
+
+	return organizerID != nil && *organizerID == *s.OrganizationID
+}
+
+// Events filters a query on the events table.
+func (s Scope) Events(db *gorm.DB) *gorm.DB {
+	if s.Global() {
+		return db
+	}
+
+	return db.Where("events.organizer_id = ?", *s.OrganizationID)
+}
+
+// EventRows filters a query on a table with an event_id column, such as
+// bookings, reviews or refunds, to rows of the scope's events.
+func (s Scope) EventRows(db *gorm.DB) *gorm.DB {
+	if s.Global() {
+		return db
+	}
+
+	return db.Where("event_id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+		Table("events").Select("id").Where("organizer_id = ?", *s.OrganizationID))
+}
@@ -0,0 +1,73 @@
+package tenant
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("expected error to be nil; got %v", err)
+	}
+
+	return db
+}
+
+func TestOwns(t *testing.T) {
+	organizationID := uuid.New()
+	other := uuid.New()
+
+	assert.True(t, Scope{}.Owns(nil))
+	assert.True(t, Scope{}.Owns(&other))
+	assert.True(t, Of(organizationID).Owns(&organizationID))
+	assert.False(t, Of(organizationID).Owns(&other))
+	assert.False(t, Of(organizationID).Owns(nil))
+}
+
+func TestScopes(t *testing.T) {
+	db := dryRun(t)
+	organizationID := uuid.New()
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("bookings").Scopes(Of(organizationID).EventRows).Find(&[]map[string]interface{}{})
+	})
+	assert.Contains(t, sql, "event_id IN (SELECT id FROM \"events\" WHERE organizer_id = '"+organizationID.String()+"')")
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("events").Scopes(Of(organizationID).Events).Find(&[]map[string]interface{}{})
+	})
+	assert.Contains(t, sql, "events.organizer_id = '"+organizationID.String()+"'")
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("events").Scopes(Scope{}.Events).Find(&[]map[string]interface{}{})
+	})
+	assert.NotContains(t, sql, "WHERE")
+}
+
+func TestFromContext(t *testing.T) {
+	organizationID := uuid.New()
+
+	var scope Scope
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.Locals("organizationID", organizationID.String())
+		scope = FromContext(c)
+		return nil
+	})
+
+	_, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, &organizationID, scope.OrganizationID)
+}
@@ -1,12 +1,19 @@
 package validator
 
 import (
+	"event-booking/internal/apperror"
 	"fmt"
 	"regexp"
 
 	"github.com/go-playground/validator/v10"
 )
 
+var (
+	// ErrInvalidBody is returned by handlers whose body does not parse.
+	ErrInvalidBody    = apperror.Validation("invalid_body", "request body could not be parsed")
+	ErrInvalidPayload = apperror.Validation("invalid_payload", "invalid payload")
+)
+
 type Validator struct {
 	validate *validator.Validate
 }
@@ -29,7 +36,7 @@ func (v *Validator) ValidateStruct(payload interface{}) error {
 	err := v.validate.Struct(payload)
 	if err != nil {
 		for _, err := range err.(validator.ValidationErrors) {
-			return fmt.Errorf("%s is invalid: %s", err.Field(), err.Tag())
+			return fmt.Errorf("%w: %s is invalid: %s", ErrInvalidPayload, err.Field(), err.Tag())
 		}
 	}
 	return nil
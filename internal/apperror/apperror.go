@@ -0,0 +1,106 @@
+package apperror
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Kind classifies a domain error. The HTTP layer maps every kind to one
+// status code, services never deal with statuses themselves.
+type Kind string
+
+const (
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindRateLimited  Kind = "rate_limited"
+)
+
+// Generic errors of each kind. errors.Is(err, ErrNotFound) is true for every
+// not found error, whatever its code.
+var (
+	ErrNotFound     = &Error{Kind: KindNotFound}
+	ErrConflict     = &Error{Kind: KindConflict}
+	ErrValidation   = &Error{Kind: KindValidation}
+	ErrUnauthorized = &Error{Kind: KindUnauthorized}
+	ErrForbidden    = &Error{Kind: KindForbidden}
+	ErrRateLimited  = &Error{Kind: KindRateLimited}
+)
+
+// Error is an error the API reports to clients. Code is a stable machine
+// readable identifier such as "event_not_found", Message is safe to show.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Err     error
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func Validation(code, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+func RateLimited(code, message string) *Error {
+	return &Error{Kind: KindRateLimited, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors of the same kind and code, so a wrapped copy still
+// matches the sentinel it was made from. Targets without a code match the
+// whole kind.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+
+	return t.Kind == e.Kind && (t.Code == "" || t.Code == e.Code)
+}
+
+// Wrap returns a copy of e caused by err. The cause is kept for logs and
+// errors.Is but is not shown to clients.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// IfNotFound turns a missing row into notFound and leaves other errors, which
+// are internal, as they are.
+func IfNotFound(err error, notFound *Error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return notFound.Wrap(err)
+	}
+
+	return err
+}
@@ -0,0 +1,51 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestErrorIs(t *testing.T) {
+	errEventNotFound := NotFound("event_not_found", "event not found")
+	errVenueNotFound := NotFound("venue_not_found", "venue not found")
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"same sentinel", errEventNotFound, errEventNotFound, true},
+		{"wrapped with detail", fmt.Errorf("%w: id 42", errEventNotFound), errEventNotFound, true},
+		{"wrapped cause", errEventNotFound.Wrap(gorm.ErrRecordNotFound), errEventNotFound, true},
+		{"cause stays reachable", errEventNotFound.Wrap(gorm.ErrRecordNotFound), gorm.ErrRecordNotFound, true},
+		{"whole kind", errEventNotFound, ErrNotFound, true},
+		{"other code", errEventNotFound, errVenueNotFound, false},
+		{"other kind", errEventNotFound, ErrConflict, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+		})
+	}
+}
+
+func TestIfNotFound(t *testing.T) {
+	errEventNotFound := NotFound("event_not_found", "event not found")
+
+	t.Run("missing row", func(t *testing.T) {
+		err := IfNotFound(gorm.ErrRecordNotFound, errEventNotFound)
+
+		assert.ErrorIs(t, err, errEventNotFound)
+		assert.Equal(t, "event not found: record not found", err.Error())
+	})
+
+	t.Run("other errors are unchanged", func(t *testing.T) {
+		assert.Equal(t, assert.AnError, IfNotFound(assert.AnError, errEventNotFound))
+	})
+}
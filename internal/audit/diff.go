@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Change is the value of a field before and after an action.
+type Change struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// redacted fields are recorded as changed without their values.
+var redacted = []string{"password", "email_verification_code", "secret", "token"}
+
+// ignored fields change on every save or hold associations.
+var ignored = []string{"CreatedAt", "UpdatedAt", "created_at", "updated_at", "Bookings", "Reviews"}
+
+var redactedValue = json.RawMessage(`"[redacted]"`)
+
+// Diff compares the JSON encoding of before and after and returns the
+// changed fields as a JSON object of Change, or "" when nothing changed.
+// Either side may be nil, e.g. for creations. Timestamps are compared as
+// instants, so a different time zone is not a change.
+func Diff(before, after interface{}) string {
+	b, a := fields(before), fields(after)
+
+	changes := map[string]Change{}
+	for _, m := range []map[string]json.RawMessage{b, a} {
+		for key := range m {
+			if _, seen := changes[key]; seen || slices.Contains(ignored, key) || equal(b[key], a[key]) {
+				continue
+			}
+
+			change := Change{Before: orNull(b[key]), After: orNull(a[key])}
+			if slices.Contains(redacted, key) {
+				change = Change{Before: redactedValue, After: redactedValue}
+			}
+			changes[key] = change
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+func fields(v interface{}) map[string]json.RawMessage {
+	m := map[string]json.RawMessage{}
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil() {
+		return m
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return m
+	}
+	json.Unmarshal(data, &m)
+
+	return m
+}
+
+func equal(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	var av, bv interface{}
+	if json.Unmarshal(orNull(a), &av) != nil || json.Unmarshal(orNull(b), &bv) != nil {
+		return false
+	}
+
+	as, aok := av.(string)
+	bs, bok := bv.(string)
+	if aok && bok {
+		at, aerr := time.Parse(time.RFC3339Nano, as)
+		bt, berr := time.Parse(time.RFC3339Nano, bs)
+		if aerr == nil && berr == nil {
+			return at.Equal(bt)
+		}
+	}
+
+	return reflect.DeepEqual(av, bv)
+}
+
+func orNull(v json.RawMessage) json.RawMessage {
+	if v == nil {
+		return json.RawMessage("null")
+	}
+
+	return v
+}
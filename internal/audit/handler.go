@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"encoding/json"
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/entity"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type httpHandler struct {
+	svc *Service
+}
+
+func NewHttpHandler(svc *Service) *httpHandler {
+	return &httpHandler{
+		svc: svc,
+	}
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"created_at"},
+	Filters:     []string{"actor_id", "action", "target_type", "target_id"},
+	DefaultSort: "-created_at",
+}
+
+// FindAllEntryHandler lists the audit log, e.g.
+// ?action=event.update&target_id=<id>&from=2024-11-01T00:00:00Z.
+func (h *httpHandler) FindAllEntryHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	var search entity.AuditSearch
+	if search.From, err = parseTime(c, "from"); err != nil {
+		return err
+	}
+	if search.To, err = parseTime(c, "to"); err != nil {
+		return err
+	}
+
+	entries, total, err := h.svc.FindAllEntryService(search, opts)
+	if err != nil {
+		return err
+	}
+
+	entryResponses := []responses.AuditEntryResponseObject{}
+	for i := range entries {
+		entryResponses = append(entryResponses, newEntryResponse(&entries[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Audit entries found", entryResponses, opts.Pagination(total)))
+}
+
+func parseTime(c *fiber.Ctx, key string) (*time.Time, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an RFC 3339 time", query.ErrInvalidOptions, key)
+	}
+
+	return &t, nil
+}
+
+func newEntryResponse(entry *entity.AuditEntry) responses.AuditEntryResponseObject {
+	response := responses.AuditEntryResponseObject{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		IP:         entry.IP,
+		UserAgent:  entry.UserAgent,
+		CreatedAt:  entry.CreatedAt,
+	}
+	if entry.Changes != "" {
+		response.Changes = json.RawMessage(entry.Changes)
+	}
+
+	return response
+}
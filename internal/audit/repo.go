@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+func (r *repo) Create(entry *entity.AuditEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *repo) FindAll(search entity.AuditSearch, opts query.Options) ([]entity.AuditEntry, int64, error) {
+	db := r.db.Model(&entity.AuditEntry{}).Scopes(opts.Filter).Session(&gorm.Session{})
+	if search.From != nil {
+		db = db.Where("created_at >= ?", *search.From)
+	}
+	if search.To != nil {
+		db = db.Where("created_at < ?", *search.To)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []entity.AuditEntry
+	if err := db.Scopes(opts.Paginate).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// Purge deletes the entries created before the cutoff. The table refuses
+// deletes unless audit.purge is set for the transaction.
+func (r *repo) Purge(before time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL audit.purge = 'on'").Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("created_at < ?", before).Delete(&entity.AuditEntry{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// Redact removes the personal data of an erased user from the audit log
+// within tx: the IP address and user agent of their requests and failed
+// sign-ins become [redacted], and their email addresses are replaced by
+// pseudonym in the targets and changes of the entries about them. The table
+// refuses updates unless audit.redact is set for the transaction. The
+// account repository calls it when it erases a user.
+func Redact(tx *gorm.DB, userID uuid.UUID, addresses []string, pseudonym string) error {
+	if err := tx.Exec("SET LOCAL audit.redact = 'on'").Error; err != nil {
+		return err
+	}
+
+	err := tx.Model(&entity.AuditEntry{}).
+		Where("actor_id = ? OR (action = ? AND target_id IN ?)", userID, ActionSignInFailed, addresses).
+		Updates(map[string]interface{}{"ip": "[redacted]", "user_agent": "[redacted]"}).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Model(&entity.AuditEntry{}).
+		Where("target_type = ? AND target_id IN ?", TargetUser, addresses).
+		Update("target_id", pseudonym).Error
+	if err != nil {
+		return err
+	}
+
+	for _, address := range addresses {
+		err := tx.Model(&entity.AuditEntry{}).
+			Where("(actor_id = ? OR (target_type = ? AND target_id = ?)) AND strpos(changes, ?) > 0", userID, TargetUser, userID.String(), address).
+			Update("changes", gorm.Expr("replace(changes, ?, ?)", address, pseudonym)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
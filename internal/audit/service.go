@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ActionSignIn         = "auth.sign_in"
+	ActionSignInFailed   = "auth.sign_in_failed"
+	ActionEventCreate    = "event.create"
+	ActionEventUpdate    = "event.update"
+	ActionEventPublish   = "event.publish"
+	ActionEventUnpublish = "event.unpublish"
+	ActionEventCancel    = "event.cancel"
+	ActionEventDelete    = "event.delete"
+	ActionAccountCreate  = "account.create"
+	ActionAccountView    = "account.view"
+	ActionAccountUpdate  = "account.update"
+	ActionAccountRole    = "account.role"
+	ActionAccountVerify  = "account.verify"
+	ActionAccountDisable = "account.disable"
+	ActionAccountEnable  = "account.enable"
+	ActionAccountDelete  = "account.delete"
+	ActionAccountExport  = "account.export"
+	ActionAccountErase   = "account.erase"
+	ActionExportEvents   = "export.events"
+	ActionExportBookings = "export.bookings"
+	ActionRestore        = "trash.restore"
+)
+
+const (
+	TargetUser    = "user"
+	TargetSeries  = "series"
+	TargetEvent   = "event"
+	TargetBooking = "booking"
+	TargetReview  = "review"
+)
+
+// ActorCLI is the actor role of entries recorded by the command line.
+const ActorCLI = "cli"
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	Create(entry *entity.AuditEntry) error
+	FindAll(search entity.AuditSearch, opts query.Options) ([]entity.AuditEntry, int64, error)
+	Purge(before time.Time) (int64, error)
+}
+
+type Service struct {
+	repo      Repository
+	retention time.Duration
+}
+
+// NewService keeps entries for retention; zero keeps them forever.
+func NewService(repo Repository, retention time.Duration) *Service {
+	return &Service{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+// FromRequest starts an entry for the signed-in user making the request.
+func FromRequest(c *fiber.Ctx, action, targetType, targetID string) *entity.AuditEntry {
+	entry := &entity.AuditEntry{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IP:         utils.CopyString(c.IP()),
+		UserAgent:  utils.CopyString(c.Get(fiber.HeaderUserAgent)),
+	}
+
+	if userID, ok := c.Locals("userID").(string); ok {
+		if id, err := uuid.Parse(userID); err == nil {
+			entry.ActorID = &id
+		}
+	}
+	entry.ActorRole, _ = c.Locals("role").(string)
+
+	return entry
+}
+
+// FromCommand starts an entry for an action taken from the command line.
+func FromCommand(action, targetType, targetID string) *entity.AuditEntry {
+	return &entity.AuditEntry{
+		ActorRole:  ActorCLI,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+	}
+}
+
+// Record appends the entry. The action it records has already happened, so
+// a failure is logged rather than returned.
+func (s *Service) Record(entry *entity.AuditEntry) {
+	if err := s.repo.Create(entry); err != nil {
+		log.Error().Err(err).Msgf("failed to record audit entry %s on %s %s", entry.Action, entry.TargetType, entry.TargetID)
+	}
+}
+
+func (s *Service) FindAllEntryService(search entity.AuditSearch, opts query.Options) ([]entity.AuditEntry, int64, error) {
+	entries, total, err := s.repo.FindAll(search, opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// PurgeService deletes the entries older than the retention and returns how
+// many were deleted.
+func (s *Service) PurgeService(now time.Time) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	deleted, err := s.repo.Purge(now.Add(-s.retention))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// Run purges expired entries every interval until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := s.PurgeService(time.Now()); err == nil && deleted > 0 {
+			log.Info().Msgf("purged %d audit entries", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"encoding/json"
+	"event-booking/internal/api/query"
+	"event-booking/internal/audit/mocks"
+	"event-booking/internal/entity"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDiff(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	start := time.Date(2024, 6, 1, 19, 0, 0, 0, jakarta)
+
+	before := &entity.Event{ID: uuid.New(), Name: "Jazz Night", Price: 100, StartDate: start, UpdatedAt: time.Now()}
+
+	t.Run("changed fields only", func(t *testing.T) {
+		after := *before
+		after.Price = 150
+		after.StartDate = start.UTC()
+		after.UpdatedAt = time.Now().Add(time.Minute)
+
+		var changes map[string]Change
+		assert.NoError(t, json.Unmarshal([]byte(Diff(before, &after)), &changes))
+
+		assert.Len(t, changes, 1)
+		assert.JSONEq(t, "100", string(changes["price"].Before))
+		assert.JSONEq(t, "150", string(changes["price"].After))
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		after := *before
+
+		assert.Empty(t, Diff(before, &after))
+	})
+
+	t.Run("creation", func(t *testing.T) {
+		var changes map[string]Change
+		assert.NoError(t, json.Unmarshal([]byte(Diff(nil, before)), &changes))
+
+		assert.JSONEq(t, "null", string(changes["name"].Before))
+		assert.JSONEq(t, `"Jazz Night"`, string(changes["name"].After))
+	})
+
+	t.Run("secrets are redacted", func(t *testing.T) {
+		user := &entity.User{Email: "john@test.com", Password: "old-hash"}
+		changed := *user
+		changed.Password = "new-hash"
+
+		diff := Diff(user, &changed)
+
+		assert.NotContains(t, diff, "hash")
+		assert.Contains(t, diff, `"password":{"before":"[redacted]","after":"[redacted]"}`)
+	})
+}
+
+func TestFromRequest(t *testing.T) {
+	userID := uuid.New()
+
+	app := fiber.New()
+	var entry *entity.AuditEntry
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.Locals("userID", userID.String())
+		c.Locals("role", "admin")
+		entry = FromRequest(c, ActionAccountView, TargetUser, "42")
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(fiber.HeaderUserAgent, "curl/8.0")
+	_, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &userID, entry.ActorID)
+	assert.Equal(t, "admin", entry.ActorRole)
+	assert.Equal(t, ActionAccountView, entry.Action)
+	assert.Equal(t, "42", entry.TargetID)
+	assert.Equal(t, "0.0.0.0", entry.IP)
+	assert.Equal(t, "curl/8.0", entry.UserAgent)
+}
+
+func TestRecord(t *testing.T) {
+	entry := FromCommand(ActionAccountRole, TargetUser, "42")
+
+	t.Run("record entry", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Create", entry).Return(nil).Once()
+
+		NewService(mockRepo, 0).Record(entry)
+	})
+
+	t.Run("failures are not returned", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Create", entry).Return(assert.AnError).Once()
+
+		assert.NotPanics(t, func() { NewService(mockRepo, 0).Record(entry) })
+	})
+}
+
+func TestFindAllEntry(t *testing.T) {
+	from := time.Now().Add(-time.Hour)
+	search := entity.AuditSearch{From: &from}
+	opts := query.Options{Limit: 20, Filters: map[string]string{"action": ActionSignInFailed}}
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FindAll", search, opts).Return([]entity.AuditEntry{{Action: ActionSignInFailed}}, int64(1), nil).Once()
+
+	entries, total, err := NewService(mockRepo, 0).FindAllEntryService(search, opts)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, int64(1), total)
+}
+
+func TestPurge(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	t.Run("entries past the retention are purged", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Purge", now.Add(-retention)).Return(int64(3), nil).Once()
+
+		deleted, err := NewService(mockRepo, retention).PurgeService(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+	})
+
+	t.Run("no retention keeps everything", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+
+		deleted, err := NewService(mockRepo, 0).PurgeService(now)
+
+		assert.NoError(t, err)
+		assert.Zero(t, deleted)
+		mockRepo.AssertNotCalled(t, "Purge", mock.Anything)
+	})
+}
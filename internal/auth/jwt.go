@@ -49,12 +49,3 @@ func (j *JwtService) ValidateToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
-
-func (j *JwtService) RefreshToken(tokenString string) (string, error) {
-	claims, err := j.ValidateToken(tokenString)
-	if err != nil {
-		return "", err
-	}
-
-	return j.CreateToken(uuid.MustParse(claims.UserID), claims.Role)
-}
@@ -1,55 +1,120 @@
 package auth
 
 import (
-	"event-booking/internal/api/responses"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 )
 
+// MemberFinder looks up the organization a user organizes for.
+type MemberFinder interface {
+	FindMemberByUserID(userID string) (*entity.OrganizationMember, error)
+}
+
+// UserFinder loads the user behind a token and fails for deleted, disabled
+// or erased users.
+type UserFinder interface {
+	FindActiveUserService(userID string) (*entity.User, error)
+}
+
+var (
+	ErrUnauthorized      = apperror.Unauthorized("unauthorized", "authentication required")
+	ErrAdminRequired     = apperror.Forbidden("admin_required", "admin access only")
+	ErrOrganizerRequired = apperror.Forbidden("organizer_required", "organizer access only")
+)
+
 type Middleware struct {
 	jwtService *JwtService
+	members    MemberFinder
+	users      UserFinder
 }
 
-func NewMiddleware(jwtService *JwtService) *Middleware {
-	return &Middleware{jwtService: jwtService}
+func NewMiddleware(jwtService *JwtService, members MemberFinder, users UserFinder) *Middleware {
+	return &Middleware{jwtService: jwtService, members: members, users: users}
 }
 
-func (m *Middleware) AuthRequired(c *fiber.Ctx) error {
+// authenticate validates the jwt cookie and loads its user on every request,
+// so disabling, erasing or deleting an account and changing its role take
+// effect immediately rather than when the token expires. The role set in
+// the context is the one stored on the user, not the one in the token.
+func (m *Middleware) authenticate(c *fiber.Ctx) (*entity.User, error) {
 	tokenString := c.Cookies("jwt")
+	if tokenString == "" {
+		log.Error().Msg("JWT cookie is missing")
+		return nil, ErrUnauthorized
+	}
+
 	claims, err := m.jwtService.ValidateToken(tokenString)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(responses.NewErrorResponse("Unauthorized"))
+		log.Error().Err(err).Msg("Failed to validate token")
+		return nil, ErrUnauthorized
+	}
+
+	user, err := m.users.FindActiveUserService(claims.UserID)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("userID", claims.UserID).
+			Msg("Access denied: account is not active")
+		return nil, ErrUnauthorized
+	}
+
+	c.Locals("userID", user.ID.String())
+	c.Locals("role", user.Role)
+
+	return user, nil
+}
+
+func (m *Middleware) AuthRequired(c *fiber.Ctx) error {
+	if _, err := m.authenticate(c); err != nil {
+		return err
 	}
 
-	c.Locals("userID", claims.UserID)
-	c.Locals("role", claims.Role)
 	return c.Next()
 }
 
 func (m *Middleware) AdminRequired(c *fiber.Ctx) error {
-	tokenString := c.Cookies("jwt")
-	if tokenString == "" {
-		log.Error().Msg("JWT cookie is missing")
-		return c.Status(fiber.StatusUnauthorized).JSON(responses.NewErrorResponse("Unauthorized"))
+	user, err := m.authenticate(c)
+	if err != nil {
+		return err
 	}
 
-	claims, err := m.jwtService.ValidateToken(tokenString)
+	if user.Role != "admin" {
+		log.Warn().
+			Str("userID", user.ID.String()).
+			Str("role", user.Role).
+			Msg("Access denied: Admin access only")
+		return ErrAdminRequired
+	}
+
+	return c.Next()
+}
+
+// OrganizerRequired lets platform admins through with global access and
+// members of an organization scoped to it, see tenant.FromContext. The
+// membership is looked up on every request so removing a member takes
+// effect immediately.
+func (m *Middleware) OrganizerRequired(c *fiber.Ctx) error {
+	user, err := m.authenticate(c)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to validate token")
-		return c.Status(fiber.StatusUnauthorized).JSON(responses.NewErrorResponse("Unauthorized"))
+		return err
 	}
 
-	if claims.Role != "admin" {
+	if user.Role == "admin" {
+		return c.Next()
+	}
+
+	member, err := m.members.FindMemberByUserID(user.ID.String())
+	if err != nil {
 		log.Warn().
-			Str("userID", claims.UserID).
-			Str("role", claims.Role).
-			Msg("Access denied: Admin access only")
-		return c.Status(fiber.StatusForbidden).JSON(responses.NewErrorResponse("Access denied: Admin access only"))
+			Str("userID", user.ID.String()).
+			Msg("Access denied: Organizer access only")
+		return ErrOrganizerRequired
 	}
 
-	c.Locals("userID", claims.UserID)
-	c.Locals("role", claims.Role)
+	c.Locals("organizationID", member.OrganizationID.String())
 
 	return c.Next()
 }
@@ -1,13 +1,14 @@
 package booking
 
 import (
+	"event-booking/internal/api/query"
 	"event-booking/internal/api/responses"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/api/validator"
 	"event-booking/internal/entity"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type httpHandler struct {
@@ -23,19 +24,20 @@ func NewHttpHandler(svc *Service, validator *validator.Validator) *httpHandler {
 }
 
 type BookingInputPayload struct {
-	UserID   uuid.UUID `json:"user_id" validate:"required"`
-	EventID  uuid.UUID `json:"event_id" validate:"required"`
-	Quantity int       `json:"quantity" validate:"required"`
+	UserID   uuid.UUID   `json:"user_id" validate:"required"`
+	EventID  uuid.UUID   `json:"event_id" validate:"required"`
+	Quantity int         `json:"quantity" validate:"required_without=SeatIDs"`
+	SeatIDs  []uuid.UUID `json:"seat_ids" validate:"omitempty,unique"`
 }
 
 func (h *httpHandler) BookEventHandler(c *fiber.Ctx) error {
 	book := new(BookingInputPayload)
 	if err := c.BodyParser(book); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(book); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	newBook := &entity.Booking{
@@ -43,10 +45,13 @@ func (h *httpHandler) BookEventHandler(c *fiber.Ctx) error {
 		EventID:  book.EventID,
 		Quantity: book.Quantity,
 	}
+	for _, seatID := range book.SeatIDs {
+		newBook.Seats = append(newBook.Seats, entity.BookingSeat{SeatID: seatID})
+	}
 
 	newBook, err := h.svc.CreateBookingService(newBook)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Booking created successfully", responses.BookingResponseObject{
@@ -55,19 +60,38 @@ func (h *httpHandler) BookEventHandler(c *fiber.Ctx) error {
 		EventID:    newBook.EventID,
 		Quantity:   newBook.Quantity,
 		TotalPrice: newBook.TotalPrice,
+		SeatIDs:    seatIDsOf(newBook),
+		Status:     newBook.Status,
 		CreatedAt:  newBook.CreatedAt,
 		UpdatedAt:  newBook.UpdatedAt,
 	}))
 }
 
+var listQueryConfig = query.Config{
+	Sorts:       []string{"created_at", "quantity", "total_price"},
+	Filters:     []string{"event_id", "user_id"},
+	DefaultSort: "-created_at",
+}
+
 func (h *httpHandler) GetBookedEventsHandler(c *fiber.Ctx) error {
-	bookings, err := h.svc.FindAllBookingService()
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	// admins see every booking and organizers the bookings of their events;
+	// everyone else only their own
+	var bookings []entity.Booking
+	var total int64
+	scope := tenant.FromContext(c)
+	if c.Locals("role") == "admin" || !scope.Global() {
+		bookings, total, err = h.svc.FindAllBookingService(scope, opts)
+	} else {
+		userID, _ := c.Locals("userID").(string)
+		bookings, total, err = h.svc.FindByUserIDBookingService(scope, userID, opts)
+	}
 	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return c.Status(fiber.StatusNotFound).JSON(responses.NewErrorResponse("Booking not found"))
-		} else {
-			return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
-		}
+		return err
 	}
 
 	var bookedEvents []responses.BookingResponseObject
@@ -78,23 +102,21 @@ func (h *httpHandler) GetBookedEventsHandler(c *fiber.Ctx) error {
 			EventID:    book.EventID,
 			Quantity:   book.Quantity,
 			TotalPrice: book.TotalPrice,
+			SeatIDs:    seatIDsOf(&book),
+			Status:     book.Status,
 			CreatedAt:  book.CreatedAt,
 			UpdatedAt:  book.UpdatedAt,
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Bookings found", bookedEvents))
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Bookings found", bookedEvents, opts.Pagination(total)))
 }
 
 func (h *httpHandler) GetBookedEventByIDHandler(c *fiber.Ctx) error {
 	id := c.Params("id")
 	book, err := h.svc.FindBookingService(id)
 	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return c.Status(fiber.StatusNotFound).JSON(responses.NewErrorResponse("Booking not found"))
-		} else {
-			return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
-		}
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Booking found", responses.BookingResponseObject{
@@ -103,6 +125,8 @@ func (h *httpHandler) GetBookedEventByIDHandler(c *fiber.Ctx) error {
 		EventID:    book.EventID,
 		Quantity:   book.Quantity,
 		TotalPrice: book.TotalPrice,
+		SeatIDs:    seatIDsOf(book),
+		Status:     book.Status,
 		CreatedAt:  book.CreatedAt,
 		UpdatedAt:  book.UpdatedAt,
 	}))
@@ -112,16 +136,12 @@ func (h *httpHandler) CancelBookedEventHandler(c *fiber.Ctx) error {
 	id := c.Params("id")
 	book, err := h.svc.FindBookingService(id)
 	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return c.Status(fiber.StatusNotFound).JSON(responses.NewErrorResponse("Booking not found"))
-		} else {
-			return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
-		}
+		return err
 	}
 
 	err = h.svc.DeleteBookingService(id, book)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Booking canceled successfully"))
@@ -132,20 +152,16 @@ func (h *httpHandler) UpdateBookedEventHandler(c *fiber.Ctx) error {
 	newBook := new(BookingInputPayload)
 
 	if err := c.BodyParser(newBook); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(newBook); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	book, err := h.svc.SaveBookingService(id, *newBook)
 	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return c.Status(fiber.StatusNotFound).JSON(responses.NewErrorResponse("Booking not found"))
-		} else {
-			return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
-		}
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Booking updated successfully", responses.BookingResponseObject{
@@ -154,6 +170,8 @@ func (h *httpHandler) UpdateBookedEventHandler(c *fiber.Ctx) error {
 		EventID:    book.EventID,
 		Quantity:   book.Quantity,
 		TotalPrice: book.TotalPrice,
+		SeatIDs:    seatIDsOf(book),
+		Status:     book.Status,
 		CreatedAt:  book.CreatedAt,
 		UpdatedAt:  book.UpdatedAt,
 	}))
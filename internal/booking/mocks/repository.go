@@ -5,6 +5,10 @@ package mocks
 import (
 	entity "event-booking/internal/entity"
 
+	query "event-booking/internal/api/query"
+
+	tenant "event-booking/internal/api/tenant"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -91,94 +95,115 @@ func (_m *Repository) Find(id string) (*entity.Booking, error) {
 	return r0, r1
 }
 
-// FindAll provides a mock function with given fields:
-func (_m *Repository) FindAll() ([]entity.Booking, error) {
-	ret := _m.Called()
+// FindAll provides a mock function with given fields: scope, opts
+func (_m *Repository) FindAll(scope tenant.Scope, opts query.Options) ([]entity.Booking, int64, error) {
+	ret := _m.Called(scope, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindAll")
 	}
 
 	var r0 []entity.Booking
-	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]entity.Booking, error)); ok {
-		return rf()
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(tenant.Scope, query.Options) ([]entity.Booking, int64, error)); ok {
+		return rf(scope, opts)
 	}
-	if rf, ok := ret.Get(0).(func() []entity.Booking); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(tenant.Scope, query.Options) []entity.Booking); ok {
+		r0 = rf(scope, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(tenant.Scope, query.Options) int64); ok {
+		r1 = rf(scope, opts)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(tenant.Scope, query.Options) error); ok {
+		r2 = rf(scope, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// FindByEventID provides a mock function with given fields: eventID
-func (_m *Repository) FindByEventID(eventID string) ([]entity.Booking, error) {
-	ret := _m.Called(eventID)
+// FindByEventID provides a mock function with given fields: scope, eventID, opts
+func (_m *Repository) FindByEventID(scope tenant.Scope, eventID string, opts query.Options) ([]entity.Booking, int64, error) {
+	ret := _m.Called(scope, eventID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByEventID")
 	}
 
 	var r0 []entity.Booking
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]entity.Booking, error)); ok {
-		return rf(eventID)
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string, query.Options) ([]entity.Booking, int64, error)); ok {
+		return rf(scope, eventID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string) []entity.Booking); ok {
-		r0 = rf(eventID)
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string, query.Options) []entity.Booking); ok {
+		r0 = rf(scope, eventID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(eventID)
+	if rf, ok := ret.Get(1).(func(tenant.Scope, string, query.Options) int64); ok {
+		r1 = rf(scope, eventID, opts)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(tenant.Scope, string, query.Options) error); ok {
+		r2 = rf(scope, eventID, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// FindByUserID provides a mock function with given fields: userID
-func (_m *Repository) FindByUserID(userID string) ([]entity.Booking, error) {
-	ret := _m.Called(userID)
+// FindByUserID provides a mock function with given fields: scope, userID, opts
+func (_m *Repository) FindByUserID(scope tenant.Scope, userID string, opts query.Options) ([]entity.Booking, int64, error) {
+	ret := _m.Called(scope, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByUserID")
 	}
 
 	var r0 []entity.Booking
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]entity.Booking, error)); ok {
-		return rf(userID)
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string, query.Options) ([]entity.Booking, int64, error)); ok {
+		return rf(scope, userID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(string) []entity.Booking); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string, query.Options) []entity.Booking); ok {
+		r0 = rf(scope, userID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(tenant.Scope, string, query.Options) int64); ok {
+		r1 = rf(scope, userID, opts)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(tenant.Scope, string, query.Options) error); ok {
+		r2 = rf(scope, userID, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // Save provides a mock function with given fields: _a0
@@ -1,8 +1,12 @@
 package booking
 
 import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/entity"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -17,61 +21,124 @@ func NewRepository(db *gorm.DB) *repo {
 }
 
 func (r *repo) Create(booking *entity.Booking) (*entity.Booking, error) {
-	if err := r.db.Create(booking).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Seats").Create(booking).Error; err != nil {
+			return err
+		}
+
+		return createSeats(tx, booking)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return booking, nil
 }
 
+// Save also replaces the booking's seats with booking.Seats.
 func (r *repo) Save(booking *entity.Booking) (*entity.Booking, error) {
-	if err := r.db.Save(booking).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Seats").Save(booking).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("booking_id = ?", booking.ID).Delete(&entity.BookingSeat{}).Error; err != nil {
+			return err
+		}
+
+		return createSeats(tx, booking)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return booking, nil
 }
 
-func (r *repo) FindAll() ([]entity.Booking, error) {
-	var bookings []entity.Booking
-	if err := r.db.Model(&entity.Booking{}).Preload("Event").Preload("User").Find(&bookings).Error; err != nil {
-		return nil, err
+// createSeats inserts the seat assignments, the unique (event_id, seat_id)
+// index rejects seats another booking already holds.
+func createSeats(tx *gorm.DB, booking *entity.Booking) error {
+	if len(booking.Seats) == 0 {
+		return nil
 	}
 
-	return bookings, nil
+	for i := range booking.Seats {
+		booking.Seats[i].ID = uuid.Nil
+		booking.Seats[i].BookingID = booking.ID
+	}
+
+	return tx.Omit("Seat").Create(&booking.Seats).Error
+}
+
+func (r *repo) FindAll(scope tenant.Scope, opts query.Options) ([]entity.Booking, int64, error) {
+	return r.findPage(r.db.Model(&entity.Booking{}).Scopes(scope.EventRows).Preload("Event").Preload("User"), opts)
+}
+
+func (r *repo) FindByUserID(scope tenant.Scope, userID string, opts query.Options) ([]entity.Booking, int64, error) {
+	return r.findPage(r.db.Model(&entity.Booking{}).Scopes(scope.EventRows).Where("user_id = ?", userID), opts)
 }
 
-func (r *repo) FindByUserID(userID string) ([]entity.Booking, error) {
+// FindByUserIDWithDeleted returns every booking of a user, soft deleted ones
+// included, for the personal data export.
+func (r *repo) FindByUserIDWithDeleted(userID string) ([]entity.Booking, error) {
 	var bookings []entity.Booking
-	if err := r.db.Where("user_id = ?", userID).Find(&bookings).Error; err != nil {
+	if err := r.db.Unscoped().Preload("Seats").Where("user_id = ?", userID).Find(&bookings).Error; err != nil {
 		return nil, err
 	}
 
 	return bookings, nil
 }
 
-func (r *repo) FindByEventID(eventID string) ([]entity.Booking, error) {
+func (r *repo) FindByEventID(scope tenant.Scope, eventID string, opts query.Options) ([]entity.Booking, int64, error) {
+	return r.findPage(r.db.Model(&entity.Booking{}).Scopes(scope.EventRows).Preload("Event").Preload("User").Where("event_id = ?", eventID), opts)
+}
+
+func (r *repo) findPage(db *gorm.DB, opts query.Options) ([]entity.Booking, int64, error) {
+	db = db.Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var bookings []entity.Booking
-	if err := r.db.Model(&entity.Booking{}).Preload("Event").Preload("User").Where("event_id = ?", eventID).Find(&bookings).Error; err != nil {
-		return nil, err
+	if err := db.Scopes(opts.Paginate).Preload("Seats").Find(&bookings).Error; err != nil {
+		return nil, 0, err
 	}
 
-	return bookings, nil
+	return bookings, total, nil
 }
 
 func (r *repo) Find(id string) (*entity.Booking, error) {
 	booking := new(entity.Booking)
-	if err := r.db.Where("id = ?", id).Preload("Event").Preload("User").First(booking).Error; err != nil {
+	if err := r.db.Where("id = ?", id).Preload("Event").Preload("User").Preload("Seats").First(booking).Error; err != nil {
 		return nil, err
 	}
 
 	return booking, nil
 }
 
+// Delete cancels the booking, releases its seats and soft deletes it. The
+// row is kept, together with a refund if it has one, until it is purged.
 func (r *repo) Delete(id string) error {
-	if err := r.db.Where("id = ?", id).Delete(&entity.Booking{}).Error; err != nil {
-		return err
-	}
-
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var booking entity.Booking
+		if err := tx.Where("id = ?", id).First(&booking).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&booking).Updates(map[string]interface{}{
+			"status":       entity.BookingStatusCancelled,
+			"cancelled_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("booking_id = ?", booking.ID).Delete(&entity.BookingSeat{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&booking).Error
+	})
 }
@@ -2,9 +2,18 @@ package booking
 
 import (
 	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
+	"event-booking/internal/apperror"
+	"event-booking/internal/calendar"
 	"event-booking/internal/entity"
+	"event-booking/internal/notification"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 //go:generate mockery --case snake --name Repository
@@ -12,9 +21,9 @@ type Repository interface {
 	Create(booking *entity.Booking) (*entity.Booking, error)
 	Save(booking *entity.Booking) (*entity.Booking, error)
 	Find(id string) (*entity.Booking, error)
-	FindAll() ([]entity.Booking, error)
-	FindByUserID(userID string) ([]entity.Booking, error)
-	FindByEventID(eventID string) ([]entity.Booking, error)
+	FindAll(scope tenant.Scope, opts query.Options) ([]entity.Booking, int64, error)
+	FindByUserID(scope tenant.Scope, userID string, opts query.Options) ([]entity.Booking, int64, error)
+	FindByEventID(scope tenant.Scope, eventID string, opts query.Options) ([]entity.Booking, int64, error)
 	Delete(id string) error
 }
 
@@ -24,15 +33,48 @@ type EventRepository interface {
 	Save(event *entity.Event) (*entity.Event, error)
 }
 
+//go:generate mockery --case snake --name SeatRepository
+type SeatRepository interface {
+	FindSeatsByVenueID(venueID string, seatIDs []uuid.UUID) ([]entity.Seat, error)
+}
+
+//go:generate mockery --case snake --name EventPublisher
+type EventPublisher interface {
+	Publish(eventType string, data interface{})
+}
+
+//go:generate mockery --case snake --name Notifier
+type Notifier interface {
+	Notify(notification notification.Notification)
+}
+
+var (
+	ErrBookingNotFound = apperror.NotFound("booking_not_found", "booking not found")
+	ErrEventNotFound   = apperror.NotFound("event_not_found", "event not found")
+	ErrNotEnoughSeat   = apperror.Conflict("not_enough_seats", "not enough seat available")
+	ErrInvalidSeats    = apperror.Validation("invalid_seats", "invalid seat selection")
+	ErrSeatTaken       = apperror.Conflict("seat_taken", "one or more seats are already booked")
+	ErrSalesClosed     = apperror.Conflict("sales_closed", "ticket sales for this event are closed")
+	// ErrBookingCancelled is returned for bookings cancelled along with their
+	// event; they are kept for their refund and can no longer be changed.
+	ErrBookingCancelled = apperror.Conflict("booking_cancelled", "booking has been cancelled")
+)
+
 type Service struct {
 	repo            Repository
 	eventRepository EventRepository
+	seatRepository  SeatRepository
+	publisher       EventPublisher
+	notifier        Notifier
 }
 
-func NewService(repo Repository, eventRepository EventRepository) *Service {
+func NewService(repo Repository, eventRepository EventRepository, seatRepository SeatRepository, publisher EventPublisher, notifier Notifier) *Service {
 	return &Service{
 		repo:            repo,
 		eventRepository: eventRepository,
+		seatRepository:  seatRepository,
+		publisher:       publisher,
+		notifier:        notifier,
 	}
 }
 
@@ -40,19 +82,35 @@ func (s *Service) CreateBookingService(booking *entity.Booking) (*entity.Booking
 	event, err := s.eventRepository.Find(booking.EventID.String())
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	if !event.SalesOpen(time.Now()) {
+		return nil, ErrSalesClosed
+	}
+
+	seatIDs := make([]uuid.UUID, 0, len(booking.Seats))
+	for _, seat := range booking.Seats {
+		seatIDs = append(seatIDs, seat.SeatID)
+	}
+	if err := s.assignSeats(event, booking, seatIDs); err != nil {
 		return nil, err
 	}
 
 	event.AvailableSeat -= booking.Quantity
 	if event.AvailableSeat < 0 {
-		return nil, errors.New("not enough seat available")
+		return nil, ErrNotEnoughSeat
 	}
 
 	booking.TotalPrice = event.Price * float64(booking.Quantity)
+	booking.Status = entity.BookingStatusConfirmed
 
 	booking, err = s.repo.Create(booking)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrSeatTaken
+		}
 		return nil, err
 	}
 
@@ -62,6 +120,9 @@ func (s *Service) CreateBookingService(booking *entity.Booking) (*entity.Booking
 		return nil, err
 	}
 
+	s.publish(entity.WebhookEventBookingCreated, booking)
+	s.notify(notification.TypeBookingConfirmed, booking, event)
+
 	return booking, nil
 }
 
@@ -69,23 +130,35 @@ func (s *Service) SaveBookingService(id string, newBooking BookingInputPayload)
 	booking, err := s.repo.Find(id)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, apperror.IfNotFound(err, ErrBookingNotFound)
+	}
+
+	if booking.Status == entity.BookingStatusCancelled {
+		return nil, ErrBookingCancelled
 	}
 
 	event, err := s.eventRepository.Find(booking.EventID.String())
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	if !event.SalesOpen(time.Now()) {
+		return nil, ErrSalesClosed
 	}
 
 	event.AvailableSeat += booking.Quantity
-	event.AvailableSeat -= newBooking.Quantity
+	booking.Quantity = newBooking.Quantity
+	if err := s.assignSeats(event, booking, newBooking.SeatIDs); err != nil {
+		return nil, err
+	}
+
+	event.AvailableSeat -= booking.Quantity
 	if event.AvailableSeat < 0 {
-		return nil, errors.New("not enough seat available")
+		return nil, ErrNotEnoughSeat
 	}
 
-	booking.Quantity = newBooking.Quantity
-	booking.TotalPrice = event.Price * float64(newBooking.Quantity)
+	booking.TotalPrice = event.Price * float64(booking.Quantity)
 
 	_, err = s.eventRepository.Save(event)
 	if err != nil {
@@ -96,57 +169,66 @@ func (s *Service) SaveBookingService(id string, newBooking BookingInputPayload)
 	booking, err = s.repo.Save(booking)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrSeatTaken
+		}
 		return nil, err
 	}
 
+	s.publish(entity.WebhookEventBookingUpdated, booking)
+
 	return booking, nil
 }
 
-func (s *Service) FindAllBookingService() ([]entity.Booking, error) {
-	bookings, err := s.repo.FindAll()
+func (s *Service) FindAllBookingService(scope tenant.Scope, opts query.Options) ([]entity.Booking, int64, error) {
+	bookings, total, err := s.repo.FindAll(scope, opts)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, 0, err
 	}
 
-	return bookings, nil
+	return bookings, total, nil
 }
 
-func (s *Service) FindByUserIDBookingService(userID string) ([]entity.Booking, error) {
-	bookings, err := s.repo.FindByUserID(userID)
+func (s *Service) FindByUserIDBookingService(scope tenant.Scope, userID string, opts query.Options) ([]entity.Booking, int64, error) {
+	bookings, total, err := s.repo.FindByUserID(scope, userID, opts)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, 0, err
 	}
 
-	return bookings, nil
+	return bookings, total, nil
 }
 
-func (s *Service) FindByEventIDBookingService(eventID string) ([]entity.Booking, error) {
-	bookings, err := s.repo.FindByEventID(eventID)
+func (s *Service) FindByEventIDBookingService(scope tenant.Scope, eventID string, opts query.Options) ([]entity.Booking, int64, error) {
+	bookings, total, err := s.repo.FindByEventID(scope, eventID, opts)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, 0, err
 	}
 
-	return bookings, nil
+	return bookings, total, nil
 }
 
 func (s *Service) FindBookingService(id string) (*entity.Booking, error) {
 	booking, err := s.repo.Find(id)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, apperror.IfNotFound(err, ErrBookingNotFound)
 	}
 
 	return booking, nil
 }
 
 func (s *Service) DeleteBookingService(id string, book *entity.Booking) error {
+	if book.Status == entity.BookingStatusCancelled {
+		return ErrBookingCancelled
+	}
+
 	event, err := s.eventRepository.Find(book.EventID.String())
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return err
+		return apperror.IfNotFound(err, ErrEventNotFound)
 	}
 
 	event.AvailableSeat += book.Quantity
@@ -163,5 +245,94 @@ func (s *Service) DeleteBookingService(id string, book *entity.Booking) error {
 		return err
 	}
 
+	s.publish(entity.WebhookEventBookingCancelled, book)
+	s.notify(notification.TypeBookingCancelled, book, event)
+
+	return nil
+}
+
+// assignSeats checks the requested seats against the event's venue and sets
+// them on the booking. Events with assigned seating require seats and the
+// quantity follows the number of seats; the database guarantees that a seat
+// is only booked once per event.
+func (s *Service) assignSeats(event *entity.Event, booking *entity.Booking, seatIDs []uuid.UUID) error {
+	if event.VenueID == nil {
+		if len(seatIDs) > 0 {
+			return fmt.Errorf("%w: event has no assigned seating", ErrInvalidSeats)
+		}
+		return nil
+	}
+
+	if len(seatIDs) == 0 {
+		return fmt.Errorf("%w: event has assigned seating, seat_ids are required", ErrInvalidSeats)
+	}
+
+	seats, err := s.seatRepository.FindSeatsByVenueID(event.VenueID.String(), seatIDs)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+	if len(seats) != len(seatIDs) {
+		return fmt.Errorf("%w: seats do not belong to the event venue", ErrInvalidSeats)
+	}
+
+	booking.Quantity = len(seatIDs)
+	booking.Seats = make([]entity.BookingSeat, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		booking.Seats = append(booking.Seats, entity.BookingSeat{
+			EventID: event.ID,
+			SeatID:  seatID,
+		})
+	}
+
 	return nil
 }
+
+// publish notifies subscribers about a booking change.
+func (s *Service) publish(eventType string, booking *entity.Booking) {
+	if s.publisher == nil {
+		return
+	}
+
+	s.publisher.Publish(eventType, entity.NewWebhookBookingPayload(booking))
+}
+
+func seatIDsOf(booking *entity.Booking) []uuid.UUID {
+	var seatIDs []uuid.UUID
+	for _, seat := range booking.Seats {
+		seatIDs = append(seatIDs, seat.SeatID)
+	}
+
+	return seatIDs
+}
+
+// notify tells the booker about their booking.
+func (s *Service) notify(notificationType string, booking *entity.Booking, event *entity.Event) {
+	if s.notifier == nil {
+		return
+	}
+
+	n := notification.Notification{
+		Type:   notificationType,
+		UserID: booking.UserID,
+		Data: notification.BookingData{
+			BookingID:  booking.ID,
+			EventName:  event.Name,
+			Location:   event.Location,
+			StartDate:  event.StartDate,
+			Quantity:   booking.Quantity,
+			TotalPrice: booking.TotalPrice,
+		},
+	}
+
+	// the confirmation carries the event so it can be added to a calendar
+	if notificationType == notification.TypeBookingConfirmed {
+		n.Attachments = []entity.EmailAttachment{{
+			Filename:    "event.ics",
+			ContentType: calendar.ContentType,
+			Content:     calendar.Marshal("", time.Now(), *event),
+		}}
+	}
+
+	s.notifier.Notify(n)
+}
@@ -1,48 +1,72 @@
 package booking
 
 import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/booking/mocks"
 	"event-booking/internal/entity"
+	"event-booking/internal/notification"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestCreateBookingService(t *testing.T) {
 	mockBookingRepo := mocks.NewRepository(t)
 	mockEventRepo := mocks.NewEventRepository(t)
 
-	mockRequest := &entity.Booking{
-		EventID:  uuid.New(),
-		UserID:   uuid.New(),
-		Quantity: 2,
+	eventID := uuid.New()
+	userID := uuid.New()
+
+	newRequest := func(quantity int) *entity.Booking {
+		return &entity.Booking{
+			EventID:  eventID,
+			UserID:   userID,
+			Quantity: quantity,
+		}
 	}
 
-	mockEvent := &entity.Event{
-		ID:            mockRequest.EventID,
-		AvailableSeat: 10,
-		Price:         100,
+	newEvent := func() *entity.Event {
+		return &entity.Event{
+			ID:            eventID,
+			AvailableSeat: 10,
+			Price:         100,
+			Status:        entity.EventStatusPublished,
+			StartDate:     time.Now().Add(24 * time.Hour),
+			EndDate:       time.Now().Add(26 * time.Hour),
+		}
 	}
 
 	expectedBooking := &entity.Booking{
 		ID:         uuid.New(),
-		EventID:    mockRequest.EventID,
-		UserID:     mockRequest.UserID,
-		Quantity:   mockRequest.Quantity,
-		TotalPrice: float64(float64(mockRequest.Quantity) * mockEvent.Price),
+		EventID:    eventID,
+		UserID:     userID,
+		Quantity:   2,
+		TotalPrice: 200,
 	}
 
 	t.Run("create booking successfully", func(t *testing.T) {
-		mockEventRepo.On("Find", mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockRequest := newRequest(2)
+		mockEvent := newEvent()
+		mockEventRepo.On("Find", eventID.String()).Return(mockEvent, nil).Once()
 
 		mockBookingRepo.On("Create", mockRequest).Return(expectedBooking, nil).Once()
 
-		mockEvent.AvailableSeat -= mockRequest.Quantity
+		mockEventRepo.On("Save", mock.MatchedBy(func(e *entity.Event) bool {
+			return e.ID == eventID && e.AvailableSeat == 8
+		})).Return(mockEvent, nil).Once()
 
-		mockEventRepo.On("Save", mockEvent).Return(mockEvent, nil).Once()
+		mockNotifier := mocks.NewNotifier(t)
+		mockNotifier.On("Notify", mock.MatchedBy(func(n notification.Notification) bool {
+			return n.Type == notification.TypeBookingConfirmed && n.UserID == expectedBooking.UserID &&
+				len(n.Attachments) == 1 && n.Attachments[0].Filename == "event.ics"
+		})).Once()
 
-		svc := NewService(mockBookingRepo, mockEventRepo)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, mockNotifier)
 		booking, err := svc.CreateBookingService(mockRequest)
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
@@ -52,43 +76,42 @@ func TestCreateBookingService(t *testing.T) {
 	})
 
 	t.Run("not enough seat available", func(t *testing.T) {
-		mockEventRepo.On("Find", mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockEventRepo.On("Find", eventID.String()).Return(newEvent(), nil).Once()
 
-		mockRequest.Quantity = 20
-
-		svc := NewService(mockBookingRepo, mockEventRepo)
-		_, err := svc.CreateBookingService(mockRequest)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
+		_, err := svc.CreateBookingService(newRequest(20))
 		assert.Equal(t, "not enough seat available", err.Error())
 	})
 
 	t.Run("find event error", func(t *testing.T) {
-		mockEventRepo.On("Find", mockEvent.ID.String()).Return(nil, assert.AnError).Once()
+		mockEventRepo.On("Find", eventID.String()).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, mockEventRepo)
-		_, err := svc.CreateBookingService(mockRequest)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
+		_, err := svc.CreateBookingService(newRequest(2))
 		assert.Equal(t, assert.AnError, err)
 	})
 
 	t.Run("create booking error", func(t *testing.T) {
-		mockEventRepo.On("Find", mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockRequest := newRequest(2)
+		mockEventRepo.On("Find", eventID.String()).Return(newEvent(), nil).Once()
 
 		mockBookingRepo.On("Create", mockRequest).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, mockEventRepo)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
 		_, err := svc.CreateBookingService(mockRequest)
 		assert.Equal(t, assert.AnError, err)
 	})
 
 	t.Run("save event error", func(t *testing.T) {
-		mockEventRepo.On("Find", mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockRequest := newRequest(2)
+		mockEvent := newEvent()
+		mockEventRepo.On("Find", eventID.String()).Return(mockEvent, nil).Once()
 
 		mockBookingRepo.On("Create", mockRequest).Return(expectedBooking, nil).Once()
 
-		mockEvent.AvailableSeat -= mockRequest.Quantity
-
 		mockEventRepo.On("Save", mockEvent).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, mockEventRepo)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
 		_, err := svc.CreateBookingService(mockRequest)
 		assert.Equal(t, assert.AnError, err)
 	})
@@ -96,33 +119,58 @@ func TestCreateBookingService(t *testing.T) {
 
 func TestSaveBookingService(t *testing.T) {
 	mockBookingRepo := mocks.NewRepository(t)
+	mockEventRepo := mocks.NewEventRepository(t)
 
-	mockRequest := &entity.Booking{
-		ID:       uuid.New(),
-		EventID:  uuid.New(),
-		UserID:   uuid.New(),
-		Quantity: 3,
+	bookingID := uuid.New()
+	eventID := uuid.New()
+
+	newBooking := func() *entity.Booking {
+		return &entity.Booking{
+			ID:       bookingID,
+			EventID:  eventID,
+			UserID:   uuid.New(),
+			Quantity: 3,
+			Status:   entity.BookingStatusConfirmed,
+		}
+	}
+
+	newEvent := func() *entity.Event {
+		return &entity.Event{
+			ID:            eventID,
+			AvailableSeat: 10,
+			Price:         100,
+			Status:        entity.EventStatusPublished,
+			StartDate:     time.Now().Add(24 * time.Hour),
+			EndDate:       time.Now().Add(26 * time.Hour),
+		}
 	}
 
 	mockRequestUpdate := &BookingInputPayload{
-		EventID:  uuid.New(),
+		EventID:  eventID,
 		UserID:   uuid.New(),
 		Quantity: 2,
 	}
 
-	expectedBooking := &entity.Booking{
-		ID:         mockRequest.ID,
-		EventID:    mockRequest.EventID,
-		UserID:     mockRequest.UserID,
-		Quantity:   mockRequest.Quantity,
-		TotalPrice: 200,
-	}
-
 	t.Run("save booking successfully", func(t *testing.T) {
-		mockBookingRepo.On("Save", mockRequest).Return(expectedBooking, nil).Once()
+		mockBooking := newBooking()
+		expectedBooking := &entity.Booking{
+			ID:         bookingID,
+			EventID:    eventID,
+			UserID:     mockBooking.UserID,
+			Quantity:   2,
+			TotalPrice: 200,
+			Status:     entity.BookingStatusConfirmed,
+		}
+
+		mockBookingRepo.On("Find", bookingID.String()).Return(mockBooking, nil).Once()
+		mockEventRepo.On("Find", eventID.String()).Return(newEvent(), nil).Once()
+		mockEventRepo.On("Save", mock.MatchedBy(func(e *entity.Event) bool {
+			return e.ID == eventID && e.AvailableSeat == 11
+		})).Return(newEvent(), nil).Once()
+		mockBookingRepo.On("Save", expectedBooking).Return(expectedBooking, nil).Once()
 
-		svc := NewService(mockBookingRepo, nil)
-		booking, err := svc.SaveBookingService(mockRequest.ID.String(), *mockRequestUpdate)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
+		booking, err := svc.SaveBookingService(bookingID.String(), *mockRequestUpdate)
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
 		}
@@ -130,11 +178,24 @@ func TestSaveBookingService(t *testing.T) {
 		assert.Equal(t, expectedBooking, booking)
 	})
 
+	t.Run("cancelled booking", func(t *testing.T) {
+		mockBooking := newBooking()
+		mockBooking.Status = entity.BookingStatusCancelled
+		mockBookingRepo.On("Find", bookingID.String()).Return(mockBooking, nil).Once()
+
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
+		_, err := svc.SaveBookingService(bookingID.String(), *mockRequestUpdate)
+		assert.ErrorIs(t, err, ErrBookingCancelled)
+	})
+
 	t.Run("save booking error", func(t *testing.T) {
-		mockBookingRepo.On("Save", mockRequest).Return(nil, assert.AnError).Once()
+		mockBookingRepo.On("Find", bookingID.String()).Return(newBooking(), nil).Once()
+		mockEventRepo.On("Find", eventID.String()).Return(newEvent(), nil).Once()
+		mockEventRepo.On("Save", mock.Anything).Return(newEvent(), nil).Once()
+		mockBookingRepo.On("Save", mock.Anything).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, nil)
-		_, err := svc.SaveBookingService(mockRequest.ID.String(), *mockRequestUpdate)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
+		_, err := svc.SaveBookingService(bookingID.String(), *mockRequestUpdate)
 		assert.Equal(t, assert.AnError, err)
 	})
 }
@@ -158,22 +219,23 @@ func TestFindAllBookingService(t *testing.T) {
 	}
 
 	t.Run("find all booking successfully", func(t *testing.T) {
-		mockBookingRepo.On("FindAll").Return(mockBookings, nil).Once()
+		mockBookingRepo.On("FindAll", tenant.Scope{}, query.Options{}).Return(mockBookings, int64(len(mockBookings)), nil).Once()
 
-		svc := NewService(mockBookingRepo, nil)
-		bookings, err := svc.FindAllBookingService()
+		svc := NewService(mockBookingRepo, nil, nil, nil, nil)
+		bookings, total, err := svc.FindAllBookingService(tenant.Scope{}, query.Options{})
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
 		}
 
 		assert.Equal(t, mockBookings, bookings)
+		assert.Equal(t, int64(len(mockBookings)), total)
 	})
 
 	t.Run("find all booking error", func(t *testing.T) {
-		mockBookingRepo.On("FindAll").Return(nil, assert.AnError).Once()
+		mockBookingRepo.On("FindAll", tenant.Scope{}, query.Options{}).Return(nil, int64(0), assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, nil)
-		_, err := svc.FindAllBookingService()
+		svc := NewService(mockBookingRepo, nil, nil, nil, nil)
+		_, _, err := svc.FindAllBookingService(tenant.Scope{}, query.Options{})
 		assert.Equal(t, assert.AnError, err)
 	})
 }
@@ -191,7 +253,7 @@ func TestFindBookingByIDService(t *testing.T) {
 	t.Run("booking found", func(t *testing.T) {
 		mockBookingRepo.On("Find", mockRequest.ID.String()).Return(mockRequest, nil).Once()
 
-		svc := NewService(mockBookingRepo, nil)
+		svc := NewService(mockBookingRepo, nil, nil, nil, nil)
 		booking, err := svc.FindBookingService(mockRequest.ID.String())
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
@@ -203,7 +265,7 @@ func TestFindBookingByIDService(t *testing.T) {
 	t.Run("booking not found", func(t *testing.T) {
 		mockBookingRepo.On("Find", mockRequest.ID.String()).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, nil)
+		svc := NewService(mockBookingRepo, nil, nil, nil, nil)
 		_, err := svc.FindBookingService(mockRequest.ID.String())
 		assert.Equal(t, assert.AnError, err)
 	})
@@ -234,7 +296,7 @@ func TestDeleteBookingService(t *testing.T) {
 
 		mockEventRepo.On("Save", mockEvent).Return(mockEvent, nil).Once()
 
-		svc := NewService(mockBookingRepo, mockEventRepo)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
 		err := svc.DeleteBookingService(mockRequest.ID.String(), mockRequest)
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
@@ -244,7 +306,7 @@ func TestDeleteBookingService(t *testing.T) {
 	t.Run("find event error", func(t *testing.T) {
 		mockEventRepo.On("Find", mockEvent.ID.String()).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, mockEventRepo)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
 		err := svc.DeleteBookingService(mockRequest.ID.String(), mockRequest)
 		assert.Equal(t, assert.AnError, err)
 	})
@@ -256,7 +318,7 @@ func TestDeleteBookingService(t *testing.T) {
 
 		mockBookingRepo.On("Delete", mockRequest.ID.String()).Return(assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, mockEventRepo)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
 		err := svc.DeleteBookingService(mockRequest.ID.String(), mockRequest)
 		assert.Equal(t, assert.AnError, err)
 	})
@@ -270,8 +332,155 @@ func TestDeleteBookingService(t *testing.T) {
 
 		mockEventRepo.On("Save", mockEvent).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockBookingRepo, mockEventRepo)
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
 		err := svc.DeleteBookingService(mockRequest.ID.String(), mockRequest)
 		assert.Equal(t, assert.AnError, err)
 	})
 }
+
+func TestCreateBookingWithSeatsService(t *testing.T) {
+	venueID := uuid.New()
+	seatIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	newEvent := func() *entity.Event {
+		return &entity.Event{
+			ID:            uuid.New(),
+			VenueID:       &venueID,
+			TotalSeat:     10,
+			AvailableSeat: 10,
+			Price:         100,
+			Status:        entity.EventStatusPublished,
+			StartDate:     time.Now().Add(24 * time.Hour),
+			EndDate:       time.Now().Add(26 * time.Hour),
+		}
+	}
+
+	newRequest := func(event *entity.Event) *entity.Booking {
+		return &entity.Booking{
+			EventID: event.ID,
+			UserID:  uuid.New(),
+			Seats:   []entity.BookingSeat{{SeatID: seatIDs[0]}, {SeatID: seatIDs[1]}},
+		}
+	}
+
+	t.Run("books the selected seats", func(t *testing.T) {
+		mockBookingRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockSeatRepo := mocks.NewSeatRepository(t)
+
+		event := newEvent()
+		request := newRequest(event)
+
+		mockEventRepo.On("Find", event.ID.String()).Return(event, nil).Once()
+		mockSeatRepo.On("FindSeatsByVenueID", venueID.String(), seatIDs).Return([]entity.Seat{{ID: seatIDs[0]}, {ID: seatIDs[1]}}, nil).Once()
+		mockBookingRepo.On("Create", request).Return(request, nil).Once()
+		mockEventRepo.On("Save", event).Return(event, nil).Once()
+
+		svc := NewService(mockBookingRepo, mockEventRepo, mockSeatRepo, nil, nil)
+		booking, err := svc.CreateBookingService(request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, booking.Quantity)
+		assert.Equal(t, float64(200), booking.TotalPrice)
+		assert.Equal(t, 8, event.AvailableSeat)
+		for i, seat := range booking.Seats {
+			assert.Equal(t, event.ID, seat.EventID)
+			assert.Equal(t, seatIDs[i], seat.SeatID)
+		}
+	})
+
+	t.Run("seat already taken", func(t *testing.T) {
+		mockBookingRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockSeatRepo := mocks.NewSeatRepository(t)
+
+		event := newEvent()
+		request := newRequest(event)
+
+		mockEventRepo.On("Find", event.ID.String()).Return(event, nil).Once()
+		mockSeatRepo.On("FindSeatsByVenueID", venueID.String(), seatIDs).Return([]entity.Seat{{ID: seatIDs[0]}, {ID: seatIDs[1]}}, nil).Once()
+		mockBookingRepo.On("Create", request).Return(nil, gorm.ErrDuplicatedKey).Once()
+
+		svc := NewService(mockBookingRepo, mockEventRepo, mockSeatRepo, nil, nil)
+		_, err := svc.CreateBookingService(request)
+
+		assert.ErrorIs(t, err, ErrSeatTaken)
+	})
+
+	t.Run("seat from another venue", func(t *testing.T) {
+		mockBookingRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockSeatRepo := mocks.NewSeatRepository(t)
+
+		event := newEvent()
+		request := newRequest(event)
+
+		mockEventRepo.On("Find", event.ID.String()).Return(event, nil).Once()
+		mockSeatRepo.On("FindSeatsByVenueID", venueID.String(), seatIDs).Return([]entity.Seat{{ID: seatIDs[0]}}, nil).Once()
+
+		svc := NewService(mockBookingRepo, mockEventRepo, mockSeatRepo, nil, nil)
+		_, err := svc.CreateBookingService(request)
+
+		assert.ErrorIs(t, err, ErrInvalidSeats)
+	})
+
+	t.Run("seats are required", func(t *testing.T) {
+		mockBookingRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+
+		event := newEvent()
+		request := &entity.Booking{EventID: event.ID, UserID: uuid.New(), Quantity: 2}
+
+		mockEventRepo.On("Find", event.ID.String()).Return(event, nil).Once()
+
+		svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
+		_, err := svc.CreateBookingService(request)
+
+		assert.ErrorIs(t, err, ErrInvalidSeats)
+	})
+}
+
+func TestCreateBookingSalesWindowService(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	cases := map[string]*entity.Event{
+		"draft event": {
+			Status:    entity.EventStatusDraft,
+			StartDate: now.Add(24 * time.Hour),
+			EndDate:   now.Add(26 * time.Hour),
+		},
+		"cancelled event": {
+			Status:    entity.EventStatusCancelled,
+			StartDate: now.Add(24 * time.Hour),
+			EndDate:   now.Add(26 * time.Hour),
+		},
+		"sales not started": {
+			Status:       entity.EventStatusPublished,
+			StartDate:    now.Add(24 * time.Hour),
+			EndDate:      now.Add(26 * time.Hour),
+			SalesStartAt: &later,
+		},
+		"event already started": {
+			Status:    entity.EventStatusPublished,
+			StartDate: now.Add(-time.Hour),
+			EndDate:   now.Add(time.Hour),
+		},
+	}
+
+	for name, event := range cases {
+		t.Run(name, func(t *testing.T) {
+			event.ID = uuid.New()
+			event.AvailableSeat = 10
+
+			mockBookingRepo := mocks.NewRepository(t)
+			mockEventRepo := mocks.NewEventRepository(t)
+			mockEventRepo.On("Find", event.ID.String()).Return(event, nil).Once()
+
+			svc := NewService(mockBookingRepo, mockEventRepo, nil, nil, nil)
+			_, err := svc.CreateBookingService(&entity.Booking{EventID: event.ID, Quantity: 1})
+
+			assert.ErrorIs(t, err, ErrSalesClosed)
+		})
+	}
+}
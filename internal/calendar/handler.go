@@ -0,0 +1,63 @@
+package calendar
+
+import (
+	"event-booking/internal/api/responses"
+	"event-booking/internal/auth"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	svc *Service
+}
+
+func NewHttpHandler(svc *Service) *httpHandler {
+	return &httpHandler{
+		svc: svc,
+	}
+}
+
+// CreateFeedHandler issues the caller a feed URL to subscribe to in a
+// calendar app. Creating a feed again revokes the previous URL.
+func (h *httpHandler) CreateFeedHandler(c *fiber.Ctx) error {
+	id, _ := c.Locals("userID").(string)
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return auth.ErrUnauthorized
+	}
+
+	token, err := h.svc.CreateFeedService(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Calendar feed created", responses.CalendarFeedResponseObject{
+		Token: token,
+		URL:   c.BaseURL() + "/api/calendar/feed/" + token + ".ics",
+	}))
+}
+
+func (h *httpHandler) RevokeFeedHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+	if err := h.svc.RevokeFeedService(userID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Calendar feed revoked", nil))
+}
+
+// FeedHandler serves the feed of a token. Calendar apps cannot send an
+// Authorization header, so the token in the path is the credential.
+func (h *httpHandler) FeedHandler(c *fiber.Ctx) error {
+	body, err := h.svc.FeedService(strings.TrimSuffix(c.Params("token"), ".ics"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, ContentType)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=900")
+
+	return c.Status(fiber.StatusOK).Send(body)
+}
@@ -0,0 +1,107 @@
+package calendar
+
+import (
+	"bytes"
+	"event-booking/internal/entity"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of the documents Marshal produces.
+const ContentType = "text/calendar; charset=utf-8; method=PUBLISH"
+
+const (
+	prodID     = "-//event-booking//Event Booking//EN"
+	uidDomain  = "event-booking"
+	timeLayout = "20060102T150405Z"
+	// lines longer than this many octets are folded, RFC 5545 section 3.1
+	maxLineLength = 75
+)
+
+// Marshal renders the events as an RFC 5545 iCalendar document. name labels
+// the calendar in clients that show one, e.g. for subscribed feeds, and may be
+// empty. now is the DTSTAMP of every event.
+//
+// Event UIDs are stable, so clients update an event in place when it is
+// downloaded again or the feed is refreshed; cancelled events are kept with
+// STATUS:CANCELLED so they are struck from calendars instead of lingering.
+func Marshal(name string, now time.Time, events ...entity.Event) []byte {
+	w := &writer{}
+
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", prodID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if name != "" {
+		w.line("X-WR-CALNAME", escape(name))
+	}
+
+	for _, event := range events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", event.ID.String()+"@"+uidDomain)
+		w.line("DTSTAMP", formatTime(now))
+		w.line("DTSTART", formatTime(event.StartDate))
+		if event.EndDate.After(event.StartDate) {
+			w.line("DTEND", formatTime(event.EndDate))
+		}
+		w.line("SUMMARY", escape(event.Name))
+		if event.Location != "" {
+			w.line("LOCATION", escape(event.Location))
+		}
+		if event.Category != "" {
+			w.line("CATEGORIES", escape(event.Category))
+		}
+		if event.Status == entity.EventStatusCancelled {
+			w.line("STATUS", "CANCELLED")
+			if event.CancellationReason != "" {
+				w.line("DESCRIPTION", escape("Cancelled: "+event.CancellationReason))
+			}
+		} else {
+			w.line("STATUS", "CONFIRMED")
+		}
+		if !event.UpdatedAt.IsZero() {
+			w.line("LAST-MODIFIED", formatTime(event.UpdatedAt))
+		}
+		w.line("END", "VEVENT")
+	}
+
+	w.line("END", "VCALENDAR")
+
+	return w.buf.Bytes()
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line writes a content line, folding it at maxLineLength octets without
+// splitting a UTF-8 sequence.
+func (w *writer) line(name, value string) {
+	line := name + ":" + value
+
+	for limit := maxLineLength; len(line) > limit; limit = maxLineLength - 1 {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut])
+		w.buf.WriteString("\r\n ")
+		line = line[cut:]
+	}
+
+	w.buf.WriteString(line)
+	w.buf.WriteString("\r\n")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape escapes a TEXT value, RFC 5545 section 3.3.11.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
@@ -0,0 +1,65 @@
+package calendar
+
+import (
+	"event-booking/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshal(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	event := entity.Event{
+		ID:        uuid.MustParse("5b3c7a86-2a51-4a3e-8a0a-8f0d1cf3e7a4"),
+		Name:      "Jazz Night; Live, Loud",
+		Location:  "Blue Note\nJakarta",
+		StartDate: time.Date(2024, 6, 1, 19, 0, 0, 0, jakarta),
+		EndDate:   time.Date(2024, 6, 1, 22, 0, 0, 0, jakarta),
+		Status:    entity.EventStatusPublished,
+	}
+
+	t.Run("event", func(t *testing.T) {
+		ics := string(Marshal("", now, event))
+
+		assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(ics, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+		assert.Contains(t, ics, "\r\nUID:5b3c7a86-2a51-4a3e-8a0a-8f0d1cf3e7a4@event-booking\r\n")
+		assert.Contains(t, ics, "\r\nDTSTAMP:20240501T120000Z\r\n")
+		assert.Contains(t, ics, "\r\nDTSTART:20240601T120000Z\r\n")
+		assert.Contains(t, ics, "\r\nDTEND:20240601T150000Z\r\n")
+		assert.Contains(t, ics, "\r\nSUMMARY:Jazz Night\\; Live\\, Loud\r\n")
+		assert.Contains(t, ics, "\r\nLOCATION:Blue Note\\nJakarta\r\n")
+		assert.Contains(t, ics, "\r\nSTATUS:CONFIRMED\r\n")
+		assert.NotContains(t, ics, "X-WR-CALNAME")
+	})
+
+	t.Run("cancelled event", func(t *testing.T) {
+		cancelled := event
+		cancelled.Status = entity.EventStatusCancelled
+		cancelled.CancellationReason = "Venue flooded"
+
+		ics := string(Marshal("My Bookings", now, cancelled))
+
+		assert.Contains(t, ics, "\r\nX-WR-CALNAME:My Bookings\r\n")
+		assert.Contains(t, ics, "\r\nSTATUS:CANCELLED\r\n")
+		assert.Contains(t, ics, "\r\nDESCRIPTION:Cancelled: Venue flooded\r\n")
+	})
+
+	t.Run("long lines are folded", func(t *testing.T) {
+		long := event
+		long.Name = strings.Repeat("Grand Orchestra é ", 10)
+
+		ics := string(Marshal("", now, long))
+
+		for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+		}
+		unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+		assert.Contains(t, unfolded, "\r\nSUMMARY:"+long.Name+"\r\n")
+	})
+}
@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"event-booking/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+// SaveFeed creates the user's feed or replaces its token.
+func (r *repo) SaveFeed(feed *entity.CalendarFeed) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at", "last_used_at"}),
+	}).Create(feed).Error
+}
+
+func (r *repo) DeleteFeed(userID string) error {
+	result := r.db.Where("user_id = ?", userID).Delete(&entity.CalendarFeed{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// UseFeed finds the feed of a token and stamps it as used.
+func (r *repo) UseFeed(tokenHash string, now time.Time) (*entity.CalendarFeed, error) {
+	var feed entity.CalendarFeed
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&feed).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&feed).Update("last_used_at", now).Error; err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+// FindBookedEvents returns the events the user holds a confirmed booking
+// for, including cancelled ones so calendars can strike them.
+func (r *repo) FindBookedEvents(userID string) ([]entity.Event, error) {
+	var events []entity.Event
+	err := r.db.Where("id IN (?)", r.db.Model(&entity.Booking{}).
+		Select("event_id").
+		Where("user_id = ? AND status = ?", userID, entity.BookingStatusConfirmed)).
+		Order("start_date").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,94 @@
+package calendar
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	SaveFeed(feed *entity.CalendarFeed) error
+	DeleteFeed(userID string) error
+	UseFeed(tokenHash string, now time.Time) (*entity.CalendarFeed, error)
+	FindBookedEvents(userID string) ([]entity.Event, error)
+}
+
+var ErrFeedNotFound = apperror.NotFound("calendar_feed_not_found", "calendar feed not found")
+
+// feedName labels subscribed feeds in calendar apps.
+const feedName = "My Bookings"
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateFeedService issues a new feed token for the user, revoking the
+// previous one. The token is returned once and cannot be looked up later.
+func (s *Service) CreateFeedService(userID uuid.UUID) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return "", err
+	}
+	token := hex.EncodeToString(secret)
+
+	feed := &entity.CalendarFeed{
+		UserID:    userID,
+		TokenHash: hashToken(token),
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.SaveFeed(feed); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RevokeFeedService deletes the user's feed; subscribed calendars stop
+// updating.
+func (s *Service) RevokeFeedService(userID string) error {
+	if err := s.repo.DeleteFeed(userID); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return apperror.IfNotFound(err, ErrFeedNotFound)
+	}
+
+	return nil
+}
+
+// FeedService renders the calendar of the token's owner.
+func (s *Service) FeedService(token string) ([]byte, error) {
+	now := time.Now()
+
+	feed, err := s.repo.UseFeed(hashToken(token), now)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrFeedNotFound)
+	}
+
+	events, err := s.repo.FindBookedEvents(feed.UserID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return Marshal(feedName, now, events...), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
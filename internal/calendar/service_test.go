@@ -0,0 +1,63 @@
+package calendar
+
+import (
+	"event-booking/internal/calendar/mocks"
+	"event-booking/internal/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestFeed(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("token opens the owner's feed", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+
+		var stored *entity.CalendarFeed
+		mockRepo.On("SaveFeed", mock.MatchedBy(func(feed *entity.CalendarFeed) bool {
+			stored = feed
+			return feed.UserID == userID
+		})).Return(nil).Once()
+
+		svc := NewService(mockRepo)
+		token, err := svc.CreateFeedService(userID)
+		require.NoError(t, err)
+		assert.Len(t, token, 64)
+		assert.NotEqual(t, token, stored.TokenHash, "only a hash of the token is stored")
+
+		event := entity.Event{ID: uuid.New(), Name: "Jazz Night", StartDate: time.Now().Add(time.Hour)}
+		mockRepo.On("UseFeed", stored.TokenHash, mock.Anything).Return(stored, nil).Once()
+		mockRepo.On("FindBookedEvents", userID.String()).Return([]entity.Event{event}, nil).Once()
+
+		ics, err := svc.FeedService(token)
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(ics), "SUMMARY:Jazz Night")
+		assert.Equal(t, 1, strings.Count(string(ics), "BEGIN:VEVENT"))
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("UseFeed", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := NewService(mockRepo).FeedService("revoked")
+
+		assert.ErrorIs(t, err, ErrFeedNotFound)
+	})
+
+	t.Run("revoke without feed", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("DeleteFeed", userID.String()).Return(gorm.ErrRecordNotFound).Once()
+
+		err := NewService(mockRepo).RevokeFeedService(userID.String())
+
+		assert.ErrorIs(t, err, ErrFeedNotFound)
+	})
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v9"
 	"github.com/joho/godotenv"
@@ -26,6 +27,12 @@ type Config struct {
 	Database Database
 	RabbitMQ RabbitMQ
 	Smtp     Smtp
+	Review   Review
+	Email    Email
+	Reminder Reminder
+	Seats    Seats
+	Audit    Audit
+	Trash    Trash
 }
 
 type App struct {
@@ -52,6 +59,46 @@ type Smtp struct {
 	FromEmail string `env:"SMTP_FROM_EMAIL"`
 }
 
+type Email struct {
+	// MaxAttempts is how often the queue tries an email before moving it to
+	// the dead letters
+	MaxAttempts  int           `env:"EMAIL_MAX_ATTEMPTS" envDefault:"5"`
+	PollInterval time.Duration `env:"EMAIL_POLL_INTERVAL" envDefault:"5s"`
+}
+
+type Reminder struct {
+	// Offsets are how long before an event starts its booking holders are
+	// reminded
+	Offsets  []time.Duration `env:"REMINDER_OFFSETS" envSeparator:"," envDefault:"168h,24h"`
+	Interval time.Duration   `env:"REMINDER_INTERVAL" envDefault:"5m"`
+}
+
+type Seats struct {
+	// ReconcileInterval is how often the scheduler compares available seats
+	// with bookings, ReconcileCorrect whether it also fixes the drift
+	ReconcileInterval time.Duration `env:"SEAT_RECONCILE_INTERVAL" envDefault:"1h"`
+	ReconcileCorrect  bool          `env:"SEAT_RECONCILE_CORRECT" envDefault:"true"`
+}
+
+type Audit struct {
+	// Retention is how long audit entries are kept, 0 keeps them forever
+	Retention     time.Duration `env:"AUDIT_RETENTION" envDefault:"8760h"`
+	PurgeInterval time.Duration `env:"AUDIT_PURGE_INTERVAL" envDefault:"24h"`
+}
+
+type Trash struct {
+	// Retention is how long soft deleted records stay restorable before the
+	// purge command deletes them for good
+	Retention time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+}
+
+type Review struct {
+	// BlockedWords replaces the moderator's default word list
+	BlockedWords []string `env:"REVIEW_BLOCKED_WORDS" envSeparator:","`
+	// ReportThreshold is the number of open reports that flags a review
+	ReportThreshold int `env:"REVIEW_REPORT_THRESHOLD" envDefault:"3"`
+}
+
 func (d Database) DataSourceName() string {
 	return fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
 		d.User, d.Password, d.Host, d.Port, d.Name)
@@ -0,0 +1,78 @@
+package email
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type httpHandler struct {
+	queue *Queue
+}
+
+func NewHttpHandler(queue *Queue) *httpHandler {
+	return &httpHandler{
+		queue: queue,
+	}
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"created_at", "attempts", "next_attempt_at"},
+	Filters:     []string{"status", "to"},
+	DefaultSort: "-created_at",
+}
+
+// FindAllEmailHandler lists the outgoing queue, e.g. ?status=dead for the
+// dead letters.
+func (h *httpHandler) FindAllEmailHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	messages, total, err := h.queue.FindAllEmailService(opts)
+	if err != nil {
+		return err
+	}
+
+	emailResponses := []responses.EmailResponseObject{}
+	for i := range messages {
+		emailResponses = append(emailResponses, newEmailResponse(&messages[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Emails found", emailResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) FindEmailHandler(c *fiber.Ctx) error {
+	message, err := h.queue.FindEmailService(c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Email found", newEmailResponse(message)))
+}
+
+func (h *httpHandler) ResendEmailHandler(c *fiber.Ctx) error {
+	message, err := h.queue.ResendEmailService(c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(responses.NewDataResponse("Email queued for resend", newEmailResponse(message)))
+}
+
+func newEmailResponse(message *entity.EmailMessage) responses.EmailResponseObject {
+	return responses.EmailResponseObject{
+		ID:            message.ID,
+		To:            message.To,
+		Subject:       message.Subject,
+		Status:        message.Status,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		SentAt:        message.SentAt,
+		CreatedAt:     message.CreatedAt,
+	}
+}
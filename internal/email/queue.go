@@ -0,0 +1,192 @@
+package email
+
+import (
+	"context"
+	"event-booking/internal/api/query"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	Create(message *entity.EmailMessage) error
+	Save(message *entity.EmailMessage) error
+	Find(id string) (*entity.EmailMessage, error)
+	FindAll(opts query.Options) ([]entity.EmailMessage, int64, error)
+	ClaimDue(now time.Time, limit int, lease time.Duration) ([]entity.EmailMessage, error)
+}
+
+// Sender delivers a single email right away, see EmailService.
+//
+//go:generate mockery --case snake --name Sender
+type Sender interface {
+	SendEmail(to, subject, htmlBody, textBody string, attachments ...entity.EmailAttachment) error
+}
+
+var (
+	ErrEmailNotFound = apperror.NotFound("email_not_found", "email not found")
+	ErrEmailQueued   = apperror.Conflict("email_queued", "email is still queued")
+)
+
+// Queue stores outgoing emails and delivers them from a worker, so callers
+// never wait for or fail on the SMTP server. Failed deliveries are retried
+// with exponential backoff; after maxAttempts the email is dead-lettered
+// until an admin resends it.
+type Queue struct {
+	repo         Repository
+	sender       Sender
+	maxAttempts  int
+	baseBackoff  time.Duration
+	pollInterval time.Duration
+	batchSize    int
+	lease        time.Duration
+}
+
+func NewQueue(repo Repository, sender Sender, maxAttempts int, pollInterval time.Duration) *Queue {
+	return &Queue{
+		repo:         repo,
+		sender:       sender,
+		maxAttempts:  maxAttempts,
+		baseBackoff:  30 * time.Second,
+		pollInterval: pollInterval,
+		batchSize:    20,
+		lease:        5 * time.Minute,
+	}
+}
+
+// SendEmail queues an email; the error only reports whether it was stored.
+func (q *Queue) SendEmail(to, subject, htmlBody, textBody string, attachments ...entity.EmailAttachment) error {
+	message := &entity.EmailMessage{
+		To:            to,
+		Subject:       subject,
+		HTMLBody:      htmlBody,
+		TextBody:      textBody,
+		Status:        entity.EmailStatusQueued,
+		NextAttemptAt: time.Now(),
+		Attachments:   attachments,
+	}
+
+	if err := q.repo.Create(message); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Run delivers due emails every pollInterval until ctx is done.
+func (q *Queue) Run(ctx context.Context) {
+	ticker := time.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := q.ProcessDue(time.Now())
+			if err != nil {
+				log.Error().Err(err).Msg("failed to process email queue")
+			}
+			// a full batch means more emails are probably due
+			if err != nil || processed < q.batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue makes one delivery attempt for each email due at now and
+// returns how many it attempted.
+func (q *Queue) ProcessDue(now time.Time) (int, error) {
+	messages, err := q.repo.ClaimDue(now, q.batchSize, q.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range messages {
+		q.deliver(&messages[i], now)
+	}
+
+	return len(messages), nil
+}
+
+func (q *Queue) deliver(message *entity.EmailMessage, now time.Time) {
+	message.Attempts++
+
+	err := q.sender.SendEmail(message.To, message.Subject, message.HTMLBody, message.TextBody, message.Attachments...)
+	switch {
+	case err == nil:
+		message.Status = entity.EmailStatusSent
+		message.SentAt = &now
+		message.LastError = ""
+	case message.Attempts >= q.maxAttempts:
+		log.Warn().Str("emailID", message.ID.String()).Int("attempts", message.Attempts).Msg("moving email to dead letters after repeated failures")
+		message.Status = entity.EmailStatusDead
+		message.LastError = err.Error()
+	default:
+		message.NextAttemptAt = now.Add(q.backoff(message.Attempts))
+		message.LastError = err.Error()
+	}
+
+	if err := q.repo.Save(message); err != nil {
+		log.Error().Err(err).Msgf("failed to save email %s after attempt %d", message.ID, message.Attempts)
+	}
+}
+
+func (q *Queue) backoff(attempt int) time.Duration {
+	return q.baseBackoff * time.Duration(1<<(attempt-1))
+}
+
+func (q *Queue) FindAllEmailService(opts query.Options) ([]entity.EmailMessage, int64, error) {
+	messages, total, err := q.repo.FindAll(opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return messages, total, nil
+}
+
+func (q *Queue) FindEmailService(id string) (*entity.EmailMessage, error) {
+	message, err := q.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEmailNotFound)
+	}
+
+	return message, nil
+}
+
+// ResendEmailService puts a dead or sent email back in the queue with a
+// fresh set of attempts.
+func (q *Queue) ResendEmailService(id string) (*entity.EmailMessage, error) {
+	message, err := q.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEmailNotFound)
+	}
+
+	if message.Status == entity.EmailStatusQueued {
+		return nil, fmt.Errorf("%w: next attempt at %s", ErrEmailQueued, message.NextAttemptAt.Format(time.RFC3339))
+	}
+
+	message.Status = entity.EmailStatusQueued
+	message.Attempts = 0
+	message.NextAttemptAt = time.Now()
+	message.SentAt = nil
+
+	if err := q.repo.Save(message); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return message, nil
+}
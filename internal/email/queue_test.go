@@ -0,0 +1,186 @@
+package email
+
+import (
+	"errors"
+	"event-booking/internal/config"
+	"event-booking/internal/email/mocks"
+	"event-booking/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestEmailService(server *smtpServer) *EmailService {
+	return NewEmailService(&config.Smtp{
+		SmtpHost:  "127.0.0.1",
+		SmtpPort:  server.port(),
+		FromEmail: "noreply@example.com",
+	})
+}
+
+func TestEmailServiceSendEmail(t *testing.T) {
+	server := newSMTPServer(t)
+	svc := newTestEmailService(server)
+
+	err := svc.SendEmail("jane@example.com", "Booking confirmed", "<p>See you there</p>", "See you there")
+	require.NoError(t, err)
+
+	received := server.received()
+	require.Len(t, received, 1)
+	assert.Equal(t, "noreply@example.com", received[0].From)
+	assert.Equal(t, []string{"jane@example.com"}, received[0].To)
+	assert.Contains(t, received[0].Data, "Subject: Booking confirmed")
+	assert.Contains(t, received[0].Data, "See you there")
+	assert.Contains(t, received[0].Data, "<p>See you there</p>")
+}
+
+func TestEmailServiceSendAttachment(t *testing.T) {
+	server := newSMTPServer(t)
+	svc := newTestEmailService(server)
+
+	err := svc.SendEmail("jane@example.com", "Booking confirmed", "", "See you there", entity.EmailAttachment{
+		Filename:    "event.ics",
+		ContentType: "text/calendar; charset=utf-8; method=PUBLISH",
+		Content:     []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"),
+	})
+	require.NoError(t, err)
+
+	received := server.received()
+	require.Len(t, received, 1)
+	assert.Contains(t, received[0].Data, "Content-Type: text/calendar; charset=utf-8; method=PUBLISH")
+	assert.Contains(t, received[0].Data, `filename="event.ics"`)
+}
+
+func TestQueueSendEmail(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("Create", mock.MatchedBy(func(message *entity.EmailMessage) bool {
+		return message.To == "jane@example.com" && message.Status == entity.EmailStatusQueued && !message.NextAttemptAt.IsZero()
+	})).Return(nil).Once()
+
+	queue := NewQueue(mockRepo, mocks.NewSender(t), 3, time.Second)
+	err := queue.SendEmail("jane@example.com", "Hello", "", "Hello")
+
+	assert.NoError(t, err)
+}
+
+func TestProcessDue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	queuedMessage := func(attempts int) entity.EmailMessage {
+		return entity.EmailMessage{
+			ID:            uuid.New(),
+			To:            "jane@example.com",
+			Subject:       "Hello",
+			TextBody:      "Hello",
+			Status:        entity.EmailStatusQueued,
+			Attempts:      attempts,
+			NextAttemptAt: now,
+		}
+	}
+
+	t.Run("sent", func(t *testing.T) {
+		server := newSMTPServer(t)
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("ClaimDue", now, 20, 5*time.Minute).Return([]entity.EmailMessage{queuedMessage(0)}, nil).Once()
+		mockRepo.On("Save", mock.MatchedBy(func(message *entity.EmailMessage) bool {
+			return message.Status == entity.EmailStatusSent && message.Attempts == 1 && message.SentAt.Equal(now)
+		})).Return(nil).Once()
+
+		queue := NewQueue(mockRepo, newTestEmailService(server), 3, time.Second)
+		processed, err := queue.ProcessDue(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		assert.Len(t, server.received(), 1)
+	})
+
+	t.Run("failure is retried with backoff", func(t *testing.T) {
+		server := newSMTPServer(t)
+		server.failNext(1)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("ClaimDue", now, 20, 5*time.Minute).Return([]entity.EmailMessage{queuedMessage(1)}, nil).Once()
+		mockRepo.On("Save", mock.MatchedBy(func(message *entity.EmailMessage) bool {
+			return message.Status == entity.EmailStatusQueued &&
+				message.Attempts == 2 &&
+				message.NextAttemptAt.Equal(now.Add(time.Minute)) &&
+				message.LastError != ""
+		})).Return(nil).Once()
+
+		queue := NewQueue(mockRepo, newTestEmailService(server), 3, time.Second)
+		_, err := queue.ProcessDue(now)
+
+		assert.NoError(t, err)
+		assert.Empty(t, server.received())
+	})
+
+	t.Run("dead letter after the last attempt", func(t *testing.T) {
+		server := newSMTPServer(t)
+		server.failNext(1)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("ClaimDue", now, 20, 5*time.Minute).Return([]entity.EmailMessage{queuedMessage(2)}, nil).Once()
+		mockRepo.On("Save", mock.MatchedBy(func(message *entity.EmailMessage) bool {
+			return message.Status == entity.EmailStatusDead && message.Attempts == 3 && message.SentAt == nil
+		})).Return(nil).Once()
+
+		queue := NewQueue(mockRepo, newTestEmailService(server), 3, time.Second)
+		_, err := queue.ProcessDue(now)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("ClaimDue", now, 20, 5*time.Minute).Return(nil, errors.New("connection refused")).Once()
+
+		queue := NewQueue(mockRepo, mocks.NewSender(t), 3, time.Second)
+		processed, err := queue.ProcessDue(now)
+
+		assert.Error(t, err)
+		assert.Zero(t, processed)
+	})
+}
+
+func TestResendEmailService(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("dead letter is queued again", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", id.String()).Return(&entity.EmailMessage{ID: id, Status: entity.EmailStatusDead, Attempts: 3, LastError: "451"}, nil).Once()
+		mockRepo.On("Save", mock.MatchedBy(func(message *entity.EmailMessage) bool {
+			return message.Status == entity.EmailStatusQueued && message.Attempts == 0
+		})).Return(nil).Once()
+
+		queue := NewQueue(mockRepo, mocks.NewSender(t), 3, time.Second)
+		message, err := queue.ResendEmailService(id.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.EmailStatusQueued, message.Status)
+	})
+
+	t.Run("still queued", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", id.String()).Return(&entity.EmailMessage{ID: id, Status: entity.EmailStatusQueued}, nil).Once()
+
+		queue := NewQueue(mockRepo, mocks.NewSender(t), 3, time.Second)
+		_, err := queue.ResendEmailService(id.String())
+
+		assert.ErrorIs(t, err, ErrEmailQueued)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", id.String()).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		queue := NewQueue(mockRepo, mocks.NewSender(t), 3, time.Second)
+		_, err := queue.ResendEmailService(id.String())
+
+		assert.ErrorIs(t, err, ErrEmailNotFound)
+	})
+}
@@ -0,0 +1,100 @@
+package email
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+func (r *repo) Create(message *entity.EmailMessage) error {
+	return r.db.Create(message).Error
+}
+
+// Save updates the message itself; attachments never change after Create.
+func (r *repo) Save(message *entity.EmailMessage) error {
+	return r.db.Omit(clause.Associations).Save(message).Error
+}
+
+func (r *repo) Find(id string) (*entity.EmailMessage, error) {
+	var message entity.EmailMessage
+	if err := r.db.Where("id = ?", id).First(&message).Error; err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+func (r *repo) FindAll(opts query.Options) ([]entity.EmailMessage, int64, error) {
+	db := r.db.Model(&entity.EmailMessage{}).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var messages []entity.EmailMessage
+	if err := db.Scopes(opts.Paginate).Find(&messages).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return messages, total, nil
+}
+
+// ClaimDue takes up to limit queued messages that are due and pushes their
+// next attempt back by lease, so other workers skip them while this one
+// sends. A worker that dies mid-send leaves the message to be retried once
+// the lease is over.
+func (r *repo) ClaimDue(now time.Time, limit int, lease time.Duration) ([]entity.EmailMessage, error) {
+	var messages []entity.EmailMessage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.EmailStatusQueued, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]any, 0, len(messages))
+		for _, message := range messages {
+			ids = append(ids, message.ID)
+		}
+
+		if err := tx.Model(&entity.EmailMessage{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return err
+		}
+
+		var attachments []entity.EmailAttachment
+		if err := tx.Where("email_message_id IN ?", ids).Find(&attachments).Error; err != nil {
+			return err
+		}
+		for _, attachment := range attachments {
+			for i := range messages {
+				if messages[i].ID == attachment.EmailMessageID {
+					messages[i].Attachments = append(messages[i].Attachments, attachment)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
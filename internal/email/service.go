@@ -2,7 +2,8 @@ package email
 
 import (
 	"event-booking/internal/config"
-	"fmt"
+	"event-booking/internal/entity"
+	"io"
 
 	"gopkg.in/gomail.v2"
 )
@@ -17,12 +18,27 @@ func NewEmailService(config *config.Smtp) *EmailService {
 	}
 }
 
-func (e *EmailService) SendEmail(to, subject, body string) error {
+// SendEmail sends an email with textBody as the plain text part and, when
+// given, htmlBody as the preferred alternative.
+func (e *EmailService) SendEmail(to, subject, htmlBody, textBody string, attachments ...entity.EmailAttachment) error {
 	mailer := gomail.NewMessage()
 	mailer.SetHeader("From", e.config.FromEmail)
 	mailer.SetHeader("To", to)
 	mailer.SetHeader("Subject", subject)
-	mailer.SetBody("text/html", body)
+	mailer.SetBody("text/plain", textBody)
+	if htmlBody != "" {
+		mailer.AddAlternative("text/html", htmlBody)
+	}
+	for _, attachment := range attachments {
+		content := attachment.Content
+		mailer.Attach(attachment.Filename,
+			gomail.SetHeader(map[string][]string{"Content-Type": {attachment.ContentType}}),
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}),
+		)
+	}
 
 	dialer := gomail.NewDialer(e.config.SmtpHost, e.config.SmtpPort, e.config.Username, e.config.Password)
 
@@ -32,22 +48,3 @@ func (e *EmailService) SendEmail(to, subject, body string) error {
 
 	return nil
 }
-
-func (e *EmailService) SendVerificationEmail(to, code string) error {
-	subject := "Email Verification Code"
-	body := fmt.Sprintf(`
-        <!DOCTYPE html>
-        <html>
-        <head>
-            <title>Email Verification</title>
-        </head>
-        <body>
-            <h1>Email Verification Code</h1>
-            <p>Your verification code is: <strong>%s</strong></p>
-            <p>Please use it within 1 hour.</p>
-            <p>If you did not request this code, please ignore this email.</p>
-        </body>
-        </html>
-    `, code)
-	return e.SendEmail(to, subject, body)
-}
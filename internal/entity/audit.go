@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntry records who did what to which row. Entries are never changed;
+// the table rejects updates and only deletes entries past the retention, see
+// audit.Service.PurgeService.
+type AuditEntry struct {
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	// ActorID is empty for failed sign-ins and the command line
+	ActorID    *uuid.UUID `json:"actor_id" gorm:"type:uuid;index"`
+	ActorRole  string     `json:"actor_role"`
+	Action     string     `json:"action" gorm:"not null;index"`
+	TargetType string     `json:"target_type" gorm:"not null;index:idx_audit_entries_target,priority:1"`
+	TargetID   string     `json:"target_id" gorm:"index:idx_audit_entries_target,priority:2"`
+	// Changes is a JSON object of the changed fields, see audit.Diff
+	Changes   string    `json:"changes" gorm:"type:text"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
+
+// AuditSearch limits entries to those created at or after From and before To.
+type AuditSearch struct {
+	From *time.Time
+	To   *time.Time
+}
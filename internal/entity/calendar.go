@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed is a user's iCalendar subscription. Only a hash of the token
+// is stored; the token itself is shown once when the feed is created.
+type CalendarFeed struct {
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;primaryKey"`
+	TokenHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time
+	User       User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT;"`
+}
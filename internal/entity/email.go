@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EmailStatusQueued = "queued"
+	EmailStatusSent   = "sent"
+	// EmailStatusDead marks the dead letters: emails that failed every
+	// attempt and wait for an admin to resend them
+	EmailStatusDead = "dead"
+)
+
+// EmailMessage is an email in the outgoing queue. Sent and dead messages are
+// kept as a log of what was delivered.
+type EmailMessage struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	To            string     `json:"to" gorm:"not null;index"`
+	Subject       string     `json:"subject" gorm:"not null"`
+	HTMLBody      string     `json:"html_body" gorm:"type:text"`
+	TextBody      string     `json:"text_body" gorm:"type:text;not null"`
+	Status        string     `json:"status" gorm:"not null;default:'queued';index:idx_email_messages_due,priority:1"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index:idx_email_messages_due,priority:2"`
+	LastError     string     `json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Attachments   []EmailAttachment `json:"attachments" gorm:"foreignKey:EmailMessageID;constraint:OnDelete:CASCADE;"`
+}
+
+type EmailAttachment struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	EmailMessageID uuid.UUID `json:"email_message_id" gorm:"type:uuid;not null;index"`
+	Filename       string    `json:"filename" gorm:"not null"`
+	ContentType    string    `json:"content_type" gorm:"not null"`
+	Content        []byte    `json:"-" gorm:"not null"`
+}
@@ -4,20 +4,120 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Stored event statuses are draft, published and cancelled; scheduled and
+// completed are derived, see Lifecycle. The column defaults to published so
+// events created before statuses existed stay visible.
+const (
+	EventStatusDraft     = "draft"
+	EventStatusScheduled = "scheduled"
+	EventStatusPublished = "published"
+	EventStatusCancelled = "cancelled"
+	EventStatusCompleted = "completed"
 )
 
 type Event struct {
-	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Name          string    `json:"name" gorm:"unique;not null"`
-	Location      string    `json:"location"`
-	StartDate     time.Time `json:"start_date"`
-	EndDate       time.Time `json:"end_date"`
-	Price         float64   `json:"price"`
-	TotalSeat     int       `json:"total_seat"`
-	AvailableSeat int       `json:"available_seat"`
-	Category      string    `json:"category"`
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Bookings      []Booking `gorm:"foreignKey:EventID"`
-	Reviews       []Review  `gorm:"foreignKey:EventID"`
+	ID                 uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name               string        `json:"name" gorm:"not null;uniqueIndex:idx_events_name_start_date,where:deleted_at IS NULL"`
+	Location           string        `json:"location"`
+	StartDate          time.Time     `json:"start_date" gorm:"uniqueIndex:idx_events_name_start_date,where:deleted_at IS NULL"`
+	EndDate            time.Time     `json:"end_date"`
+	Price              float64       `json:"price"`
+	TotalSeat          int           `json:"total_seat"`
+	AvailableSeat      int           `json:"available_seat"`
+	Category           string        `json:"category"`
+	SeriesID           *uuid.UUID    `json:"series_id" gorm:"type:uuid;index"`
+	Detached           bool          `json:"detached" gorm:"not null;default:false"`
+	VenueID            *uuid.UUID    `json:"venue_id" gorm:"type:uuid;index"`
+	Venue              *Venue        `json:"-" gorm:"foreignKey:VenueID;constraint:OnDelete:RESTRICT;"`
+	OrganizerID        *uuid.UUID    `json:"organizer_id" gorm:"type:uuid;index"`
+	Organizer          *Organization `json:"-" gorm:"foreignKey:OrganizerID;constraint:OnDelete:RESTRICT;"`
+	Status             string        `json:"status" gorm:"not null;default:'published';index"`
+	PublishAt          *time.Time    `json:"publish_at"`
+	SalesStartAt       *time.Time    `json:"sales_start_at"`
+	SalesEndAt         *time.Time    `json:"sales_end_at"`
+	CancelledAt        *time.Time    `json:"cancelled_at"`
+	CancellationReason string        `json:"cancellation_reason"`
+	EventRating        `gorm:"embedded"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DeletedAt          gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	Bookings           []Booking      `gorm:"foreignKey:EventID"`
+	Reviews            []Review       `gorm:"foreignKey:EventID"`
+}
+
+// EventRating aggregates the reviews of an event. GORM only reads these
+// columns, so saving an event never overwrites them; the review repository
+// recomputes them in the transaction that changes a review.
+type EventRating struct {
+	Rating       float64 `json:"rating" gorm:"->;not null;default:0;index"`
+	RatingCount  int     `json:"rating_count" gorm:"->;not null;default:0"`
+	Rating1Count int     `json:"rating_1_count" gorm:"->;not null;default:0"`
+	Rating2Count int     `json:"rating_2_count" gorm:"->;not null;default:0"`
+	Rating3Count int     `json:"rating_3_count" gorm:"->;not null;default:0"`
+	Rating4Count int     `json:"rating_4_count" gorm:"->;not null;default:0"`
+	Rating5Count int     `json:"rating_5_count" gorm:"->;not null;default:0"`
+}
+
+// Distribution maps each star rating to its number of reviews.
+func (r EventRating) Distribution() map[int]int {
+	return map[int]int{
+		1: r.Rating1Count,
+		2: r.Rating2Count,
+		3: r.Rating3Count,
+		4: r.Rating4Count,
+		5: r.Rating5Count,
+	}
+}
+
+// IsPublished reports whether users can see the event at now.
+func (e *Event) IsPublished(now time.Time) bool {
+	return e.Status == EventStatusPublished && (e.PublishAt == nil || !e.PublishAt.After(now))
+}
+
+// Lifecycle is the status shown to clients: the stored status, or
+// scheduled/completed for published events before publish_at or after end_date.
+func (e *Event) Lifecycle(now time.Time) string {
+	switch {
+	case e.Status != EventStatusPublished:
+		return e.Status
+	case !e.IsPublished(now):
+		return EventStatusScheduled
+	case !e.EndDate.After(now):
+		return EventStatusCompleted
+	default:
+		return EventStatusPublished
+	}
+}
+
+// SalesOpen reports whether the event can be booked at now. Sales run from
+// sales_start_at (or publication) until sales_end_at (or the event start).
+func (e *Event) SalesOpen(now time.Time) bool {
+	if e.Lifecycle(now) != EventStatusPublished {
+		return false
+	}
+	if e.SalesStartAt != nil && now.Before(*e.SalesStartAt) {
+		return false
+	}
+
+	salesEnd := e.StartDate
+	if e.SalesEndAt != nil {
+		salesEnd = *e.SalesEndAt
+	}
+
+	return now.Before(salesEnd)
+}
+
+// EventSearch holds the event-specific search filters. Paging, sorting and
+// plain equality filters travel separately as query.Options.
+type EventSearch struct {
+	Query     string
+	StartFrom *time.Time
+	StartTo   *time.Time
+	MinPrice  *float64
+	MaxPrice  *float64
+	// PublishedOnly hides drafts, scheduled and cancelled events
+	PublishedOnly bool
 }
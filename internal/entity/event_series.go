@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventSeries is the template of a recurring event. Every occurrence is a
+// regular Event row pointing back to the series, so it has its own seats,
+// dates and bookings.
+type EventSeries struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name      string    `json:"name" gorm:"not null"`
+	Location  string    `json:"location"`
+	StartDate time.Time `json:"start_date" gorm:"not null"`
+	EndDate   time.Time `json:"end_date" gorm:"not null"`
+	Price     float64   `json:"price"`
+	TotalSeat int       `json:"total_seat"`
+	Category  string    `json:"category"`
+	RRule     string    `json:"rrule" gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	Events    []Event        `gorm:"foreignKey:SeriesID;constraint:OnDelete:RESTRICT;"`
+}
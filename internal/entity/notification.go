@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is a message in a user's in-app inbox.
+type Notification struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	Type      string     `json:"type" gorm:"not null"`
+	Subject   string     `json:"subject" gorm:"not null"`
+	Body      string     `json:"body" gorm:"type:text;not null"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time
+	User      User `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT;"`
+}
+
+// NotificationPreference overrides whether a user receives one type of
+// notification on one channel. Without a row the channel's default applies.
+type NotificationPreference struct {
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Type      string    `json:"type" gorm:"primaryKey"`
+	Channel   string    `json:"channel" gorm:"primaryKey"`
+	Enabled   bool      `json:"enabled" gorm:"not null"`
+	UpdatedAt time.Time
+	User      User `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT;"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleMember = "member"
+)
+
+// Organization runs events. Its members manage the organization's events,
+// bookings, exports and reviews; platform admins manage everything.
+type Organization struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name      string    `json:"name" gorm:"unique;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Members   []OrganizationMember `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;"`
+	Events    []Event              `gorm:"foreignKey:OrganizerID"`
+}
+
+// OrganizationMember links a user to the one organization they organize for.
+type OrganizationMember struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;index"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+	Role           string    `json:"role" gorm:"not null;default:'member'"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	User           User `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT;"`
+}
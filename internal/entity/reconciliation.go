@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Seat reconciliations are started by the scheduler, an admin request or the
+// command line.
+const (
+	ReconciliationTriggerSchedule = "schedule"
+	ReconciliationTriggerAdmin    = "admin"
+	ReconciliationTriggerCLI      = "cli"
+)
+
+// SeatReconciliation is the audit report of one run comparing the available
+// seats of every event with its confirmed bookings.
+type SeatReconciliation struct {
+	ID             uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Trigger        string      `json:"trigger" gorm:"not null;index"`
+	Correct        bool        `json:"correct" gorm:"not null;default:false"`
+	EventsChecked  int         `json:"events_checked" gorm:"not null;default:0"`
+	DriftCount     int         `json:"drift_count" gorm:"not null;default:0"`
+	CorrectedCount int         `json:"corrected_count" gorm:"not null;default:0"`
+	StartedAt      time.Time   `json:"started_at" gorm:"not null"`
+	FinishedAt     time.Time   `json:"finished_at" gorm:"not null"`
+	CreatedAt      time.Time   `json:"created_at"`
+	Drifts         []SeatDrift `json:"drifts" gorm:"foreignKey:ReconciliationID;constraint:OnDelete:CASCADE;"`
+}
+
+// SeatDrift is an event whose available seats did not match TotalSeat minus
+// its booked seats, or whose bookings exceed its capacity.
+type SeatDrift struct {
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ReconciliationID uuid.UUID `json:"reconciliation_id" gorm:"type:uuid;not null;index"`
+	EventID          uuid.UUID `json:"event_id" gorm:"type:uuid;not null;index"`
+	EventName        string    `json:"event_name"`
+	TotalSeat        int       `json:"total_seat"`
+	BookedSeat       int       `json:"booked_seat"`
+	// RecordedSeat is the available seats found, ExpectedSeat what they
+	// should be
+	RecordedSeat int  `json:"recorded_seat"`
+	ExpectedSeat int  `json:"expected_seat"`
+	Corrected    bool `json:"corrected" gorm:"not null;default:false"`
+}
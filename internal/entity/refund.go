@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusProcessed = "processed"
+)
+
+// Refund records money owed back to a user for a booking, e.g. after the
+// event was cancelled. Payment processing picks up pending refunds.
+type Refund struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BookingID   uuid.UUID  `json:"booking_id" gorm:"type:uuid;not null;uniqueIndex"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	EventID     uuid.UUID  `json:"event_id" gorm:"type:uuid;not null;index"`
+	Amount      float64    `json:"amount" gorm:"not null"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status" gorm:"not null;default:'pending'"`
+	ProcessedAt *time.Time `json:"processed_at"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Booking     Booking `gorm:"foreignKey:BookingID;constraint:OnDelete:RESTRICT;"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventReminder records that a user was reminded of an event at an offset
+// before its start, so the scheduler never sends the same reminder twice.
+type EventReminder struct {
+	EventID       uuid.UUID `json:"event_id" gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	OffsetMinutes int       `json:"offset_minutes" gorm:"primaryKey;autoIncrement:false"`
+	SentAt        time.Time `json:"sent_at" gorm:"not null"`
+	Event         Event     `json:"-" gorm:"foreignKey:EventID;constraint:OnDelete:RESTRICT;"`
+	User          User      `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT;"`
+}
@@ -4,16 +4,80 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+	ReviewStatusFlagged  = "flagged"
+)
+
+// Review is written by an attendee after the event. A user reviews an event
+// at most once. Only approved reviews are public; Status defaults to approved
+// so reviews written before moderation existed stay visible.
 type Review struct {
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	EventID          uuid.UUID  `json:"event_id" gorm:"uniqueIndex:idx_reviews_event_user,where:deleted_at IS NULL"`
+	UserID           uuid.UUID  `json:"user_id" gorm:"uniqueIndex:idx_reviews_event_user,where:deleted_at IS NULL"`
+	Review           string     `json:"review"`
+	Rating           int        `json:"rating"`
+	Status           string     `json:"status" gorm:"not null;default:'approved';index"`
+	ModerationReason string     `json:"moderation_reason"`
+	ModeratedAt      *time.Time `json:"moderated_at"`
+	ModeratedBy      *uuid.UUID `json:"moderated_by" gorm:"type:uuid"`
+	ReviewVotes      `gorm:"embedded"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	User             User           `json:"user"`
+	Event            Event          `json:"event"`
+	Reports          []ReviewReport `json:"reports" gorm:"foreignKey:ReviewID;constraint:OnDelete:RESTRICT;"`
+	Votes            []ReviewVote   `json:"votes" gorm:"foreignKey:ReviewID;constraint:OnDelete:RESTRICT;"`
+	Reply            *ReviewReply   `json:"reply" gorm:"foreignKey:ReviewID;constraint:OnDelete:RESTRICT;"`
+}
+
+// ReviewVotes counts the helpful votes of a review. Like EventRating these
+// columns are read only for GORM; the review repository recomputes them in
+// the transaction that changes a vote. Helpfulness is helpful minus
+// unhelpful votes.
+type ReviewVotes struct {
+	HelpfulCount   int `json:"helpful_count" gorm:"->;not null;default:0"`
+	UnhelpfulCount int `json:"unhelpful_count" gorm:"->;not null;default:0"`
+	Helpfulness    int `json:"helpfulness" gorm:"->;not null;default:0;index"`
+}
+
+// ReviewVote is a user's helpful or unhelpful vote on a review, one per user.
+type ReviewVote struct {
+	ReviewID  uuid.UUID `json:"review_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Helpful   bool      `json:"helpful" gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	User      User `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT;"`
+}
+
+// ReviewReply is the public answer of the event's organizer to a review,
+// one per review.
+type ReviewReply struct {
 	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	EventID   uuid.UUID `json:"event_id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Review    string    `json:"review"`
-	Rating    int       `json:"rating"`
+	ReviewID  uuid.UUID `json:"review_id" gorm:"type:uuid;not null;uniqueIndex"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	Reply     string    `json:"reply" gorm:"not null"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
-	User      User  `json:"user"`
-	Event     Event `json:"event"`
+	User      User `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT;"`
+}
+
+// ReviewReport is a user's complaint about a review. A moderation decision
+// resolves all open reports of the review.
+type ReviewReport struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ReviewID   uuid.UUID  `json:"review_id" gorm:"type:uuid;not null;uniqueIndex:idx_review_reports_review_user"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_review_reports_review_user"`
+	Reason     string     `json:"reason" gorm:"not null"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	CreatedAt  time.Time
+	User       User `gorm:"foreignKey:UserID;constraint:OnDelete:RESTRICT;"`
 }
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashedRecord summarizes a soft deleted user, event series, event, booking
+// or review. Name is the email of a user, the name of a series or event, and
+// the event name of a booking or review. SeriesID, EventID and UserID point
+// at the parents that have to be live for the record to be restored.
+type TrashedRecord struct {
+	Kind      string     `json:"kind" gorm:"-"`
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	SeriesID  *uuid.UUID `json:"series_id"`
+	EventID   *uuid.UUID `json:"event_id"`
+	UserID    *uuid.UUID `json:"user_id"`
+	DeletedAt time.Time  `json:"deleted_at"`
+}
@@ -4,20 +4,33 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type User struct {
-	ID                       uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Name                     string    `json:"name" gorm:"not null"`
-	Email                    string    `json:"email" gorm:"unique;not null"`
-	Password                 string    `json:"password" gorm:"not null"`
-	Role                     string    `json:"role" gorm:"not null;default:'user'"`
-	EmailVerificationCode    string    `json:"email_verification_code"`
-	VerificationExpiry       time.Time `json:"verification_expiry"`
-	VerificationAttemptsLeft int       `json:"verification_attempts_left" gorm:"default:3"`
-	IsVerified               bool      `json:"is_verified" gorm:"default:false"`
+	ID                       uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name                     string     `json:"name" gorm:"not null"`
+	Email                    string     `json:"email" gorm:"not null;uniqueIndex:idx_users_email,where:deleted_at IS NULL"`
+	PendingEmail             string     `json:"pending_email" gorm:"not null;default:'';uniqueIndex:idx_users_pending_email,where:pending_email <> '' AND deleted_at IS NULL"`
+	Password                 string     `json:"password" gorm:"not null"`
+	Role                     string     `json:"role" gorm:"not null;default:'user'"`
+	EmailVerificationCode    string     `json:"email_verification_code"`
+	VerificationExpiry       time.Time  `json:"verification_expiry"`
+	VerificationAttemptsLeft int        `json:"verification_attempts_left" gorm:"default:3"`
+	IsVerified               bool       `json:"is_verified" gorm:"default:false"`
+	DisabledAt               *time.Time `json:"disabled_at"`
+	ErasedAt                 *time.Time `json:"erased_at"`
 	CreatedAt                time.Time
 	UpdatedAt                time.Time
-	Bookings                 []Booking `gorm:"foreignKey:UserID"`
-	Reviews                  []Review  `gorm:"foreignKey:UserID"`
+	DeletedAt                gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	Bookings                 []Booking      `gorm:"foreignKey:UserID"`
+	Reviews                  []Review       `gorm:"foreignKey:UserID"`
+}
+
+// UserSearch holds the user-specific search filters. Paging, sorting and
+// equality filters come from query.Options. Query matches part of the name
+// or email.
+type UserSearch struct {
+	Query    string
+	Disabled *bool
 }
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Venue struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name      string    `json:"name" gorm:"unique;not null"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Sections  []Section `gorm:"foreignKey:VenueID;constraint:OnDelete:CASCADE;"`
+}
+
+type Section struct {
+	ID       uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	VenueID  uuid.UUID `json:"venue_id" gorm:"type:uuid;not null;uniqueIndex:idx_sections_venue_name"`
+	Name     string    `json:"name" gorm:"not null;uniqueIndex:idx_sections_venue_name"`
+	Position int       `json:"position"`
+	Seats    []Seat    `gorm:"foreignKey:SectionID;constraint:OnDelete:CASCADE;"`
+}
+
+type Seat struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	SectionID uuid.UUID `json:"section_id" gorm:"type:uuid;not null;uniqueIndex:idx_seats_section_row_number"`
+	Row       string    `json:"row" gorm:"column:row_label;not null;uniqueIndex:idx_seats_section_row_number"`
+	Number    int       `json:"number" gorm:"not null;uniqueIndex:idx_seats_section_row_number"`
+}
+
+// BookingSeat assigns a seat to a booking. The unique index on
+// (event_id, seat_id) is what guarantees a seat is sold at most once per
+// event, even when two bookings race for it.
+type BookingSeat struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BookingID uuid.UUID `json:"booking_id" gorm:"type:uuid;not null;index"`
+	EventID   uuid.UUID `json:"event_id" gorm:"type:uuid;not null;uniqueIndex:idx_booking_seats_event_seat"`
+	SeatID    uuid.UUID `json:"seat_id" gorm:"type:uuid;not null;uniqueIndex:idx_booking_seats_event_seat"`
+	Seat      Seat      `gorm:"foreignKey:SeatID;constraint:OnDelete:RESTRICT;"`
+}
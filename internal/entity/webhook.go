@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookEventBookingCreated   = "booking.created"
+	WebhookEventBookingUpdated   = "booking.updated"
+	WebhookEventBookingCancelled = "booking.cancelled"
+	// WebhookEventNotification carries user notifications sent through the
+	// webhook channel, for integrations such as SMS or push gateways
+	WebhookEventNotification = "notification.sent"
+)
+
+type Webhook struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	URL          string     `json:"url" gorm:"not null"`
+	Secret       string     `json:"-" gorm:"not null"`
+	EventTypes   string     `json:"event_types" gorm:"not null"`
+	Active       bool       `json:"active" gorm:"not null;default:true"`
+	FailureCount int        `json:"failure_count" gorm:"not null;default:0"`
+	DisabledAt   *time.Time `json:"disabled_at"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Deliveries   []WebhookDelivery `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE;"`
+}
+
+// WebhookDelivery is one attempt at delivering an event to a webhook. An
+// attempt that has not been made yet has NextAttemptAt set; the worker clears
+// it once the attempt is done and queues a new delivery for the retry.
+type WebhookDelivery struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	WebhookID     uuid.UUID  `json:"webhook_id" gorm:"type:uuid;not null;index"`
+	EventID       uuid.UUID  `json:"event_id" gorm:"type:uuid;not null"`
+	EventType     string     `json:"event_type" gorm:"not null"`
+	Payload       string     `json:"payload" gorm:"type:text;not null"`
+	Attempt       int        `json:"attempt" gorm:"not null"`
+	StatusCode    int        `json:"status_code"`
+	ResponseBody  string     `json:"response_body" gorm:"type:text"`
+	Error         string     `json:"error"`
+	Success       bool       `json:"success" gorm:"not null;default:false"`
+	DurationMs    int64      `json:"duration_ms"`
+	NextAttemptAt *time.Time `json:"next_attempt_at" gorm:"index:idx_webhook_deliveries_due,where:next_attempt_at IS NOT NULL"`
+	CreatedAt     time.Time
+}
+
+// WebhookBookingPayload is the data of the booking.* webhook events. Only the
+// public booking fields are sent so preloaded relations (e.g. the user's
+// password hash) never leak.
+type WebhookBookingPayload struct {
+	ID         uuid.UUID   `json:"id"`
+	UserID     uuid.UUID   `json:"user_id"`
+	EventID    uuid.UUID   `json:"event_id"`
+	Quantity   int         `json:"quantity"`
+	TotalPrice float64     `json:"total_price"`
+	SeatIDs    []uuid.UUID `json:"seat_ids,omitempty"`
+	Status     string      `json:"status,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+func NewWebhookBookingPayload(booking *Booking) WebhookBookingPayload {
+	payload := WebhookBookingPayload{
+		ID:         booking.ID,
+		UserID:     booking.UserID,
+		EventID:    booking.EventID,
+		Quantity:   booking.Quantity,
+		TotalPrice: booking.TotalPrice,
+		Status:     booking.Status,
+		CreatedAt:  booking.CreatedAt,
+		UpdatedAt:  booking.UpdatedAt,
+	}
+	for _, seat := range booking.Seats {
+		payload.SeatIDs = append(payload.SeatIDs, seat.SeatID)
+	}
+
+	return payload
+}
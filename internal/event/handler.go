@@ -1,77 +1,100 @@
 package event
 
 import (
+	"event-booking/internal/api/query"
 	"event-booking/internal/api/responses"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/api/validator"
+	"event-booking/internal/audit"
+	"event-booking/internal/calendar"
 	"event-booking/internal/entity"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type httpHandler struct {
 	svc       *Service
+	audit     *audit.Service
 	validator *validator.Validator
 }
 
-func NewHttpHandler(svc *Service, validator *validator.Validator) *httpHandler {
+func NewHttpHandler(svc *Service, audit *audit.Service, validator *validator.Validator) *httpHandler {
 	return &httpHandler{
 		svc:       svc,
+		audit:     audit,
 		validator: validator,
 	}
 }
 
 type EventInputPayload struct {
-	Name          string    `json:"name" validate:"required,min=3,max=50"`
-	Location      string    `json:"location" validate:"required,min=3,max=50"`
-	StartDate     time.Time `json:"start_date" validate:"required"`
-	EndDate       time.Time `json:"end_date" validate:"required"`
-	Price         float64   `json:"price" validate:"required"`
-	TotalSeat     int       `json:"total_seat" validate:"required"`
-	AvailableSeat int       `json:"available_seat" validate:"required"`
-	Category      string    `json:"category" validate:"required"`
+	Name      string     `json:"name" validate:"required,min=3,max=50"`
+	Location  string     `json:"location" validate:"required,min=3,max=50"`
+	StartDate time.Time  `json:"start_date" validate:"required"`
+	EndDate   time.Time  `json:"end_date" validate:"required"`
+	Price     float64    `json:"price" validate:"min=0"`
+	TotalSeat int        `json:"total_seat" validate:"required_without=VenueID"`
+	Category  string     `json:"category" validate:"required"`
+	VenueID   *uuid.UUID `json:"venue_id"`
+	// Status defaults to draft, or to published when PublishAt is set
+	Status       string     `json:"status" validate:"omitempty,oneof=draft published"`
+	PublishAt    *time.Time `json:"publish_at"`
+	SalesStartAt *time.Time `json:"sales_start_at"`
+	SalesEndAt   *time.Time `json:"sales_end_at"`
+	// OrganizerID is only honoured for platform admins; organizers always
+	// create events for their own organization
+	OrganizerID *uuid.UUID `json:"organizer_id"`
 }
 
 func (h *httpHandler) CreateEventHandler(c *fiber.Ctx) error {
 	event := new(EventInputPayload)
 	if err := c.BodyParser(event); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(event); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	newEvent := &entity.Event{
-		Name:          event.Name,
-		Location:      event.Location,
-		StartDate:     event.StartDate,
-		EndDate:       event.EndDate,
-		Price:         event.Price,
-		TotalSeat:     event.TotalSeat,
-		AvailableSeat: event.AvailableSeat,
-		Category:      event.Category,
+		Name:         event.Name,
+		Location:     event.Location,
+		StartDate:    event.StartDate,
+		EndDate:      event.EndDate,
+		Price:        event.Price,
+		TotalSeat:    event.TotalSeat,
+		Category:     event.Category,
+		VenueID:      event.VenueID,
+		Status:       event.Status,
+		PublishAt:    event.PublishAt,
+		SalesStartAt: event.SalesStartAt,
+		SalesEndAt:   event.SalesEndAt,
+	}
+	if scope := tenant.FromContext(c); !scope.Global() {
+		newEvent.OrganizerID = scope.OrganizationID
+	} else {
+		newEvent.OrganizerID = event.OrganizerID
+	}
+	if newEvent.Status == "" {
+		newEvent.Status = entity.EventStatusDraft
+		if newEvent.PublishAt != nil {
+			newEvent.Status = entity.EventStatusPublished
+		}
 	}
 
 	createdEvent, err := h.svc.CreateEventService(newEvent)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
-	eventResponse := responses.EventResponseObject{
-		ID:            createdEvent.ID,
-		Name:          createdEvent.Name,
-		Location:      createdEvent.Location,
-		StartDate:     createdEvent.StartDate,
-		EndDate:       createdEvent.EndDate,
-		Price:         createdEvent.Price,
-		TotalSeat:     createdEvent.TotalSeat,
-		AvailableSeat: createdEvent.AvailableSeat,
-		Category:      createdEvent.Category,
-	}
+	entry := audit.FromRequest(c, audit.ActionEventCreate, audit.TargetEvent, createdEvent.ID.String())
+	entry.Changes = audit.Diff(nil, createdEvent)
+	h.audit.Record(entry)
+
+	eventResponse := newEventResponse(createdEvent)
 
 	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse(
 		"Event created successfully",
@@ -80,163 +103,415 @@ func (h *httpHandler) CreateEventHandler(c *fiber.Ctx) error {
 }
 
 type EventUpdatePayload struct {
-	Name          string    `json:"name" validate:"required,min=3,max=50"`
-	Location      string    `json:"location" validate:"required,min=3,max=50"`
-	StartDate     time.Time `json:"start_date" validate:"required"`
-	EndDate       time.Time `json:"end_date" validate:"required"`
-	Price         float64   `json:"price" validate:"required"`
-	TotalSeat     int       `json:"total_seat" validate:"required"`
-	AvailableSeat int       `json:"available_seat" validate:"required"`
-	Category      string    `json:"category" validate:"required"`
+	Name         string     `json:"name" validate:"required,min=3,max=50"`
+	Location     string     `json:"location" validate:"required,min=3,max=50"`
+	StartDate    time.Time  `json:"start_date" validate:"required"`
+	EndDate      time.Time  `json:"end_date" validate:"required"`
+	Price        float64    `json:"price" validate:"min=0"`
+	TotalSeat    int        `json:"total_seat" validate:"required_without=VenueID"`
+	Category     string     `json:"category" validate:"required"`
+	VenueID      *uuid.UUID `json:"venue_id"`
+	SalesStartAt *time.Time `json:"sales_start_at"`
+	SalesEndAt   *time.Time `json:"sales_end_at"`
 }
 
 func (h *httpHandler) SaveEventHandler(c *fiber.Ctx) error {
 	id := c.Params("id")
+	eventID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrEventNotFound
+	}
+
 	event := new(EventUpdatePayload)
 	if err := c.BodyParser(event); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(event); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	eventData := &entity.Event{
-		ID:            uuid.MustParse(id),
-		Name:          event.Name,
-		Location:      event.Location,
-		StartDate:     event.StartDate,
-		EndDate:       event.EndDate,
-		Price:         event.Price,
-		TotalSeat:     event.TotalSeat,
-		AvailableSeat: event.AvailableSeat,
-		Category:      event.Category,
-	}
-
-	newEvent, err := h.svc.SaveEventService(eventData, event)
+		ID:           eventID,
+		Name:         event.Name,
+		Location:     event.Location,
+		StartDate:    event.StartDate,
+		EndDate:      event.EndDate,
+		Price:        event.Price,
+		TotalSeat:    event.TotalSeat,
+		Category:     event.Category,
+		VenueID:      event.VenueID,
+		SalesStartAt: event.SalesStartAt,
+		SalesEndAt:   event.SalesEndAt,
+	}
+
+	before, err := h.svc.FindEventService(tenant.FromContext(c), id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
-	updatedEvent := responses.EventResponseObject{
-		ID:            newEvent.ID,
-		Name:          newEvent.Name,
-		Location:      newEvent.Location,
-		StartDate:     newEvent.StartDate,
-		EndDate:       newEvent.EndDate,
-		Price:         newEvent.Price,
-		TotalSeat:     newEvent.TotalSeat,
-		AvailableSeat: newEvent.AvailableSeat,
-		Category:      newEvent.Category,
+	newEvent, err := h.svc.SaveEventService(tenant.FromContext(c), eventData)
+	if err != nil {
+		return err
 	}
 
+	h.record(c, audit.ActionEventUpdate, before, newEvent)
+
+	updatedEvent := newEventResponse(newEvent)
+
 	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse(
 		"Event updated successfully",
 		updatedEvent,
 	))
 }
 
+var listQueryConfig = query.Config{
+	Sorts:       []string{SortStartDate, SortPrice, SortName, SortCreatedAt, SortRating},
+	Filters:     []string{"category", "location", "series_id"},
+	DefaultSort: SortStartDate,
+}
+
 func (h *httpHandler) FindAllEventHandler(c *fiber.Ctx) error {
-	events, err := h.svc.FindAllEventService()
+	opts, err := query.Parse(c, listQueryConfig)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
+	}
+
+	// drafts, scheduled and cancelled events are only listed for admins and
+	// organizers
+	var events []entity.Event
+	var total int64
+	if canManage(c) {
+		events, total, err = h.svc.FindAllEventService(tenant.FromContext(c), opts)
+	} else {
+		events, total, err = h.svc.FindPublishedEventService(opts)
+	}
+	if err != nil {
+		return err
 	}
 
 	var eventResponse []responses.EventResponseObject
 	for _, event := range events {
-		eventResponse = append(eventResponse, responses.EventResponseObject{
-			ID:            event.ID,
-			Name:          event.Name,
-			Location:      event.Location,
-			StartDate:     event.StartDate,
-			EndDate:       event.EndDate,
-			Price:         event.Price,
-			TotalSeat:     event.TotalSeat,
-			AvailableSeat: event.AvailableSeat,
-			Category:      event.Category,
-		})
+		eventResponse = append(eventResponse, newEventResponse(&event))
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse(
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse(
 		"Events found",
 		eventResponse,
+		opts.Pagination(total),
 	))
 }
 
 func (h *httpHandler) FindEventHandler(c *fiber.Ctx) error {
 	id := c.Params("id")
-	event, err := h.svc.FindEventService(id)
+	event, err := h.svc.FindEventService(tenant.FromContext(c), id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.NewErrorResponse("Event not found"))
+		return err
 	}
 
-	eventResponse := responses.EventResponseObject{
-		ID:            event.ID,
-		Name:          event.Name,
-		Location:      event.Location,
-		StartDate:     event.StartDate,
-		EndDate:       event.EndDate,
-		Price:         event.Price,
-		TotalSeat:     event.TotalSeat,
-		AvailableSeat: event.AvailableSeat,
-		Category:      event.Category,
+	// cancelled events stay visible so bookers can see what happened
+	if !canManage(c) && !event.IsPublished(time.Now()) && event.Status != entity.EventStatusCancelled {
+		return ErrEventNotFound
 	}
 
+	eventResponse := newEventResponse(event)
+
 	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse(
 		"Event found",
 		eventResponse,
 	))
 }
 
-func (h *httpHandler) DeleteEventHandler(c *fiber.Ctx) error {
+// FindEventRatingHandler returns the average rating and star distribution
+// of an event, under the same visibility rules as FindEventHandler.
+func (h *httpHandler) FindEventRatingHandler(c *fiber.Ctx) error {
 	id := c.Params("id")
-	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+	event, err := h.svc.FindEventService(tenant.FromContext(c), id)
+	if err != nil {
+		return err
+	}
+
+	if !canManage(c) && !event.IsPublished(time.Now()) && event.Status != entity.EventStatusCancelled {
+		return ErrEventNotFound
 	}
-	err := h.svc.DeleteEventService(id)
+
+	ratingResponse := responses.RatingResponseObject{
+		EventID:      event.ID,
+		Rating:       event.Rating,
+		Count:        event.RatingCount,
+		Distribution: event.Distribution(),
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Event rating found", ratingResponse))
+}
+
+// FindEventICSHandler downloads the event as an iCalendar file, under the
+// same visibility rules as FindEventHandler.
+func (h *httpHandler) FindEventICSHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	event, err := h.svc.FindEventService(tenant.FromContext(c), id)
 	if err != nil {
-		if err.Error() == gorm.ErrRecordNotFound.Error() {
-			return c.Status(fiber.StatusNotFound).JSON(responses.NewErrorResponse("Event not found"))
-		} else {
-			return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
+	}
+
+	if !canManage(c) && !event.IsPublished(time.Now()) && event.Status != entity.EventStatusCancelled {
+		return ErrEventNotFound
+	}
+
+	c.Attachment(fmt.Sprintf("event-%s.ics", event.ID))
+	c.Set(fiber.HeaderContentType, calendar.ContentType)
+
+	return c.Status(fiber.StatusOK).Send(calendar.Marshal("", time.Now(), *event))
+}
+
+type PublishPayload struct {
+	// PublishAt schedules publication; empty publishes right away
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+func (h *httpHandler) PublishEventHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	payload := new(PublishPayload)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(payload); err != nil {
+			return validator.ErrInvalidBody
+		}
+	}
+
+	before, err := h.svc.FindEventService(tenant.FromContext(c), id)
+	if err != nil {
+		return err
+	}
+
+	event, err := h.svc.PublishEventService(tenant.FromContext(c), id, payload.PublishAt)
+	if err != nil {
+		return err
+	}
+
+	h.record(c, audit.ActionEventPublish, before, event)
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Event published successfully", newEventResponse(event)))
+}
+
+func (h *httpHandler) UnpublishEventHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	before, err := h.svc.FindEventService(tenant.FromContext(c), id)
+	if err != nil {
+		return err
+	}
+
+	event, err := h.svc.UnpublishEventService(tenant.FromContext(c), id)
+	if err != nil {
+		return err
+	}
+
+	h.record(c, audit.ActionEventUnpublish, before, event)
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Event unpublished successfully", newEventResponse(event)))
+}
+
+type CancelPayload struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// CancelEventHandler also serves DELETE /api/admin/event/:id; events are
+// cancelled rather than deleted so bookings and refunds keep their history.
+func (h *httpHandler) CancelEventHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	payload := new(CancelPayload)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(payload); err != nil {
+			return validator.ErrInvalidBody
 		}
 	}
 
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	before, err := h.svc.FindEventService(tenant.FromContext(c), id)
+	if err != nil {
+		return err
+	}
+
+	event, err := h.svc.CancelEventService(tenant.FromContext(c), id, payload.Reason)
+	if err != nil {
+		return err
+	}
+
+	h.record(c, audit.ActionEventCancel, before, event)
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Event cancelled successfully", newEventResponse(event)))
+}
+
+// DeleteEventHandler soft deletes an event; see DeleteEventService.
+func (h *httpHandler) DeleteEventHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	before, err := h.svc.FindEventService(tenant.FromContext(c), id)
+	if err != nil {
+		return err
+	}
+
+	event, err := h.svc.DeleteEventService(tenant.FromContext(c), id)
+	if err != nil {
+		return err
+	}
+
+	h.record(c, audit.ActionEventDelete, before, event)
+
 	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Event deleted successfully"))
 }
 
-func (h *httpHandler) FilterByCriteria(c *fiber.Ctx) error {
-	criteria := make(map[string]interface{})
-	criteria["name"] = c.Query("name")
-	criteria["location"] = c.Query("location")
-	criteria["category"] = c.Query("category")
+var refundQueryConfig = query.Config{
+	Sorts:       []string{"created_at", "amount"},
+	Filters:     []string{"status", "user_id"},
+	DefaultSort: "created_at",
+}
 
-	events, err := h.svc.FilterEventService(criteria)
+func (h *httpHandler) FindRefundsHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, refundQueryConfig)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
-	var eventResponse []responses.EventResponseObject
-	for _, event := range events {
-		eventResponse = append(eventResponse, responses.EventResponseObject{
-			ID:            event.ID,
-			Name:          event.Name,
-			Location:      event.Location,
-			StartDate:     event.StartDate,
-			EndDate:       event.EndDate,
-			Price:         event.Price,
-			TotalSeat:     event.TotalSeat,
-			AvailableSeat: event.AvailableSeat,
-			Category:      event.Category,
+	refunds, total, err := h.svc.FindRefundsService(tenant.FromContext(c), c.Params("id"), opts)
+	if err != nil {
+		return err
+	}
+
+	refundResponses := []responses.RefundResponseObject{}
+	for _, refund := range refunds {
+		refundResponses = append(refundResponses, responses.RefundResponseObject{
+			ID:          refund.ID,
+			BookingID:   refund.BookingID,
+			UserID:      refund.UserID,
+			EventID:     refund.EventID,
+			Amount:      refund.Amount,
+			Reason:      refund.Reason,
+			Status:      refund.Status,
+			ProcessedAt: refund.ProcessedAt,
+			CreatedAt:   refund.CreatedAt,
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse(
-		fmt.Sprintf("Events found based on criteria: %v", criteria),
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Refunds found", refundResponses, opts.Pagination(total)))
+}
+
+var searchQueryConfig = query.Config{
+	Sorts:   []string{SortRelevance, SortStartDate, SortPrice, SortName, SortCreatedAt, SortRating},
+	Filters: []string{"category"},
+}
+
+func (h *httpHandler) SearchEventHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, searchQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	search := entity.EventSearch{
+		Query:         c.Query("q"),
+		PublishedOnly: !canManage(c),
+	}
+	if search.StartFrom, err = parseTimeQuery(c.Query("start_from")); err != nil {
+		return fmt.Errorf("%w: start_from is invalid", ErrInvalidSearchParams)
+	}
+	if search.StartTo, err = parseTimeQuery(c.Query("start_to")); err != nil {
+		return fmt.Errorf("%w: start_to is invalid", ErrInvalidSearchParams)
+	}
+	if search.MinPrice, err = parseFloatQuery(c.Query("min_price")); err != nil {
+		return fmt.Errorf("%w: min_price is invalid", ErrInvalidSearchParams)
+	}
+	if search.MaxPrice, err = parseFloatQuery(c.Query("max_price")); err != nil {
+		return fmt.Errorf("%w: max_price is invalid", ErrInvalidSearchParams)
+	}
+
+	events, total, err := h.svc.SearchEventService(search, opts)
+	if err != nil {
+		return err
+	}
+
+	eventResponse := []responses.EventResponseObject{}
+	for _, event := range events {
+		eventResponse = append(eventResponse, newEventResponse(&event))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse(
+		"Events found",
 		eventResponse,
+		opts.Pagination(total),
 	))
 }
 
+// canManage reports whether the caller is a platform admin or an organizer
+// on an organizer route; everyone else only sees published events.
+func canManage(c *fiber.Ctx) bool {
+	return c.Locals("role") == "admin" || !tenant.FromContext(c).Global()
+}
+
+// record audits an action that changed an event loaded before it.
+func (h *httpHandler) record(c *fiber.Ctx, action string, before, after *entity.Event) {
+	entry := audit.FromRequest(c, action, audit.TargetEvent, after.ID.String())
+	entry.Changes = audit.Diff(before, after)
+	h.audit.Record(entry)
+}
+
+func newEventResponse(event *entity.Event) responses.EventResponseObject {
+	now := time.Now()
+	return responses.EventResponseObject{
+		ID:                 event.ID,
+		Name:               event.Name,
+		Location:           event.Location,
+		StartDate:          event.StartDate,
+		EndDate:            event.EndDate,
+		Price:              event.Price,
+		TotalSeat:          event.TotalSeat,
+		AvailableSeat:      event.AvailableSeat,
+		Category:           event.Category,
+		SeriesID:           event.SeriesID,
+		VenueID:            event.VenueID,
+		OrganizerID:        event.OrganizerID,
+		Status:             event.Lifecycle(now),
+		PublishAt:          event.PublishAt,
+		SalesStartAt:       event.SalesStartAt,
+		SalesEndAt:         event.SalesEndAt,
+		SalesOpen:          event.SalesOpen(now),
+		CancelledAt:        event.CancelledAt,
+		CancellationReason: event.CancellationReason,
+		Rating:             event.Rating,
+		RatingCount:        event.RatingCount,
+	}
+}
+
+// parseTimeQuery accepts either an RFC 3339 timestamp or a plain date.
+func parseTimeQuery(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
+
+func parseFloatQuery(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
 type CustomEventBookingsResponse struct {
 	responses.EventResponseObject
 	Bookings []responses.BookingResponseObject `json:"bookings"`
@@ -245,26 +520,16 @@ type CustomEventBookingsResponse struct {
 func (h *httpHandler) GetEventBookingsHandler(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return ErrEventNotFound
 	}
 
-	event, err := h.svc.GetEventBookingsService(id)
+	event, err := h.svc.GetEventBookingsService(tenant.FromContext(c), id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
 	res := CustomEventBookingsResponse{
-		EventResponseObject: responses.EventResponseObject{
-			ID:            event.ID,
-			Name:          event.Name,
-			Location:      event.Location,
-			StartDate:     event.StartDate,
-			EndDate:       event.EndDate,
-			Price:         event.Price,
-			TotalSeat:     event.TotalSeat,
-			AvailableSeat: event.AvailableSeat,
-			Category:      event.Category,
-		},
+		EventResponseObject: newEventResponse(&event),
 	}
 
 	for _, booking := range event.Bookings {
@@ -274,6 +539,7 @@ func (h *httpHandler) GetEventBookingsHandler(c *fiber.Ctx) error {
 			EventID:    booking.EventID,
 			Quantity:   booking.Quantity,
 			TotalPrice: booking.TotalPrice,
+			Status:     booking.Status,
 			CreatedAt:  booking.CreatedAt,
 			UpdatedAt:  booking.UpdatedAt,
 		})
@@ -0,0 +1,63 @@
+package event
+
+import (
+	"event-booking/internal/api/validator"
+	"event-booking/internal/event/mocks"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHandler serves handler at route, requests path and returns the error it
+// returned for the request.
+func testHandler(t *testing.T, method, route, path, body string, handler fiber.Handler) error {
+	var handlerErr error
+	app := fiber.New()
+	app.Add(method, route, func(c *fiber.Ctx) error {
+		handlerErr = handler(c)
+		return nil
+	})
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	_, err := app.Test(req)
+	assert.NoError(t, err)
+
+	return handlerErr
+}
+
+func TestSaveEventHandler(t *testing.T) {
+	t.Run("malformed id", func(t *testing.T) {
+		h := NewHttpHandler(NewService(mocks.NewRepository(t), nil, nil), nil, validator.NewValidator())
+
+		err := testHandler(t, fiber.MethodPut, "/api/admin/event/:id", "/api/admin/event/abc", `{}`, h.SaveEventHandler)
+
+		assert.ErrorIs(t, err, ErrEventNotFound)
+	})
+}
+
+func TestEventPayloadPrice(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour)
+	v := validator.NewValidator()
+
+	for _, price := range []float64{0, 25} {
+		assert.NoError(t, v.ValidateStruct(&EventInputPayload{
+			Name: "Free Concert", Location: "City Park", StartDate: start, EndDate: start.Add(time.Hour),
+			Price: price, TotalSeat: 100, Category: "music",
+		}))
+		assert.NoError(t, v.ValidateStruct(&EventUpdatePayload{
+			Name: "Free Concert", Location: "City Park", StartDate: start, EndDate: start.Add(time.Hour),
+			Price: price, TotalSeat: 100, Category: "music",
+		}))
+	}
+
+	err := v.ValidateStruct(&EventInputPayload{
+		Name: "Free Concert", Location: "City Park", StartDate: start, EndDate: start.Add(time.Hour),
+		Price: -1, TotalSeat: 100, Category: "music",
+	})
+	assert.ErrorIs(t, err, validator.ErrInvalidPayload)
+}
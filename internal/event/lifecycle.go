@@ -0,0 +1,168 @@
+package event
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"event-booking/internal/notification"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidTransition = apperror.Conflict("invalid_transition", "invalid event status transition")
+	ErrEventHasBookings  = apperror.Conflict("event_has_bookings", "events with confirmed bookings must be cancelled before they are deleted")
+)
+
+// PublishEventService makes a draft event visible, either right away or at
+// publishAt when it is given.
+func (s *Service) PublishEventService(scope tenant.Scope, id string, publishAt *time.Time) (*entity.Event, error) {
+	event, err := s.repo.FindInScope(scope, id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	if event.Status == entity.EventStatusCancelled {
+		return nil, fmt.Errorf("%w: cancelled events cannot be published", ErrInvalidTransition)
+	}
+
+	event.Status = entity.EventStatusPublished
+	event.PublishAt = publishAt
+
+	event, err = s.repo.Save(event)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// UnpublishEventService moves an event back to draft. Events with bookings
+// have to be cancelled instead.
+func (s *Service) UnpublishEventService(scope tenant.Scope, id string) (*entity.Event, error) {
+	event, err := s.repo.FindInScope(scope, id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	if event.Status == entity.EventStatusCancelled {
+		return nil, fmt.Errorf("%w: cancelled events cannot be unpublished", ErrInvalidTransition)
+	}
+	if event.AvailableSeat < event.TotalSeat {
+		return nil, fmt.Errorf("%w: events with bookings must be cancelled instead", ErrInvalidTransition)
+	}
+
+	event.Status = entity.EventStatusDraft
+	event.PublishAt = nil
+
+	event, err = s.repo.Save(event)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// CancelEventService cancels the event and every confirmed booking on it.
+// Each booking gets a pending refund for its full price and the booker is
+// emailed; nothing is deleted.
+func (s *Service) CancelEventService(scope tenant.Scope, id, reason string) (*entity.Event, error) {
+	event, err := s.repo.FindInScope(scope, id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	now := time.Now()
+	switch event.Lifecycle(now) {
+	case entity.EventStatusCancelled:
+		return nil, fmt.Errorf("%w: event is already cancelled", ErrInvalidTransition)
+	case entity.EventStatusCompleted:
+		return nil, fmt.Errorf("%w: completed events cannot be cancelled", ErrInvalidTransition)
+	}
+
+	event.Status = entity.EventStatusCancelled
+	event.CancelledAt = &now
+	event.CancellationReason = reason
+
+	bookings, err := s.repo.Cancel(event)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	for _, booking := range bookings {
+		if s.publisher != nil {
+			s.publisher.Publish(entity.WebhookEventBookingCancelled, entity.NewWebhookBookingPayload(&booking))
+		}
+	}
+
+	if s.notifier != nil {
+		s.notifyCancelled(event, bookings)
+	}
+
+	return event, nil
+}
+
+// DeleteEventService soft deletes an event together with its bookings and
+// reviews. Confirmed bookings have to be refunded by cancelling the event
+// first; admins can restore the event from the trash until it is purged.
+func (s *Service) DeleteEventService(scope tenant.Scope, id string) (*entity.Event, error) {
+	event, err := s.repo.FindInScope(scope, id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	booked, err := s.repo.CountBookedSeats(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if booked > 0 {
+		return nil, ErrEventHasBookings
+	}
+
+	if err := s.repo.Delete(event); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (s *Service) notifyCancelled(event *entity.Event, bookings []entity.Booking) {
+	for _, booking := range bookings {
+		s.notifier.Notify(notification.Notification{
+			Type:   notification.TypeEventCancelled,
+			UserID: booking.UserID,
+			Data: notification.EventCancelledData{
+				EventName: event.Name,
+				Reason:    event.CancellationReason,
+				Refund:    booking.TotalPrice,
+			},
+		})
+	}
+}
+
+func (s *Service) FindRefundsService(scope tenant.Scope, eventID string, opts query.Options) ([]entity.Refund, int64, error) {
+	if _, err := s.repo.FindInScope(scope, eventID); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	refunds, total, err := s.repo.FindRefundsByEventID(eventID, opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return refunds, total, nil
+}
@@ -5,6 +5,10 @@ package mocks
 import (
 	entity "event-booking/internal/entity"
 
+	query "event-booking/internal/api/query"
+
+	tenant "event-booking/internal/api/tenant"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,24 +17,24 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: _a0
-func (_m *Repository) Create(_a0 *entity.Event) (*entity.Event, error) {
+// Cancel provides a mock function with given fields: _a0
+func (_m *Repository) Cancel(_a0 *entity.Event) ([]entity.Booking, error) {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for Cancel")
 	}
 
-	var r0 *entity.Event
+	var r0 []entity.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*entity.Event) (*entity.Event, error)); ok {
+	if rf, ok := ret.Get(0).(func(*entity.Event) ([]entity.Booking, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(*entity.Event) *entity.Event); ok {
+	if rf, ok := ret.Get(0).(func(*entity.Event) []entity.Booking); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.Event)
+			r0 = ret.Get(0).([]entity.Booking)
 		}
 	}
 
@@ -43,47 +47,85 @@ func (_m *Repository) Create(_a0 *entity.Event) (*entity.Event, error) {
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Repository) Delete(id string) error {
-	ret := _m.Called(id)
+// CountBookedSeats provides a mock function with given fields: eventID
+func (_m *Repository) CountBookedSeats(eventID string) (int, error) {
+	ret := _m.Called(eventID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for CountBookedSeats")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(id)
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(eventID)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(eventID)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// FilterByCriteria provides a mock function with given fields: criteria
-func (_m *Repository) FilterByCriteria(criteria map[string]interface{}) ([]entity.Event, error) {
-	ret := _m.Called(criteria)
+// CountSeatsByVenueID provides a mock function with given fields: venueID
+func (_m *Repository) CountSeatsByVenueID(venueID string) (int, error) {
+	ret := _m.Called(venueID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FilterByCriteria")
+		panic("no return value specified for CountSeatsByVenueID")
 	}
 
-	var r0 []entity.Event
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]entity.Event, error)); ok {
-		return rf(criteria)
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(venueID)
 	}
-	if rf, ok := ret.Get(0).(func(map[string]interface{}) []entity.Event); ok {
-		r0 = rf(criteria)
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(venueID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(venueID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: _a0
+func (_m *Repository) Create(_a0 *entity.Event) (*entity.Event, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *entity.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entity.Event) (*entity.Event, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*entity.Event) *entity.Event); ok {
+		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Event)
+			r0 = ret.Get(0).(*entity.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
-		r1 = rf(criteria)
+	if rf, ok := ret.Get(1).(func(*entity.Event) error); ok {
+		r1 = rf(_a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,6 +133,24 @@ func (_m *Repository) FilterByCriteria(criteria map[string]interface{}) ([]entit
 	return r0, r1
 }
 
+// Delete provides a mock function with given fields: _a0
+func (_m *Repository) Delete(_a0 *entity.Event) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Event) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Find provides a mock function with given fields: id
 func (_m *Repository) Find(id string) (*entity.Event, error) {
 	ret := _m.Called(id)
@@ -121,59 +181,66 @@ func (_m *Repository) Find(id string) (*entity.Event, error) {
 	return r0, r1
 }
 
-// FindAll provides a mock function with given fields:
-func (_m *Repository) FindAll() ([]entity.Event, error) {
-	ret := _m.Called()
+// FindAll provides a mock function with given fields: scope, opts
+func (_m *Repository) FindAll(scope tenant.Scope, opts query.Options) ([]entity.Event, int64, error) {
+	ret := _m.Called(scope, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindAll")
 	}
 
 	var r0 []entity.Event
-	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]entity.Event, error)); ok {
-		return rf()
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(tenant.Scope, query.Options) ([]entity.Event, int64, error)); ok {
+		return rf(scope, opts)
 	}
-	if rf, ok := ret.Get(0).(func() []entity.Event); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(tenant.Scope, query.Options) []entity.Event); ok {
+		r0 = rf(scope, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(tenant.Scope, query.Options) int64); ok {
+		r1 = rf(scope, opts)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(tenant.Scope, query.Options) error); ok {
+		r2 = rf(scope, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// FindByName provides a mock function with given fields: name
-func (_m *Repository) FindByName(name string) (*entity.Event, error) {
-	ret := _m.Called(name)
+// FindInScope provides a mock function with given fields: scope, id
+func (_m *Repository) FindInScope(scope tenant.Scope, id string) (*entity.Event, error) {
+	ret := _m.Called(scope, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByName")
+		panic("no return value specified for FindInScope")
 	}
 
 	var r0 *entity.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entity.Event, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string) (*entity.Event, error)); ok {
+		return rf(scope, id)
 	}
-	if rf, ok := ret.Get(0).(func(string) *entity.Event); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string) *entity.Event); ok {
+		r0 = rf(scope, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(tenant.Scope, string) error); ok {
+		r1 = rf(scope, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -181,9 +248,83 @@ func (_m *Repository) FindByName(name string) (*entity.Event, error) {
 	return r0, r1
 }
 
-// GetBookingsByEventID provides a mock function with given fields: eventID
-func (_m *Repository) GetBookingsByEventID(eventID string) (entity.Event, error) {
-	ret := _m.Called(eventID)
+// FindPublished provides a mock function with given fields: opts
+func (_m *Repository) FindPublished(opts query.Options) ([]entity.Event, int64, error) {
+	ret := _m.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPublished")
+	}
+
+	var r0 []entity.Event
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(query.Options) ([]entity.Event, int64, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(query.Options) []entity.Event); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(query.Options) int64); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(query.Options) error); ok {
+		r2 = rf(opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindRefundsByEventID provides a mock function with given fields: eventID, opts
+func (_m *Repository) FindRefundsByEventID(eventID string, opts query.Options) ([]entity.Refund, int64, error) {
+	ret := _m.Called(eventID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRefundsByEventID")
+	}
+
+	var r0 []entity.Refund
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, query.Options) ([]entity.Refund, int64, error)); ok {
+		return rf(eventID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(string, query.Options) []entity.Refund); ok {
+		r0 = rf(eventID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, query.Options) int64); ok {
+		r1 = rf(eventID, opts)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(string, query.Options) error); ok {
+		r2 = rf(eventID, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBookingsByEventID provides a mock function with given fields: scope, eventID
+func (_m *Repository) GetBookingsByEventID(scope tenant.Scope, eventID string) (entity.Event, error) {
+	ret := _m.Called(scope, eventID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBookingsByEventID")
@@ -191,17 +332,17 @@ func (_m *Repository) GetBookingsByEventID(eventID string) (entity.Event, error)
 
 	var r0 entity.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (entity.Event, error)); ok {
-		return rf(eventID)
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string) (entity.Event, error)); ok {
+		return rf(scope, eventID)
 	}
-	if rf, ok := ret.Get(0).(func(string) entity.Event); ok {
-		r0 = rf(eventID)
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string) entity.Event); ok {
+		r0 = rf(scope, eventID)
 	} else {
 		r0 = ret.Get(0).(entity.Event)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(eventID)
+	if rf, ok := ret.Get(1).(func(tenant.Scope, string) error); ok {
+		r1 = rf(scope, eventID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -209,6 +350,43 @@ func (_m *Repository) GetBookingsByEventID(eventID string) (entity.Event, error)
 	return r0, r1
 }
 
+// RecountSeats provides a mock function with given fields: eventID
+func (_m *Repository) RecountSeats(eventID string) (*entity.Event, int, error) {
+	ret := _m.Called(eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecountSeats")
+	}
+
+	var r0 *entity.Event
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (*entity.Event, int, error)); ok {
+		return rf(eventID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entity.Event); ok {
+		r0 = rf(eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) int); ok {
+		r1 = rf(eventID)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(eventID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Save provides a mock function with given fields: _a0
 func (_m *Repository) Save(_a0 *entity.Event) (*entity.Event, error) {
 	ret := _m.Called(_a0)
@@ -239,6 +417,43 @@ func (_m *Repository) Save(_a0 *entity.Event) (*entity.Event, error) {
 	return r0, r1
 }
 
+// Search provides a mock function with given fields: search, opts
+func (_m *Repository) Search(search entity.EventSearch, opts query.Options) ([]entity.Event, int64, error) {
+	ret := _m.Called(search, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []entity.Event
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(entity.EventSearch, query.Options) ([]entity.Event, int64, error)); ok {
+		return rf(search, opts)
+	}
+	if rf, ok := ret.Get(0).(func(entity.EventSearch, query.Options) []entity.Event); ok {
+		r0 = rf(search, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(entity.EventSearch, query.Options) int64); ok {
+		r1 = rf(search, opts)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(entity.EventSearch, query.Options) error); ok {
+		r2 = rf(search, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
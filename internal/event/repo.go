@@ -1,9 +1,13 @@
 package event
 
 import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/entity"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type repo struct {
@@ -32,13 +36,20 @@ func (r *repo) Save(event *entity.Event) (*entity.Event, error) {
 	return event, nil
 }
 
-func (r *repo) FindAll() ([]entity.Event, error) {
+func (r *repo) FindAll(scope tenant.Scope, opts query.Options) ([]entity.Event, int64, error) {
+	db := r.db.Model(&entity.Event{}).Scopes(scope.Events, opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var events []entity.Event
-	if err := r.db.Find(&events).Error; err != nil {
-		return nil, err
+	if err := db.Scopes(opts.Paginate).Find(&events).Error; err != nil {
+		return nil, 0, err
 	}
 
-	return events, nil
+	return events, total, nil
 }
 
 func (r *repo) Find(id string) (*entity.Event, error) {
@@ -50,36 +61,244 @@ func (r *repo) Find(id string) (*entity.Event, error) {
 	return &event, nil
 }
 
-func (r *repo) FindByName(name string) (*entity.Event, error) {
+func (r *repo) FindInScope(scope tenant.Scope, id string) (*entity.Event, error) {
 	var event entity.Event
-	if err := r.db.Where("name = ?", name).First(&event).Error; err != nil {
+	if err := r.db.Scopes(scope.Events).Where("id = ?", id).First(&event).Error; err != nil {
 		return nil, err
 	}
 
 	return &event, nil
 }
 
-func (r *repo) Delete(id string) error {
-	err := r.db.Where("id = ?", id).First(&entity.Event{}).Delete(&entity.Event{}).Error
+// published limits a query to events regular users may see.
+func published(db *gorm.DB) *gorm.DB {
+	return db.Where("status = ? AND (publish_at IS NULL OR publish_at <= ?)", entity.EventStatusPublished, time.Now())
+}
+
+func (r *repo) FindPublished(opts query.Options) ([]entity.Event, int64, error) {
+	db := r.db.Model(&entity.Event{}).Scopes(published, opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []entity.Event
+	if err := db.Scopes(opts.Paginate).Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// Cancel saves the cancelled event, cancels its confirmed bookings, frees
+// their seats and records a pending refund for each. The cancelled bookings
+// are returned with their users so they can be notified.
+func (r *repo) Cancel(event *entity.Event) ([]entity.Booking, error) {
+	var bookings []entity.Booking
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(event).Error; err != nil {
+			return err
+		}
+
+		err := tx.Preload("User").
+			Where("event_id = ? AND status = ?", event.ID, entity.BookingStatusConfirmed).
+			Find(&bookings).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range bookings {
+			booking := &bookings[i]
+			booking.Status = entity.BookingStatusCancelled
+			booking.CancelledAt = event.CancelledAt
+
+			err := tx.Model(booking).Updates(map[string]interface{}{
+				"status":       booking.Status,
+				"cancelled_at": booking.CancelledAt,
+			}).Error
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Where("booking_id = ?", booking.ID).Delete(&entity.BookingSeat{}).Error; err != nil {
+				return err
+			}
+
+			refund := &entity.Refund{
+				BookingID: booking.ID,
+				UserID:    booking.UserID,
+				EventID:   booking.EventID,
+				Amount:    booking.TotalPrice,
+				Reason:    event.CancellationReason,
+			}
+			if err := tx.Create(refund).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// Delete soft deletes the event and its bookings and reviews with one
+// deleted_at, so restoring the event can bring back exactly these rows.
+func (r *repo) Delete(event *entity.Event) error {
+	event.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.Booking{}).Where("event_id = ?", event.ID).Update("deleted_at", event.DeletedAt).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entity.Review{}).Where("event_id = ?", event.ID).Update("deleted_at", event.DeletedAt).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(event).Update("deleted_at", event.DeletedAt).Error
+	})
+}
+
+func (r *repo) FindRefundsByEventID(eventID string, opts query.Options) ([]entity.Refund, int64, error) {
+	db := r.db.Model(&entity.Refund{}).Where("event_id = ?", eventID).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var refunds []entity.Refund
+	if err := db.Scopes(opts.Paginate).Find(&refunds).Error; err != nil {
+		return nil, 0, err
 	}
 
-	return nil
+	return refunds, total, nil
 }
 
-func (r *repo) FilterByCriteria(criteria map[string]interface{}) ([]entity.Event, error) {
+const searchDocument = "to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(location, ''))"
+
+func (r *repo) Search(search entity.EventSearch, opts query.Options) ([]entity.Event, int64, error) {
+	db := r.db.Model(&entity.Event{}).Scopes(opts.Filter)
+
+	if search.PublishedOnly {
+		db = db.Scopes(published)
+	}
+	if search.Query != "" {
+		db = db.Where(searchDocument+" @@ websearch_to_tsquery('simple', ?)", search.Query)
+	}
+	if search.StartFrom != nil {
+		db = db.Where("start_date >= ?", *search.StartFrom)
+	}
+	if search.StartTo != nil {
+		db = db.Where("start_date <= ?", *search.StartTo)
+	}
+	if search.MinPrice != nil {
+		db = db.Where("price >= ?", *search.MinPrice)
+	}
+	if search.MaxPrice != nil {
+		db = db.Where("price <= ?", *search.MaxPrice)
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// relevance is not a column, so rank it here and let Paginate handle the rest
+	sorted := opts
+	sorted.Sort = nil
+	for _, s := range opts.Sort {
+		if s.Field == SortRelevance {
+			db = db.Order(gorm.Expr("ts_rank("+searchDocument+", websearch_to_tsquery('simple', ?)) DESC", search.Query))
+			continue
+		}
+		sorted.Sort = append(sorted.Sort, s)
+	}
+
 	var events []entity.Event
-	if err := r.db.Where(criteria).Find(&events).Error; err != nil {
-		return nil, err
+	if err := db.Scopes(sorted.Paginate).Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+func (r *repo) CountSeatsByVenueID(venueID string) (int, error) {
+	var count int64
+	err := r.db.Model(&entity.Seat{}).
+		Joins("JOIN sections ON sections.id = seats.section_id").
+		Where("sections.venue_id = ?", venueID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// CountBookedSeats sums the quantities of the event's confirmed bookings.
+func (r *repo) CountBookedSeats(eventID string) (int, error) {
+	var booked int64
+	err := r.db.Model(&entity.Booking{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("event_id = ? AND status = ?", eventID, entity.BookingStatusConfirmed).
+		Scan(&booked).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(booked), nil
+}
+
+// RecountSeats sets the event's available seats to its total seats minus the
+// quantities of its confirmed bookings, never below zero. The event row is
+// locked so no booking changes the count in between. The previous available
+// seats are returned with the updated event.
+func (r *repo) RecountSeats(eventID string) (*entity.Event, int, error) {
+	var event entity.Event
+	var previous int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", eventID).
+			First(&event).Error
+		if err != nil {
+			return err
+		}
+
+		var booked int64
+		err = tx.Model(&entity.Booking{}).
+			Select("COALESCE(SUM(quantity), 0)").
+			Where("event_id = ? AND status = ?", eventID, entity.BookingStatusConfirmed).
+			Scan(&booked).Error
+		if err != nil {
+			return err
+		}
+
+		previous = event.AvailableSeat
+		event.AvailableSeat = max(event.TotalSeat-int(booked), 0)
+		if event.AvailableSeat == previous {
+			return nil
+		}
+
+		return tx.Model(&event).Update("available_seat", event.AvailableSeat).Error
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return events, nil
+	return &event, previous, nil
 }
 
-func (r *repo) GetBookingsByEventID(eventID string) (entity.Event, error) {
+func (r *repo) GetBookingsByEventID(scope tenant.Scope, eventID string) (entity.Event, error) {
 	var event entity.Event
-	if err := r.db.Preload("Bookings").Where("id = ?", eventID).First(&event).Error; err != nil {
+	if err := r.db.Preload("Bookings").Scopes(scope.Events).Where("id = ?", eventID).First(&event).Error; err != nil {
 		return entity.Event{}, err
 	}
 
@@ -1,111 +1,335 @@
 package event
 
 import (
+	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
+	"event-booking/internal/apperror"
 	"event-booking/internal/entity"
+	"event-booking/internal/notification"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 //go:generate mockery --case snake --name Repository
 type Repository interface {
 	Create(event *entity.Event) (*entity.Event, error)
 	Save(event *entity.Event) (*entity.Event, error)
-	FindAll() ([]entity.Event, error)
+	FindAll(scope tenant.Scope, opts query.Options) ([]entity.Event, int64, error)
 	Find(id string) (*entity.Event, error)
-	FindByName(name string) (*entity.Event, error)
-	FilterByCriteria(criteria map[string]interface{}) ([]entity.Event, error)
-	GetBookingsByEventID(eventID string) (entity.Event, error)
-	Delete(id string) error
+	FindInScope(scope tenant.Scope, id string) (*entity.Event, error)
+	Search(search entity.EventSearch, opts query.Options) ([]entity.Event, int64, error)
+	FindPublished(opts query.Options) ([]entity.Event, int64, error)
+	GetBookingsByEventID(scope tenant.Scope, eventID string) (entity.Event, error)
+	CountSeatsByVenueID(venueID string) (int, error)
+	CountBookedSeats(eventID string) (int, error)
+	RecountSeats(eventID string) (*entity.Event, int, error)
+	Cancel(event *entity.Event) ([]entity.Booking, error)
+	Delete(event *entity.Event) error
+	FindRefundsByEventID(eventID string, opts query.Options) ([]entity.Refund, int64, error)
 }
 
+//go:generate mockery --case snake --name Notifier
+type Notifier interface {
+	Notify(notification notification.Notification)
+}
+
+//go:generate mockery --case snake --name EventPublisher
+type EventPublisher interface {
+	Publish(eventType string, data interface{})
+}
+
+var (
+	ErrEventNotFound = apperror.NotFound("event_not_found", "event not found")
+	ErrEventExists   = apperror.Conflict("event_exists", "an event with this name already starts at this date")
+)
+
 type Service struct {
-	repo Repository
+	repo      Repository
+	notifier  Notifier
+	publisher EventPublisher
 }
 
-func NewService(repo Repository) *Service {
+func NewService(repo Repository, notifier Notifier, publisher EventPublisher) *Service {
 	return &Service{
-		repo: repo,
+		repo:      repo,
+		notifier:  notifier,
+		publisher: publisher,
 	}
 }
 
 func (s *Service) CreateEventService(event *entity.Event) (*entity.Event, error) {
-	_, err := s.repo.FindByName(event.Name)
-	if err == nil {
-		log.Error().Msg("event already exists")
-		return nil, fmt.Errorf("event already exists")
+	// with assigned seating the venue layout decides the capacity
+	if event.VenueID != nil {
+		seats, err := s.repo.CountSeatsByVenueID(event.VenueID.String())
+		if err != nil {
+			log.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		if seats == 0 {
+			return nil, ErrInvalidVenue
+		}
+
+		event.TotalSeat = seats
+	}
+
+	// nothing is booked yet
+	event.AvailableSeat = event.TotalSeat
+	if err := validateEvent(event); err != nil {
+		return nil, err
 	}
 
-	event, err = s.repo.Create(event)
+	// names only have to be unique per start date, which the database
+	// enforces, so series occurrences can share their name
+	event, err := s.repo.Create(event)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEventExists
+		}
 		return nil, err
 	}
 
 	return event, nil
 }
 
-func (s *Service) SaveEventService(event *entity.Event, newEvent *EventUpdatePayload) (*entity.Event, error) {
-	_, err := s.repo.Find(event.ID.String())
+// SaveEventService replaces the editable fields of an event. Available seats
+// are recomputed from the quantities actually booked, so capacity can never
+// drop below what has been sold.
+func (s *Service) SaveEventService(scope tenant.Scope, event *entity.Event) (*entity.Event, error) {
+	existing, err := s.repo.FindInScope(scope, event.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	if existing.Status == entity.EventStatusCancelled {
+		return nil, fmt.Errorf("%w: cancelled events cannot be edited", ErrInvalidTransition)
+	}
+
+	// the lifecycle only changes through publish, unpublish and cancel
+	event.Status = existing.Status
+	event.PublishAt = existing.PublishAt
+	event.OrganizerID = existing.OrganizerID
+	event.CreatedAt = existing.CreatedAt
+
+	// an occurrence edited on its own stays in its series but is no longer
+	// touched by "this and following" edits
+	if existing.SeriesID != nil {
+		event.SeriesID = existing.SeriesID
+		event.Detached = true
+	}
+
+	booked, err := s.repo.CountBookedSeats(existing.ID.String())
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return nil, err
 	}
 
+	if event.VenueID != nil || existing.VenueID != nil {
+		if booked > 0 && !sameVenue(event.VenueID, existing.VenueID) {
+			return nil, ErrVenueLocked
+		}
+
+		if event.VenueID != nil {
+			seats, err := s.repo.CountSeatsByVenueID(event.VenueID.String())
+			if err != nil {
+				log.Error().Err(err).Msg(err.Error())
+				return nil, err
+			}
+			if seats == 0 {
+				return nil, ErrInvalidVenue
+			}
+
+			event.TotalSeat = seats
+		}
+	}
+
+	if event.TotalSeat < booked {
+		return nil, fmt.Errorf("%w: %d seats are booked", ErrCapacityBelowBooked, booked)
+	}
+
+	event.AvailableSeat = event.TotalSeat - booked
+	if err := validateEvent(event); err != nil {
+		return nil, err
+	}
+
 	event, err = s.repo.Save(event)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEventExists
+		}
 		return nil, err
 	}
 
+	if changes := eventChanges(existing, event); s.notifier != nil && booked > 0 && len(changes) > 0 {
+		s.notifyChanged(scope, event, changes)
+	}
+
 	return event, nil
 }
 
-func (s *Service) FindAllEventService() ([]entity.Event, error) {
-	events, err := s.repo.FindAll()
+// eventChanges describes the changes bookers need to know about.
+func eventChanges(before, after *entity.Event) []string {
+	var changes []string
+	if before.Name != after.Name {
+		changes = append(changes, fmt.Sprintf("Name: %s (was %s)", after.Name, before.Name))
+	}
+	if !before.StartDate.Equal(after.StartDate) {
+		changes = append(changes, fmt.Sprintf("Start: %s (was %s)", after.StartDate.Format(changeTimeFormat), before.StartDate.Format(changeTimeFormat)))
+	}
+	if !before.EndDate.Equal(after.EndDate) {
+		changes = append(changes, fmt.Sprintf("End: %s (was %s)", after.EndDate.Format(changeTimeFormat), before.EndDate.Format(changeTimeFormat)))
+	}
+	if before.Location != after.Location {
+		changes = append(changes, fmt.Sprintf("Location: %s (was %s)", after.Location, before.Location))
+	}
+
+	return changes
+}
+
+const changeTimeFormat = "Mon, 02 Jan 2006 15:04 MST"
+
+// notifyChanged tells every user with a confirmed booking about the changes,
+// once per user.
+func (s *Service) notifyChanged(scope tenant.Scope, event *entity.Event, changes []string) {
+	withBookings, err := s.repo.GetBookingsByEventID(scope, event.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to notify bookers of event %s about changes", event.ID)
+		return
+	}
+
+	notified := make(map[uuid.UUID]bool)
+	for _, booking := range withBookings.Bookings {
+		if booking.Status != entity.BookingStatusConfirmed || notified[booking.UserID] {
+			continue
+		}
+		notified[booking.UserID] = true
+
+		s.notifier.Notify(notification.Notification{
+			Type:   notification.TypeEventChanged,
+			UserID: booking.UserID,
+			Data: notification.EventChangedData{
+				EventName: event.Name,
+				Location:  event.Location,
+				StartDate: event.StartDate,
+				EndDate:   event.EndDate,
+				Changes:   changes,
+			},
+		})
+	}
+}
+
+func (s *Service) FindAllEventService(scope tenant.Scope, opts query.Options) ([]entity.Event, int64, error) {
+	events, total, err := s.repo.FindAll(scope, opts)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, 0, err
 	}
 
-	return events, nil
+	return events, total, nil
 }
 
-func (s *Service) FindEventService(id string) (*entity.Event, error) {
-	event, err := s.repo.Find(id)
+// FindPublishedEventService lists what regular users may see.
+func (s *Service) FindPublishedEventService(opts query.Options) ([]entity.Event, int64, error) {
+	events, total, err := s.repo.FindPublished(opts)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+func (s *Service) FindEventService(scope tenant.Scope, id string) (*entity.Event, error) {
+	event, err := s.repo.FindInScope(scope, id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
 	}
 
 	return event, nil
 }
 
-func (s *Service) DeleteEventService(id string) error {
-	err := s.repo.Delete(id)
+// RecountSeatsService repairs the available seats of an event from its
+// confirmed bookings and returns the event with the count it had before.
+func (s *Service) RecountSeatsService(id string) (*entity.Event, int, error) {
+	event, previous, err := s.repo.RecountSeats(id)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return err
+		return nil, 0, apperror.IfNotFound(err, ErrEventNotFound)
 	}
 
-	return nil
+	return event, previous, nil
 }
 
-func (s *Service) FilterEventService(criteria map[string]interface{}) ([]entity.Event, error) {
-	events, err := s.repo.FilterByCriteria(criteria)
+const (
+	SortRelevance = "relevance"
+	SortStartDate = "start_date"
+	SortPrice     = "price"
+	SortName      = "name"
+	SortCreatedAt = "created_at"
+	SortRating    = "rating"
+)
+
+var ErrInvalidSearchParams = apperror.Validation("invalid_search", "invalid search parameters")
+
+var (
+	ErrInvalidVenue = apperror.Validation("invalid_venue", "venue does not exist or has no seats")
+	ErrVenueLocked  = apperror.Conflict("venue_locked", "venue cannot change once seats are booked")
+)
+
+func (s *Service) SearchEventService(search entity.EventSearch, opts query.Options) ([]entity.Event, int64, error) {
+	if search.StartFrom != nil && search.StartTo != nil && search.StartFrom.After(*search.StartTo) {
+		return nil, 0, fmt.Errorf("%w: start_from must not be after start_to", ErrInvalidSearchParams)
+	}
+	if search.MinPrice != nil && search.MaxPrice != nil && *search.MinPrice > *search.MaxPrice {
+		return nil, 0, fmt.Errorf("%w: min_price must not be greater than max_price", ErrInvalidSearchParams)
+	}
+
+	// relevance only makes sense for a text query
+	var sorts []query.SortField
+	for _, sort := range opts.Sort {
+		if sort.Field == SortRelevance && search.Query == "" {
+			continue
+		}
+		sorts = append(sorts, sort)
+	}
+	if len(sorts) == 0 {
+		if search.Query != "" {
+			sorts = []query.SortField{{Field: SortRelevance}}
+		} else {
+			sorts = []query.SortField{{Field: SortStartDate}}
+		}
+	}
+	opts.Sort = sorts
+
+	events, total, err := s.repo.Search(search, opts)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+func sameVenue(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
 	}
 
-	return events, nil
+	return *a == *b
 }
 
-func (s *Service) GetEventBookingsService(eventID string) (entity.Event, error) {
-	event, err := s.repo.GetBookingsByEventID(eventID)
+func (s *Service) GetEventBookingsService(scope tenant.Scope, eventID string) (entity.Event, error) {
+	event, err := s.repo.GetBookingsByEventID(scope, eventID)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return entity.Event{}, err
+		return entity.Event{}, apperror.IfNotFound(err, ErrEventNotFound)
 	}
 
 	return event, nil
@@ -1,13 +1,18 @@
 package event
 
 import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/entity"
 	"event-booking/internal/event/mocks"
+	"event-booking/internal/notification"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestCreateEvent(t *testing.T) {
@@ -27,9 +32,8 @@ func TestCreateEvent(t *testing.T) {
 
 	t.Run("create event successfully", func(t *testing.T) {
 		mockRepo.On("Create", mockEvent).Return(mockEvent, nil).Once()
-		mockRepo.On("FindByName", mockEvent.Name).Return(nil, nil).Once()
 
-		svc := NewService(mockRepo)
+		svc := NewService(mockRepo, nil, nil)
 		event, err := svc.CreateEventService(mockEvent)
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
@@ -38,20 +42,36 @@ func TestCreateEvent(t *testing.T) {
 		assert.Equal(t, mockEvent, event)
 	})
 
+	t.Run("same name on another date", func(t *testing.T) {
+		nextWeek := *mockEvent
+		nextWeek.ID = uuid.New()
+		nextWeek.StartDate = mockEvent.StartDate.AddDate(0, 0, 7)
+		nextWeek.EndDate = mockEvent.EndDate.AddDate(0, 0, 7)
+
+		mockRepo.On("Create", mockEvent).Return(mockEvent, nil).Once()
+		mockRepo.On("Create", &nextWeek).Return(&nextWeek, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.CreateEventService(mockEvent)
+		assert.NoError(t, err)
+
+		event, err := svc.CreateEventService(&nextWeek)
+		assert.NoError(t, err)
+		assert.Equal(t, &nextWeek, event)
+	})
+
 	t.Run("event already exists", func(t *testing.T) {
-		mockRepo.On("FindByName", mockEvent.Name).Return(mockEvent, nil).Once()
+		mockRepo.On("Create", mockEvent).Return(nil, gorm.ErrDuplicatedKey).Once()
 
-		svc := NewService(mockRepo)
+		svc := NewService(mockRepo, nil, nil)
 		_, err := svc.CreateEventService(mockEvent)
-		if err == nil {
-			t.Error("expected error; got nil")
-		}
+		assert.ErrorIs(t, err, ErrEventExists)
 	})
 
 	t.Run("create event failed", func(t *testing.T) {
 		mockRepo.On("Create", mockEvent).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockRepo)
+		svc := NewService(mockRepo, nil, nil)
 		_, err := svc.CreateEventService(mockEvent)
 		if err == nil {
 			t.Error("expected error; got nil")
@@ -60,9 +80,7 @@ func TestCreateEvent(t *testing.T) {
 }
 
 func TestSaveEvent(t *testing.T) {
-	mockRepo := mocks.NewRepository(t)
-
-	mockEvent := &entity.Event{
+	existing := &entity.Event{
 		ID:            uuid.New(),
 		Name:          "Test Event",
 		Location:      "Test Location",
@@ -72,42 +90,142 @@ func TestSaveEvent(t *testing.T) {
 		TotalSeat:     100,
 		AvailableSeat: 100,
 		Category:      "Test Category",
+		Status:        entity.EventStatusPublished,
+		CreatedAt:     time.Now().Add(-time.Hour),
 	}
 
-	newEvent := &EventUpdatePayload{
-		Name:          "New Event",
-		Location:      "New Location",
-		StartDate:     time.Now().Add(time.Hour * 3),
-		EndDate:       time.Now().Add(time.Hour * 5),
-		Price:         200000,
-		TotalSeat:     200,
-		AvailableSeat: 200,
-		Category:      "New Category",
+	newEvent := func() *entity.Event {
+		return &entity.Event{
+			ID:        existing.ID,
+			Name:      "New Event",
+			Location:  "New Location",
+			StartDate: time.Now().Add(time.Hour * 3),
+			EndDate:   time.Now().Add(time.Hour * 5),
+			Price:     200000,
+			TotalSeat: 200,
+			Category:  "New Category",
+		}
 	}
 
-	t.Run("save event successfully", func(t *testing.T) {
-		mockRepo.On("Save", mockEvent).Return(mockEvent, nil).Once()
+	t.Run("save event recomputes available seats from bookings", func(t *testing.T) {
+		event := newEvent()
 
-		svc := NewService(mockRepo)
-		event, err := svc.SaveEventService(mockEvent, newEvent)
-		if err != nil {
-			t.Errorf("expected error to be nil; got %v", err)
-		}
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, existing.ID.String()).Return(existing, nil).Once()
+		mockRepo.On("CountBookedSeats", existing.ID.String()).Return(30, nil).Once()
+		mockRepo.On("Save", event).Return(event, nil).Once()
 
-		assert.Equal(t, mockEvent, event)
+		svc := NewService(mockRepo, nil, nil)
+		saved, err := svc.SaveEventService(tenant.Scope{}, event)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 170, saved.AvailableSeat)
+		assert.Equal(t, entity.EventStatusPublished, saved.Status)
+		assert.Equal(t, existing.CreatedAt, saved.CreatedAt)
+	})
+
+	t.Run("bookers are notified of changes once", func(t *testing.T) {
+		event := newEvent()
+		userID := uuid.New()
+		bookings := entity.Event{Bookings: []entity.Booking{
+			{UserID: userID, Status: entity.BookingStatusConfirmed},
+			{UserID: userID, Status: entity.BookingStatusConfirmed},
+			{UserID: uuid.New(), Status: entity.BookingStatusCancelled},
+		}}
+
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, existing.ID.String()).Return(existing, nil).Once()
+		mockRepo.On("CountBookedSeats", existing.ID.String()).Return(30, nil).Once()
+		mockRepo.On("Save", event).Return(event, nil).Once()
+		mockRepo.On("GetBookingsByEventID", tenant.Scope{}, existing.ID.String()).Return(bookings, nil).Once()
+		mockNotifier.On("Notify", mock.MatchedBy(func(n notification.Notification) bool {
+			data, ok := n.Data.(notification.EventChangedData)
+			return ok && n.Type == notification.TypeEventChanged && n.UserID == userID && len(data.Changes) == 4
+		})).Once()
+
+		svc := NewService(mockRepo, mockNotifier, nil)
+		_, err := svc.SaveEventService(tenant.Scope{}, event)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("capacity below booked seats", func(t *testing.T) {
+		event := newEvent()
+		event.TotalSeat = 20
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, existing.ID.String()).Return(existing, nil).Once()
+		mockRepo.On("CountBookedSeats", existing.ID.String()).Return(30, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.SaveEventService(tenant.Scope{}, event)
+
+		assert.ErrorIs(t, err, ErrCapacityBelowBooked)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		event := newEvent()
+		event.EndDate = event.StartDate.Add(-time.Hour)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, existing.ID.String()).Return(existing, nil).Once()
+		mockRepo.On("CountBookedSeats", existing.ID.String()).Return(0, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.SaveEventService(tenant.Scope{}, event)
+
+		assert.ErrorIs(t, err, ErrInvalidEvent)
 	})
 
 	t.Run("save event failed", func(t *testing.T) {
-		mockRepo.On("Save", mockEvent).Return(nil, assert.AnError).Once()
+		event := newEvent()
 
-		svc := NewService(mockRepo)
-		_, err := svc.SaveEventService(mockEvent, newEvent)
-		if err == nil {
-			t.Error("expected error; got nil")
-		}
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, existing.ID.String()).Return(existing, nil).Once()
+		mockRepo.On("CountBookedSeats", existing.ID.String()).Return(0, nil).Once()
+		mockRepo.On("Save", event).Return(nil, assert.AnError).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.SaveEventService(tenant.Scope{}, event)
+
+		assert.Equal(t, assert.AnError, err)
 	})
 }
 
+func TestValidateEvent(t *testing.T) {
+	start := time.Now()
+	salesEnd := start.Add(3 * time.Hour)
+
+	tests := []struct {
+		name  string
+		event entity.Event
+		field string
+	}{
+		{"valid", entity.Event{StartDate: start, EndDate: start.Add(time.Hour), TotalSeat: 10, AvailableSeat: 10}, ""},
+		{"end before start", entity.Event{StartDate: start, EndDate: start.Add(-time.Hour), TotalSeat: 10}, "end_date"},
+		{"negative price", entity.Event{StartDate: start, EndDate: start.Add(time.Hour), Price: -1, TotalSeat: 10}, "price"},
+		{"no seats", entity.Event{StartDate: start, EndDate: start.Add(time.Hour)}, "total_seat"},
+		{"available above total", entity.Event{StartDate: start, EndDate: start.Add(time.Hour), TotalSeat: 10, AvailableSeat: 11}, "available_seat"},
+		{"sales end after event", entity.Event{StartDate: start, EndDate: start.Add(time.Hour), TotalSeat: 10, SalesEndAt: &salesEnd}, "sales_end_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEvent(&tt.event)
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+			assert.ErrorIs(t, err, ErrInvalidEvent)
+		})
+	}
+}
+
 func TestFindAllEvent(t *testing.T) {
 	mockRepo := mocks.NewRepository(t)
 
@@ -137,22 +255,23 @@ func TestFindAllEvent(t *testing.T) {
 	}
 
 	t.Run("find all event successfully", func(t *testing.T) {
-		mockRepo.On("FindAll").Return(mockEvents, nil).Once()
+		mockRepo.On("FindAll", tenant.Scope{}, query.Options{}).Return(mockEvents, int64(len(mockEvents)), nil).Once()
 
-		svc := NewService(mockRepo)
-		events, err := svc.FindAllEventService()
+		svc := NewService(mockRepo, nil, nil)
+		events, total, err := svc.FindAllEventService(tenant.Scope{}, query.Options{})
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
 		}
 
 		assert.Equal(t, mockEvents, events)
+		assert.Equal(t, int64(len(mockEvents)), total)
 	})
 
 	t.Run("find all event failed", func(t *testing.T) {
-		mockRepo.On("FindAll").Return(nil, assert.AnError).Once()
+		mockRepo.On("FindAll", tenant.Scope{}, query.Options{}).Return(nil, int64(0), assert.AnError).Once()
 
-		svc := NewService(mockRepo)
-		_, err := svc.FindAllEventService()
+		svc := NewService(mockRepo, nil, nil)
+		_, _, err := svc.FindAllEventService(tenant.Scope{}, query.Options{})
 		if err == nil {
 			t.Error("expected error; got nil")
 		}
@@ -177,10 +296,10 @@ func TestFindEvent(t *testing.T) {
 	}
 
 	t.Run("find event successfully", func(t *testing.T) {
-		mockRepo.On("Find", mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(mockEvent, nil).Once()
 
-		svc := NewService(mockRepo)
-		event, err := svc.FindEventService(mockEvent.ID.String())
+		svc := NewService(mockRepo, nil, nil)
+		event, err := svc.FindEventService(tenant.Scope{}, mockEvent.ID.String())
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
 		}
@@ -189,10 +308,10 @@ func TestFindEvent(t *testing.T) {
 	})
 
 	t.Run("find event failed", func(t *testing.T) {
-		mockRepo.On("Find", mockEvent.ID.String()).Return(nil, assert.AnError).Once()
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(nil, assert.AnError).Once()
 
-		svc := NewService(mockRepo)
-		_, err := svc.FindEventService(mockEvent.ID.String())
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.FindEventService(tenant.Scope{}, mockEvent.ID.String())
 		if err == nil {
 			t.Error("expected error; got nil")
 		}
@@ -201,40 +320,259 @@ func TestFindEvent(t *testing.T) {
 	})
 }
 
-func TestDeleteEvent(t *testing.T) {
+func TestRecountSeats(t *testing.T) {
 	mockRepo := mocks.NewRepository(t)
 
 	mockEvent := &entity.Event{
 		ID:            uuid.New(),
-		Name:          "Test Event",
-		Location:      "Test Location",
-		StartDate:     time.Now(),
-		EndDate:       time.Now().Add(time.Hour * 2),
-		Price:         100000,
 		TotalSeat:     100,
-		AvailableSeat: 100,
-		Category:      "Test Category",
+		AvailableSeat: 90,
 	}
 
-	t.Run("delete event successfully", func(t *testing.T) {
-		mockRepo.On("Delete", mockEvent.ID.String()).Return(nil).Once()
+	t.Run("recount seats successfully", func(t *testing.T) {
+		mockRepo.On("RecountSeats", mockEvent.ID.String()).Return(mockEvent, 85, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		event, previous, err := svc.RecountSeatsService(mockEvent.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockEvent, event)
+		assert.Equal(t, 85, previous)
+	})
+
+	t.Run("event not found", func(t *testing.T) {
+		mockRepo.On("RecountSeats", mockEvent.ID.String()).Return(nil, 0, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, _, err := svc.RecountSeatsService(mockEvent.ID.String())
+
+		assert.ErrorIs(t, err, ErrEventNotFound)
+	})
+}
+
+func TestCancelEvent(t *testing.T) {
+	newEvent := func() *entity.Event {
+		return &entity.Event{
+			ID:            uuid.New(),
+			Name:          "Test Event",
+			StartDate:     time.Now().Add(time.Hour * 24),
+			EndDate:       time.Now().Add(time.Hour * 26),
+			Price:         100,
+			TotalSeat:     100,
+			AvailableSeat: 98,
+			Status:        entity.EventStatusPublished,
+		}
+	}
+
+	t.Run("cancels bookings and notifies bookers", func(t *testing.T) {
+		mockEvent := newEvent()
+		booking := entity.Booking{
+			ID:         uuid.New(),
+			EventID:    mockEvent.ID,
+			Quantity:   2,
+			TotalPrice: 200,
+			Status:     entity.BookingStatusCancelled,
+			UserID:     uuid.New(),
+		}
+
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+		mockPublisher := mocks.NewEventPublisher(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockRepo.On("Cancel", mock.MatchedBy(func(e *entity.Event) bool {
+			return e.Status == entity.EventStatusCancelled && e.CancelledAt != nil && e.CancellationReason == "Storm"
+		})).Return([]entity.Booking{booking}, nil).Once()
+		mockPublisher.On("Publish", entity.WebhookEventBookingCancelled, mock.Anything).Once()
+		mockNotifier.On("Notify", notification.Notification{
+			Type:   notification.TypeEventCancelled,
+			UserID: booking.UserID,
+			Data:   notification.EventCancelledData{EventName: "Test Event", Reason: "Storm", Refund: 200},
+		}).Once()
+
+		svc := NewService(mockRepo, mockNotifier, mockPublisher)
+		event, err := svc.CancelEventService(tenant.Scope{}, mockEvent.ID.String(), "Storm")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.EventStatusCancelled, event.Lifecycle(time.Now()))
+	})
 
-		svc := NewService(mockRepo)
-		err := svc.DeleteEventService(mockEvent.ID.String())
+	t.Run("already cancelled", func(t *testing.T) {
+		mockEvent := newEvent()
+		mockEvent.Status = entity.EventStatusCancelled
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(mockEvent, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.CancelEventService(tenant.Scope{}, mockEvent.ID.String(), "")
+
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+	})
+
+	t.Run("completed event", func(t *testing.T) {
+		mockEvent := newEvent()
+		mockEvent.StartDate = time.Now().Add(-time.Hour * 3)
+		mockEvent.EndDate = time.Now().Add(-time.Hour)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(mockEvent, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.CancelEventService(tenant.Scope{}, mockEvent.ID.String(), "")
+
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+	})
+}
+
+func TestDeleteEvent(t *testing.T) {
+	mockEvent := &entity.Event{
+		ID:     uuid.New(),
+		Status: entity.EventStatusCancelled,
+	}
+
+	t.Run("deletes event without bookings", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockRepo.On("CountBookedSeats", mockEvent.ID.String()).Return(0, nil).Once()
+		mockRepo.On("Delete", mockEvent).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		event, err := svc.DeleteEventService(tenant.Scope{}, mockEvent.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockEvent, event)
+	})
+
+	t.Run("event with bookings", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockRepo.On("CountBookedSeats", mockEvent.ID.String()).Return(2, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.DeleteEventService(tenant.Scope{}, mockEvent.ID.String())
+
+		assert.ErrorIs(t, err, ErrEventHasBookings)
+	})
+
+	t.Run("event not found", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.DeleteEventService(tenant.Scope{}, mockEvent.ID.String())
+
+		assert.ErrorIs(t, err, ErrEventNotFound)
+	})
+}
+
+func TestPublishEvent(t *testing.T) {
+	t.Run("schedules publication", func(t *testing.T) {
+		publishAt := time.Now().Add(time.Hour)
+		mockEvent := &entity.Event{
+			ID:        uuid.New(),
+			StartDate: time.Now().Add(time.Hour * 24),
+			EndDate:   time.Now().Add(time.Hour * 26),
+			Status:    entity.EventStatusDraft,
+		}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(mockEvent, nil).Once()
+		mockRepo.On("Save", mockEvent).Return(mockEvent, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		event, err := svc.PublishEventService(tenant.Scope{}, mockEvent.ID.String(), &publishAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.EventStatusScheduled, event.Lifecycle(time.Now()))
+		assert.Equal(t, entity.EventStatusPublished, event.Lifecycle(publishAt))
+		assert.False(t, event.SalesOpen(time.Now()))
+	})
+
+	t.Run("unpublish with bookings", func(t *testing.T) {
+		mockEvent := &entity.Event{
+			ID:            uuid.New(),
+			TotalSeat:     10,
+			AvailableSeat: 8,
+			Status:        entity.EventStatusPublished,
+		}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", tenant.Scope{}, mockEvent.ID.String()).Return(mockEvent, nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, err := svc.UnpublishEventService(tenant.Scope{}, mockEvent.ID.String())
+
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+	})
+}
+
+func TestSearchEvent(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+
+	mockEvents := []entity.Event{
+		{
+			ID:            uuid.New(),
+			Name:          "Jazz Night",
+			Location:      "Jakarta",
+			StartDate:     time.Now().Add(time.Hour * 24),
+			EndDate:       time.Now().Add(time.Hour * 26),
+			Price:         150000,
+			TotalSeat:     100,
+			AvailableSeat: 100,
+			Category:      "Music",
+		},
+	}
+
+	t.Run("text search defaults to relevance", func(t *testing.T) {
+		search := entity.EventSearch{Query: "jazz"}
+		expectedOpts := query.Options{Limit: 20, Sort: []query.SortField{{Field: SortRelevance}}}
+		mockRepo.On("Search", search, expectedOpts).Return(mockEvents, int64(1), nil).Once()
+
+		svc := NewService(mockRepo, nil, nil)
+		events, total, err := svc.SearchEventService(search, query.Options{Limit: 20})
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
 		}
+
+		assert.Equal(t, mockEvents, events)
+		assert.Equal(t, int64(1), total)
 	})
 
-	t.Run("delete event failed", func(t *testing.T) {
-		mockRepo.On("Delete", mockEvent.ID.String()).Return(assert.AnError).Once()
+	t.Run("relevance without query falls back to start date", func(t *testing.T) {
+		search := entity.EventSearch{}
+		expectedOpts := query.Options{Limit: 20, Sort: []query.SortField{{Field: SortStartDate}}}
+		mockRepo.On("Search", search, expectedOpts).Return([]entity.Event{}, int64(0), nil).Once()
 
-		svc := NewService(mockRepo)
-		err := svc.DeleteEventService(mockEvent.ID.String())
-		if err == nil {
-			t.Error("expected error; got nil")
+		svc := NewService(mockRepo, nil, nil)
+		_, _, err := svc.SearchEventService(search, query.Options{Limit: 20, Sort: []query.SortField{{Field: SortRelevance}}})
+		if err != nil {
+			t.Errorf("expected error to be nil; got %v", err)
 		}
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		from := time.Now().Add(time.Hour * 48)
+		to := time.Now()
+
+		svc := NewService(mockRepo, nil, nil)
+		_, _, err := svc.SearchEventService(entity.EventSearch{StartFrom: &from, StartTo: &to}, query.Options{})
+		assert.ErrorIs(t, err, ErrInvalidSearchParams)
+	})
+
+	t.Run("invalid price range", func(t *testing.T) {
+		minPrice := 200.0
+		maxPrice := 100.0
+
+		svc := NewService(mockRepo, nil, nil)
+		_, _, err := svc.SearchEventService(entity.EventSearch{MinPrice: &minPrice, MaxPrice: &maxPrice}, query.Options{})
+		assert.ErrorIs(t, err, ErrInvalidSearchParams)
+	})
+
+	t.Run("search event failed", func(t *testing.T) {
+		mockRepo.On("Search", mock.Anything, mock.Anything).Return(nil, int64(0), assert.AnError).Once()
 
+		svc := NewService(mockRepo, nil, nil)
+		_, _, err := svc.SearchEventService(entity.EventSearch{}, query.Options{})
 		assert.Equal(t, assert.AnError, err)
 	})
 }
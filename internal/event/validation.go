@@ -0,0 +1,59 @@
+package event
+
+import (
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"fmt"
+)
+
+var (
+	// ErrInvalidEvent matches every ValidationError.
+	ErrInvalidEvent        = apperror.Validation("invalid_event", "invalid event")
+	ErrCapacityBelowBooked = apperror.Conflict("capacity_below_booked", "total_seat is below the seats already booked")
+)
+
+// ValidationError reports an event that breaks one of its invariants.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidEvent
+}
+
+// validateEvent checks the invariants every stored event has to satisfy.
+// Capacity is checked after AvailableSeat has been derived from bookings.
+func validateEvent(event *entity.Event) error {
+	if !event.EndDate.After(event.StartDate) {
+		return &ValidationError{Field: "end_date", Reason: "must be after start_date"}
+	}
+	if event.Price < 0 {
+		return &ValidationError{Field: "price", Reason: "must not be negative"}
+	}
+	if event.TotalSeat < 1 {
+		return &ValidationError{Field: "total_seat", Reason: "must be at least 1"}
+	}
+	if event.AvailableSeat < 0 || event.AvailableSeat > event.TotalSeat {
+		return &ValidationError{Field: "available_seat", Reason: "must be between 0 and total_seat"}
+	}
+
+	return validateSalesWindow(event)
+}
+
+// validateSalesWindow checks that ticket sales open before they close and
+// close no later than the end of the event.
+func validateSalesWindow(event *entity.Event) error {
+	if event.SalesStartAt != nil && event.SalesEndAt != nil && !event.SalesEndAt.After(*event.SalesStartAt) {
+		return &ValidationError{Field: "sales_end_at", Reason: "must be after sales_start_at"}
+	}
+	if event.SalesEndAt != nil && event.SalesEndAt.After(event.EndDate) {
+		return &ValidationError{Field: "sales_end_at", Reason: "must not be after end_date"}
+	}
+
+	return nil
+}
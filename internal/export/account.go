@@ -0,0 +1,198 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"event-booking/internal/api/query"
+	"event-booking/internal/audit"
+	"event-booking/internal/entity"
+	"event-booking/internal/notification"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+type ProfileDataExport struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	PendingEmail string     `json:"pending_email,omitempty"`
+	Role         string     `json:"role"`
+	IsVerified   bool       `json:"is_verified"`
+	DisabledAt   *time.Time `json:"disabled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type ReviewsDataExport struct {
+	ID        uuid.UUID  `json:"id"`
+	EventID   uuid.UUID  `json:"event_id"`
+	Rating    int        `json:"rating"`
+	Review    string     `json:"review"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// SessionsDataExport is a sign-in. Sessions are stateless JWT cookies, so
+// the sign-ins in the audit log are all that is stored about them.
+type SessionsDataExport struct {
+	SignedInAt time.Time `json:"signed_in_at"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+}
+
+// AccountDataExport is everything stored about a user, as sent to them by
+// ExportAccountService.
+type AccountDataExport struct {
+	Profile  ProfileDataExport
+	Bookings []BookingsDataExport
+	Reviews  []ReviewsDataExport
+	Sessions []SessionsDataExport
+}
+
+// ExportAccount collects the personal data of a user: the profile, every
+// booking and review whatever its status, deleted ones included, and the
+// sign-ins still in the audit log.
+func (s *Service) ExportAccount(userID string) (*AccountDataExport, error) {
+	user, err := s.UserRepository.FindByID(userID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	bookings, err := s.BookingRepository.FindByUserIDWithDeleted(userID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	reviews, err := s.ReviewRepository.FindByUserIDWithDeleted(userID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	signIns, _, err := s.AuditRepository.FindAll(entity.AuditSearch{}, query.Options{
+		Sort:    []query.SortField{{Field: "created_at", Desc: true}},
+		Filters: map[string]string{"actor_id": userID, "action": audit.ActionSignIn},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	data := &AccountDataExport{
+		Profile: ProfileDataExport{
+			ID:           user.ID,
+			Name:         user.Name,
+			Email:        user.Email,
+			PendingEmail: user.PendingEmail,
+			Role:         user.Role,
+			IsVerified:   user.IsVerified,
+			DisabledAt:   user.DisabledAt,
+			CreatedAt:    user.CreatedAt,
+			UpdatedAt:    user.UpdatedAt,
+		},
+		Bookings: newBookingsDataExport(bookings),
+		Reviews:  make([]ReviewsDataExport, 0, len(reviews)),
+		Sessions: make([]SessionsDataExport, 0, len(signIns)),
+	}
+
+	for _, review := range reviews {
+		data.Reviews = append(data.Reviews, ReviewsDataExport{
+			ID:        review.ID,
+			EventID:   review.EventID,
+			Rating:    review.Rating,
+			Review:    review.Review,
+			Status:    review.Status,
+			CreatedAt: review.CreatedAt,
+			UpdatedAt: review.UpdatedAt,
+			DeletedAt: deletedAt(review.DeletedAt),
+		})
+	}
+
+	for _, signIn := range signIns {
+		data.Sessions = append(data.Sessions, SessionsDataExport{
+			SignedInAt: signIn.CreatedAt,
+			IP:         signIn.IP,
+			UserAgent:  signIn.UserAgent,
+		})
+	}
+
+	return data, nil
+}
+
+// Zip writes the export as profile.json, bookings.json, reviews.json and
+// sessions.json in a ZIP archive.
+func (d *AccountDataExport) Zip() ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", d.Profile},
+		{"bookings.json", d.Bookings},
+		{"reviews.json", d.Reviews},
+		{"sessions.json", d.Sessions},
+	}
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ExportAccountService emails the user their personal data as a ZIP file.
+func (s *Service) ExportAccountService(userID string) error {
+	data, err := s.ExportAccount(userID)
+	if err != nil {
+		return err
+	}
+
+	content, err := data.Zip()
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	err = s.Notifier.SendTransactional(notification.Notification{
+		Type:   notification.TypeAccountExport,
+		UserID: data.Profile.ID,
+		Data: notification.AccountExportData{
+			Bookings: len(data.Bookings),
+			Reviews:  len(data.Reviews),
+			Sessions: len(data.Sessions),
+		},
+		Attachments: []entity.EmailAttachment{{
+			Filename:    fmt.Sprintf("personal-data-%s.zip", time.Now().Format("2006-01-02")),
+			ContentType: "application/zip",
+			Content:     content,
+		}},
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
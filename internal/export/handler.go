@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"event-booking/internal/api/responses"
+	"event-booking/internal/api/tenant"
+	"event-booking/internal/audit"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rabbitmq/amqp091-go"
@@ -13,24 +16,28 @@ import (
 type httpHandler struct {
 	svc        *Service
 	rabbitConn *amqp091.Connection
+	eraser     Eraser
+	audit      *audit.Service
 }
 
-func NewHttpHandler(svc *Service, rabittCon *amqp091.Connection) *httpHandler {
+func NewHttpHandler(svc *Service, rabittCon *amqp091.Connection, eraser Eraser, audit *audit.Service) *httpHandler {
 	return &httpHandler{
 		svc:        svc,
 		rabbitConn: rabittCon,
+		eraser:     eraser,
+		audit:      audit,
 	}
 }
 
 func (h *httpHandler) ExportAllEventHandler(c *fiber.Ctx) error {
-	eventsData, err := h.svc.ExportAllEvent()
+	eventsData, err := h.svc.ExportAllEvent(tenant.FromContext(c))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
 	ch, err := h.rabbitConn.Channel()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Error creating rabbitmq channel"))
+		return err
 	}
 	defer ch.Close()
 
@@ -43,7 +50,7 @@ func (h *httpHandler) ExportAllEventHandler(c *fiber.Ctx) error {
 		nil,
 	)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Error declaring queue"))
+		return err
 	}
 
 	jsonData, err := json.Marshal(eventsData)
@@ -61,19 +68,21 @@ func (h *httpHandler) ExportAllEventHandler(c *fiber.Ctx) error {
 		return err
 	}
 
+	h.audit.Record(audit.FromRequest(c, audit.ActionExportEvents, audit.TargetEvent, ""))
+
 	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Export event success"))
 }
 
 func (h *httpHandler) ExportBookingHandler(c *fiber.Ctx) error {
 	bookingID := c.Params("id")
-	bookings, err := h.svc.ExportAllBookingByUser(bookingID)
+	bookings, err := h.svc.ExportAllBookingByUser(tenant.FromContext(c), bookingID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Internal Server Error"))
+		return err
 	}
 
 	ch, err := h.rabbitConn.Channel()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Error creating rabbitmq channel"))
+		return err
 	}
 	defer ch.Close()
 
@@ -86,23 +95,95 @@ func (h *httpHandler) ExportBookingHandler(c *fiber.Ctx) error {
 		nil,
 	)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Error declaring queue"))
+		return err
 	}
 
 	jsonData, err := json.Marshal(bookings)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		return err
 	}
 
 	err = ch.PublishWithContext(context.Background(), "", queue.Name, false, false, amqp091.Publishing{
 		ContentType: "application/json",
 		Body:        jsonData,
 	})
-
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse("Error publishing message"))
+		return err
 	}
 
+	h.audit.Record(audit.FromRequest(c, audit.ActionExportBookings, audit.TargetUser, bookingID))
+
 	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Export booking success"))
 }
+
+func (h *httpHandler) publishAccountJob(job AccountJob) error {
+	ch, err := h.rabbitConn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	queue, err := declareAccountQueue(ch)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.Marshal(job)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	err = ch.PublishWithContext(context.Background(), "", queue.Name, false, false, amqp091.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp091.Persistent,
+		Body:         jsonData,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ExportAccountHandler queues an export of the signed-in user's personal
+// data, which is emailed to them as a ZIP file.
+func (h *httpHandler) ExportAccountHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+
+	if err := h.publishAccountJob(AccountJob{Type: JobExportAccount, UserID: userID}); err != nil {
+		return err
+	}
+
+	h.audit.Record(audit.FromRequest(c, audit.ActionAccountExport, audit.TargetUser, userID))
+
+	return c.Status(fiber.StatusAccepted).JSON(responses.NewSuccessResponse("Your data export will be emailed to you"))
+}
+
+// EraseAccountHandler queues the erasure of the signed-in user's account and
+// signs them out. Accounts with tickets for upcoming events are refused
+// right away.
+func (h *httpHandler) EraseAccountHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+
+	if _, err := h.eraser.CheckErasureService(userID); err != nil {
+		return err
+	}
+
+	if err := h.publishAccountJob(AccountJob{Type: JobEraseAccount, UserID: userID}); err != nil {
+		return err
+	}
+
+	h.audit.Record(audit.FromRequest(c, audit.ActionAccountErase, audit.TargetUser, userID))
+
+	c.Cookie(&fiber.Cookie{
+		Name:    "jwt",
+		Value:   "",
+		Expires: time.Now().Add(-time.Hour),
+	})
+
+	return c.Status(fiber.StatusAccepted).JSON(responses.NewSuccessResponse("Your account will be deleted, you will get a confirmation by email"))
+}
@@ -5,6 +5,10 @@ package mocks
 import (
 	entity "event-booking/internal/entity"
 
+	query "event-booking/internal/api/query"
+
+	tenant "event-booking/internal/api/tenant"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,14 +17,51 @@ type BookingRepository struct {
 	mock.Mock
 }
 
-// FindByUserID provides a mock function with given fields: userID
-func (_m *BookingRepository) FindByUserID(userID string) ([]entity.Booking, error) {
-	ret := _m.Called(userID)
+// FindByUserID provides a mock function with given fields: scope, userID, opts
+func (_m *BookingRepository) FindByUserID(scope tenant.Scope, userID string, opts query.Options) ([]entity.Booking, int64, error) {
+	ret := _m.Called(scope, userID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByUserID")
 	}
 
+	var r0 []entity.Booking
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string, query.Options) ([]entity.Booking, int64, error)); ok {
+		return rf(scope, userID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(tenant.Scope, string, query.Options) []entity.Booking); ok {
+		r0 = rf(scope, userID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(tenant.Scope, string, query.Options) int64); ok {
+		r1 = rf(scope, userID, opts)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(tenant.Scope, string, query.Options) error); ok {
+		r2 = rf(scope, userID, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByUserIDWithDeleted provides a mock function with given fields: userID
+func (_m *BookingRepository) FindByUserIDWithDeleted(userID string) ([]entity.Booking, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByUserIDWithDeleted")
+	}
+
 	var r0 []entity.Booking
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) ([]entity.Booking, error)); ok {
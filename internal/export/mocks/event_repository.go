@@ -5,6 +5,10 @@ package mocks
 import (
 	entity "event-booking/internal/entity"
 
+	query "event-booking/internal/api/query"
+
+	tenant "event-booking/internal/api/tenant"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,34 +17,41 @@ type EventRepository struct {
 	mock.Mock
 }
 
-// FindAll provides a mock function with given fields:
-func (_m *EventRepository) FindAll() ([]entity.Event, error) {
-	ret := _m.Called()
+// FindAll provides a mock function with given fields: scope, opts
+func (_m *EventRepository) FindAll(scope tenant.Scope, opts query.Options) ([]entity.Event, int64, error) {
+	ret := _m.Called(scope, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindAll")
 	}
 
 	var r0 []entity.Event
-	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]entity.Event, error)); ok {
-		return rf()
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(tenant.Scope, query.Options) ([]entity.Event, int64, error)); ok {
+		return rf(scope, opts)
 	}
-	if rf, ok := ret.Get(0).(func() []entity.Event); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(tenant.Scope, query.Options) []entity.Event); ok {
+		r0 = rf(scope, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(tenant.Scope, query.Options) int64); ok {
+		r1 = rf(scope, opts)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(tenant.Scope, query.Options) error); ok {
+		r2 = rf(scope, opts)
 	} else {
-		r1 = ret.Error(1)
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
 
 // NewEventRepository creates a new instance of EventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -1,32 +1,67 @@
 package export
 
 import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/entity"
+	"event-booking/internal/notification"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 //go:generate mockery --case snake --name EventRepository
 type EventRepository interface {
-	FindAll() ([]entity.Event, error)
+	FindAll(scope tenant.Scope, opts query.Options) ([]entity.Event, int64, error)
 }
 
 //go:generate mockery --case snake --name BookingRepository
 type BookingRepository interface {
-	FindByUserID(userID string) ([]entity.Booking, error)
+	FindByUserID(scope tenant.Scope, userID string, opts query.Options) ([]entity.Booking, int64, error)
+	FindByUserIDWithDeleted(userID string) ([]entity.Booking, error)
+}
+
+//go:generate mockery --case snake --name ReviewRepository
+type ReviewRepository interface {
+	FindByUserIDWithDeleted(userID string) ([]entity.Review, error)
+}
+
+//go:generate mockery --case snake --name UserRepository
+type UserRepository interface {
+	FindByID(id string) (*entity.User, error)
+}
+
+//go:generate mockery --case snake --name AuditRepository
+type AuditRepository interface {
+	FindAll(search entity.AuditSearch, opts query.Options) ([]entity.AuditEntry, int64, error)
+}
+
+// Notifier emails the personal data exports.
+//
+//go:generate mockery --case snake --name Notifier
+type Notifier interface {
+	SendTransactional(notification notification.Notification) error
 }
 
 type Service struct {
 	EventRepository   EventRepository
 	BookingRepository BookingRepository
+	ReviewRepository  ReviewRepository
+	UserRepository    UserRepository
+	AuditRepository   AuditRepository
+	Notifier          Notifier
 }
 
-func NewService(eventRepo EventRepository, bookingRepo BookingRepository) *Service {
+func NewService(eventRepo EventRepository, bookingRepo BookingRepository, reviewRepo ReviewRepository, userRepo UserRepository, auditRepo AuditRepository, notifier Notifier) *Service {
 	return &Service{
 		EventRepository:   eventRepo,
 		BookingRepository: bookingRepo,
+		ReviewRepository:  reviewRepo,
+		UserRepository:    userRepo,
+		AuditRepository:   auditRepo,
+		Notifier:          notifier,
 	}
 }
 
@@ -41,8 +76,11 @@ type EventsDataExport struct {
 	AvailableSeat int       `json:"available_seat"`
 }
 
-func (s *Service) ExportAllEvent() ([]EventsDataExport, error) {
-	events, err := s.EventRepository.FindAll()
+// ExportAllEvent exports every event in scope, so organizers only get their
+// own events.
+func (s *Service) ExportAllEvent(scope tenant.Scope) ([]EventsDataExport, error) {
+	// exports always cover every row, so no limit is applied
+	events, _, err := s.EventRepository.FindAll(scope, query.Options{})
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return []EventsDataExport{}, err
@@ -71,16 +109,25 @@ type BookingsDataExport struct {
 	EventID    uuid.UUID `json:"event_id"`
 	Quantity   int       `json:"quantity"`
 	TotalPrice float64   `json:"total_price"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+	// DeletedAt is only set on deleted bookings, which only the personal
+	// data export includes
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
-func (s *Service) ExportAllBookingByUser(userId string) ([]BookingsDataExport, error) {
-	bookings, err := s.BookingRepository.FindByUserID(userId)
+func (s *Service) ExportAllBookingByUser(scope tenant.Scope, userId string) ([]BookingsDataExport, error) {
+	bookings, _, err := s.BookingRepository.FindByUserID(scope, userId, query.Options{})
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return []BookingsDataExport{}, err
 	}
 
-	var bookingsData []BookingsDataExport
+	return newBookingsDataExport(bookings), nil
+}
+
+func newBookingsDataExport(bookings []entity.Booking) []BookingsDataExport {
+	bookingsData := make([]BookingsDataExport, 0, len(bookings))
 	for _, booking := range bookings {
 		bookingsData = append(bookingsData, BookingsDataExport{
 			ID:         booking.ID,
@@ -88,8 +135,19 @@ func (s *Service) ExportAllBookingByUser(userId string) ([]BookingsDataExport, e
 			EventID:    booking.EventID,
 			Quantity:   booking.Quantity,
 			TotalPrice: booking.TotalPrice,
+			Status:     booking.Status,
+			CreatedAt:  booking.CreatedAt,
+			DeletedAt:  deletedAt(booking.DeletedAt),
 		})
 	}
 
-	return bookingsData, nil
+	return bookingsData
+}
+
+func deletedAt(deleted gorm.DeletedAt) *time.Time {
+	if !deleted.Valid {
+		return nil
+	}
+
+	return &deleted.Time
 }
@@ -1,13 +1,23 @@
 package export
 
 import (
+	"archive/zip"
+	"bytes"
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
+	"event-booking/internal/audit"
 	"event-booking/internal/entity"
 	"event-booking/internal/export/mocks"
+	"event-booking/internal/notification"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 // this unit test got nill pointer error, need to work on rabbitmq connection testing
@@ -42,10 +52,10 @@ func TestExportAllEvent(t *testing.T) {
 	}
 
 	t.Run("export all event successfully", func(t *testing.T) {
-		mockEventRepo.On("FindAll").Return(mockEvents, nil).Once()
+		mockEventRepo.On("FindAll", tenant.Scope{}, query.Options{}).Return(mockEvents, int64(len(mockEvents)), nil).Once()
 
-		svc := NewService(mockEventRepo, mockBookingRepo)
-		eventsData, err := svc.ExportAllEvent()
+		svc := NewService(mockEventRepo, mockBookingRepo, nil, nil, nil, nil)
+		eventsData, err := svc.ExportAllEvent(tenant.Scope{})
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
 		}
@@ -54,10 +64,10 @@ func TestExportAllEvent(t *testing.T) {
 	})
 
 	t.Run("export all event failed", func(t *testing.T) {
-		mockEventRepo.On("FindAll").Return(nil, assert.AnError).Once()
+		mockEventRepo.On("FindAll", tenant.Scope{}, query.Options{}).Return(nil, int64(0), assert.AnError).Once()
 
-		svc := NewService(mockEventRepo, mockBookingRepo)
-		_, err := svc.ExportAllEvent()
+		svc := NewService(mockEventRepo, mockBookingRepo, nil, nil, nil, nil)
+		_, err := svc.ExportAllEvent(tenant.Scope{})
 		if err == nil {
 			t.Error("expected error; got nil")
 		}
@@ -79,10 +89,10 @@ func TestExportAllBookingByUserID(t *testing.T) {
 	}
 
 	t.Run("export booking by id successfully", func(t *testing.T) {
-		mockBookingRepository.On("FindByUserID", mockBooking.UserID.String()).Return([]entity.Booking{mockBooking}, nil).Once()
+		mockBookingRepository.On("FindByUserID", tenant.Scope{}, mockBooking.UserID.String(), query.Options{}).Return([]entity.Booking{mockBooking}, int64(1), nil).Once()
 
-		svc := NewService(mockEventRepository, mockBookingRepository)
-		bookings, err := svc.ExportAllBookingByUser(mockBooking.UserID.String())
+		svc := NewService(mockEventRepository, mockBookingRepository, nil, nil, nil, nil)
+		bookings, err := svc.ExportAllBookingByUser(tenant.Scope{}, mockBooking.UserID.String())
 		if err != nil {
 			t.Errorf("expected error to be nil; got %v", err)
 		}
@@ -91,14 +101,121 @@ func TestExportAllBookingByUserID(t *testing.T) {
 	})
 
 	t.Run("export booking by id failed", func(t *testing.T) {
-		mockBookingRepository.On("FindByUserID", mockBooking.UserID.String()).Return(nil, assert.AnError).Once()
+		mockBookingRepository.On("FindByUserID", tenant.Scope{}, mockBooking.UserID.String(), query.Options{}).Return(nil, int64(0), assert.AnError).Once()
 
-		svc := NewService(mockEventRepository, mockBookingRepository)
-		_, err := svc.ExportAllBookingByUser(mockBooking.UserID.String())
-		if err == nil {
-			t.Error("expected error; got nil")
+		svc := NewService(mockEventRepository, mockBookingRepository, nil, nil, nil, nil)
+		_, err := svc.ExportAllBookingByUser(tenant.Scope{}, mockBooking.UserID.String())
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestExportAccountService(t *testing.T) {
+	user := &entity.User{ID: uuid.New(), Name: "John Doe", Email: "johndoe@gmail.com", Role: "user", IsVerified: true}
+	userID := user.ID.String()
+	bookings := []entity.Booking{{ID: uuid.New(), UserID: user.ID, EventID: uuid.New(), Quantity: 2, TotalPrice: 50, Status: entity.BookingStatusConfirmed}}
+	reviews := []entity.Review{{ID: uuid.New(), UserID: user.ID, EventID: uuid.New(), Rating: 4, Review: "Great", Status: entity.ReviewStatusPending,
+		DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}}
+	signIns := []entity.AuditEntry{{Action: audit.ActionSignIn, IP: "10.0.0.1", UserAgent: "curl", CreatedAt: time.Now()}}
+	signInOpts := query.Options{
+		Sort:    []query.SortField{{Field: "created_at", Desc: true}},
+		Filters: map[string]string{"actor_id": userID, "action": audit.ActionSignIn},
+	}
+
+	t.Run("emails a zip of the personal data", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockBookingRepo := mocks.NewBookingRepository(t)
+		mockReviewRepo := mocks.NewReviewRepository(t)
+		mockAuditRepo := mocks.NewAuditRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+
+		mockUserRepo.On("FindByID", userID).Return(user, nil).Once()
+		mockBookingRepo.On("FindByUserIDWithDeleted", userID).Return(bookings, nil).Once()
+		mockReviewRepo.On("FindByUserIDWithDeleted", userID).Return(reviews, nil).Once()
+		mockAuditRepo.On("FindAll", entity.AuditSearch{}, signInOpts).Return(signIns, int64(1), nil).Once()
+
+		var sent notification.Notification
+		mockNotifier.On("SendTransactional", mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(0).(notification.Notification)
+		}).Return(nil).Once()
+
+		svc := NewService(nil, mockBookingRepo, mockReviewRepo, mockUserRepo, mockAuditRepo, mockNotifier)
+		err := svc.ExportAccountService(userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, notification.TypeAccountExport, sent.Type)
+		assert.Equal(t, user.ID, sent.UserID)
+		assert.Equal(t, notification.AccountExportData{Bookings: 1, Reviews: 1, Sessions: 1}, sent.Data)
+		require.Len(t, sent.Attachments, 1)
+		assert.Equal(t, "application/zip", sent.Attachments[0].ContentType)
+
+		content := sent.Attachments[0].Content
+		archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		require.NoError(t, err)
+
+		files := map[string]string{}
+		for _, file := range archive.File {
+			r, err := file.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			files[file.Name] = string(data)
 		}
 
-		assert.Nil(t, err)
+		assert.Len(t, files, 4)
+		assert.Contains(t, files["profile.json"], `"email": "johndoe@gmail.com"`)
+		assert.Contains(t, files["bookings.json"], `"status": "confirmed"`)
+		assert.Contains(t, files["reviews.json"], `"status": "pending"`)
+		assert.Contains(t, files["reviews.json"], `"deleted_at"`)
+		assert.NotContains(t, files["bookings.json"], `"deleted_at"`)
+		assert.Contains(t, files["sessions.json"], `"ip": "10.0.0.1"`)
+	})
+
+	t.Run("empty lists are arrays", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockBookingRepo := mocks.NewBookingRepository(t)
+		mockReviewRepo := mocks.NewReviewRepository(t)
+		mockAuditRepo := mocks.NewAuditRepository(t)
+
+		mockUserRepo.On("FindByID", userID).Return(user, nil).Once()
+		mockBookingRepo.On("FindByUserIDWithDeleted", userID).Return(nil, nil).Once()
+		mockReviewRepo.On("FindByUserIDWithDeleted", userID).Return(nil, nil).Once()
+		mockAuditRepo.On("FindAll", entity.AuditSearch{}, signInOpts).Return(nil, int64(0), nil).Once()
+
+		svc := NewService(nil, mockBookingRepo, mockReviewRepo, mockUserRepo, mockAuditRepo, nil)
+		data, err := svc.ExportAccount(userID)
+
+		require.NoError(t, err)
+		assert.NotNil(t, data.Bookings)
+		assert.NotNil(t, data.Reviews)
+		assert.NotNil(t, data.Sessions)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockUserRepo := mocks.NewUserRepository(t)
+		mockUserRepo.On("FindByID", userID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(nil, nil, nil, mockUserRepo, nil, nil)
+		err := svc.ExportAccountService(userID)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestWorkerHandle(t *testing.T) {
+	t.Run("erase job", func(t *testing.T) {
+		mockEraser := mocks.NewEraser(t)
+		mockEraser.On("EraseUserService", "user-1").Return(nil).Once()
+
+		worker := NewWorker(nil, nil, mockEraser)
+		err := worker.Handle(AccountJob{Type: JobEraseAccount, UserID: "user-1"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		worker := NewWorker(nil, nil, mocks.NewEraser(t))
+		err := worker.Handle(AccountJob{Type: "account.unknown", UserID: "user-1"})
+
+		assert.ErrorContains(t, err, "unknown account job")
 	})
 }
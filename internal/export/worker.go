@@ -0,0 +1,123 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"event-booking/internal/entity"
+	"fmt"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog/log"
+)
+
+// AccountQueue carries the personal data exports and account erasures users
+// ask for. Unlike the other export queues it is durable, so requests survive
+// a broker restart.
+const AccountQueue = "account_requests"
+
+const (
+	JobExportAccount = "account.export"
+	JobEraseAccount  = "account.erase"
+)
+
+// AccountJob is a message on AccountQueue.
+type AccountJob struct {
+	Type   string `json:"type"`
+	UserID string `json:"user_id"`
+}
+
+// Eraser anonymizes accounts, see account.Service.
+//
+//go:generate mockery --case snake --name Eraser
+type Eraser interface {
+	CheckErasureService(userID string) (*entity.User, error)
+	EraseUserService(userID string) error
+}
+
+func declareAccountQueue(ch *amqp091.Channel) (amqp091.Queue, error) {
+	return ch.QueueDeclare(
+		AccountQueue,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	)
+}
+
+// Worker runs the jobs on AccountQueue.
+type Worker struct {
+	conn   *amqp091.Connection
+	svc    *Service
+	eraser Eraser
+}
+
+func NewWorker(conn *amqp091.Connection, svc *Service, eraser Eraser) *Worker {
+	return &Worker{
+		conn:   conn,
+		svc:    svc,
+		eraser: eraser,
+	}
+}
+
+// Run handles jobs one at a time until ctx is done. A failed job is logged
+// and dropped; the user gets no email and can ask again.
+func (w *Worker) Run(ctx context.Context) {
+	ch, err := w.conn.Channel()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to open the account queue channel")
+		return
+	}
+	defer ch.Close()
+
+	if _, err := declareAccountQueue(ch); err != nil {
+		log.Error().Err(err).Msg("failed to declare the account queue")
+		return
+	}
+
+	if err := ch.Qos(1, 0, false); err != nil {
+		log.Error().Err(err).Msg("failed to set the account queue prefetch")
+		return
+	}
+
+	deliveries, err := ch.ConsumeWithContext(ctx, AccountQueue, "", false, false, false, false, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to consume the account queue")
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery, ok := <-deliveries:
+			if !ok {
+				log.Error().Msg("account queue consumer stopped")
+				return
+			}
+
+			var job AccountJob
+			if err := json.Unmarshal(delivery.Body, &job); err != nil {
+				log.Error().Err(err).Msg("failed to decode account job")
+			} else if err := w.Handle(job); err != nil {
+				log.Error().Err(err).Msgf("failed to run %s job for user %s", job.Type, job.UserID)
+			}
+
+			if err := delivery.Ack(false); err != nil {
+				log.Error().Err(err).Msg(err.Error())
+			}
+		}
+	}
+}
+
+// Handle runs a single job.
+func (w *Worker) Handle(job AccountJob) error {
+	switch job.Type {
+	case JobExportAccount:
+		return w.svc.ExportAccountService(job.UserID)
+	case JobEraseAccount:
+		return w.eraser.EraseUserService(job.UserID)
+	default:
+		return fmt.Errorf("unknown account job %q", job.Type)
+	}
+}
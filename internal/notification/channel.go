@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"event-booking/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// Channel delivers rendered notifications to a user.
+type Channel interface {
+	Name() string
+	Send(user *entity.User, notificationType string, message Message) error
+}
+
+// Mailer sends an email with an HTML body and a plain text alternative.
+type Mailer interface {
+	SendEmail(to, subject, htmlBody, textBody string, attachments ...entity.EmailAttachment) error
+}
+
+type EmailChannel struct {
+	mailer Mailer
+}
+
+func NewEmailChannel(mailer Mailer) *EmailChannel {
+	return &EmailChannel{mailer: mailer}
+}
+
+func (c *EmailChannel) Name() string {
+	return ChannelEmail
+}
+
+func (c *EmailChannel) Send(user *entity.User, _ string, message Message) error {
+	return c.mailer.SendEmail(user.Email, message.Subject, message.HTML, message.Text, message.Attachments...)
+}
+
+// InboxRepository stores in-app notifications.
+type InboxRepository interface {
+	CreateNotification(notification *entity.Notification) error
+}
+
+// InAppChannel puts notifications in the user's inbox, see
+// GET /api/notifications.
+type InAppChannel struct {
+	repo InboxRepository
+}
+
+func NewInAppChannel(repo InboxRepository) *InAppChannel {
+	return &InAppChannel{repo: repo}
+}
+
+func (c *InAppChannel) Name() string {
+	return ChannelInApp
+}
+
+func (c *InAppChannel) Send(user *entity.User, notificationType string, message Message) error {
+	return c.repo.CreateNotification(&entity.Notification{
+		UserID:  user.ID,
+		Type:    notificationType,
+		Subject: message.Subject,
+		Body:    message.Text,
+	})
+}
+
+// Publisher fans events out to the registered webhooks.
+type Publisher interface {
+	Publish(eventType string, data interface{})
+}
+
+// WebhookPayload is the data of a notification.sent webhook event.
+type WebhookPayload struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Email   string    `json:"email"`
+	Type    string    `json:"type"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+}
+
+// WebhookChannel forwards notifications to the webhooks subscribed to
+// notification.sent, e.g. an SMS or push gateway. Delivery and retries are
+// up to the webhook service.
+type WebhookChannel struct {
+	publisher Publisher
+}
+
+func NewWebhookChannel(publisher Publisher) *WebhookChannel {
+	return &WebhookChannel{publisher: publisher}
+}
+
+func (c *WebhookChannel) Name() string {
+	return ChannelWebhook
+}
+
+func (c *WebhookChannel) Send(user *entity.User, notificationType string, message Message) error {
+	c.publisher.Publish(entity.WebhookEventNotification, WebhookPayload{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Type:    notificationType,
+		Subject: message.Subject,
+		Body:    message.Text,
+	})
+
+	return nil
+}
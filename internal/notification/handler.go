@@ -0,0 +1,146 @@
+package notification
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/api/validator"
+	"event-booking/internal/auth"
+	"event-booking/internal/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	svc       *Service
+	validator *validator.Validator
+}
+
+func NewHttpHandler(svc *Service, validator *validator.Validator) *httpHandler {
+	return &httpHandler{
+		svc:       svc,
+		validator: validator,
+	}
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"created_at"},
+	Filters:     []string{"type"},
+	DefaultSort: "-created_at",
+}
+
+// FindNotificationsHandler lists the caller's inbox, newest first.
+func (h *httpHandler) FindNotificationsHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	userID, _ := c.Locals("userID").(string)
+	notifications, total, err := h.svc.FindNotificationsService(userID, opts)
+	if err != nil {
+		return err
+	}
+
+	notificationResponses := []responses.NotificationResponseObject{}
+	for _, notification := range notifications {
+		notificationResponses = append(notificationResponses, responses.NotificationResponseObject{
+			ID:        notification.ID,
+			Type:      notification.Type,
+			Subject:   notification.Subject,
+			Body:      notification.Body,
+			ReadAt:    notification.ReadAt,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Notifications found", notificationResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) MarkReadHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+	if err := h.svc.MarkReadService(userID, c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Notification marked as read", nil))
+}
+
+func (h *httpHandler) MarkAllReadHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+	count, err := h.svc.MarkAllReadService(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Notifications marked as read", fiber.Map{"count": count}))
+}
+
+func (h *httpHandler) FindPreferencesHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+	preferences, err := h.svc.FindPreferencesService(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Notification preferences found", newPreferenceResponses(preferences)))
+}
+
+type PreferencesPayload struct {
+	Preferences []PreferencePayload `json:"preferences" validate:"required,min=1,dive"`
+}
+
+type PreferencePayload struct {
+	Type    string `json:"type" validate:"required"`
+	Channel string `json:"channel" validate:"required"`
+	Enabled *bool  `json:"enabled" validate:"required"`
+}
+
+// SavePreferencesHandler opts the caller in or out of notification types
+// per channel.
+func (h *httpHandler) SavePreferencesHandler(c *fiber.Ctx) error {
+	id, _ := c.Locals("userID").(string)
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return auth.ErrUnauthorized
+	}
+
+	payload := new(PreferencesPayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	preferences := make([]entity.NotificationPreference, 0, len(payload.Preferences))
+	for _, preference := range payload.Preferences {
+		preferences = append(preferences, entity.NotificationPreference{
+			UserID:  userID,
+			Type:    preference.Type,
+			Channel: preference.Channel,
+			Enabled: *preference.Enabled,
+		})
+	}
+
+	saved, err := h.svc.SavePreferencesService(userID.String(), preferences)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Notification preferences saved", newPreferenceResponses(saved)))
+}
+
+func newPreferenceResponses(preferences []Preference) []responses.NotificationPreferenceResponseObject {
+	res := make([]responses.NotificationPreferenceResponseObject, 0, len(preferences))
+	for _, preference := range preferences {
+		res = append(res, responses.NotificationPreferenceResponseObject{
+			Type:    preference.Type,
+			Channel: preference.Channel,
+			Enabled: preference.Enabled,
+		})
+	}
+
+	return res
+}
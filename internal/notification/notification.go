@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"event-booking/internal/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TypeVerification     = "account.verification"
+	TypeAccountExport    = "account.export"
+	TypeAccountErased    = "account.erased"
+	TypeBookingConfirmed = "booking.confirmed"
+	TypeBookingCancelled = "booking.cancelled"
+	TypeEventChanged     = "event.changed"
+	TypeEventCancelled   = "event.cancelled"
+	TypeEventReminder    = "event.reminder"
+)
+
+const (
+	ChannelEmail   = "email"
+	ChannelInApp   = "in_app"
+	ChannelWebhook = "webhook"
+)
+
+// Types lists the notifications users can choose channels for.
+var Types = []string{TypeBookingConfirmed, TypeBookingCancelled, TypeEventChanged, TypeEventCancelled, TypeEventReminder}
+
+// TransactionalTypes always go out by email, see Service.SendTransactional.
+var TransactionalTypes = []string{TypeVerification, TypeAccountExport, TypeAccountErased}
+
+// Channels lists the channels in the order preferences are shown.
+var Channels = []string{ChannelEmail, ChannelInApp, ChannelWebhook}
+
+// defaults are the channels a user receives a type on until they change their
+// preferences. The webhook channel is opt-in.
+var defaults = map[string]bool{
+	ChannelEmail:   true,
+	ChannelInApp:   true,
+	ChannelWebhook: false,
+}
+
+// Notification is a message for one user. Data is passed to the templates of
+// Type as .Data, next to the recipient as .User.
+type Notification struct {
+	Type   string
+	UserID uuid.UUID
+	Data   any
+	// Attachments are only sent on the email channel
+	Attachments []entity.EmailAttachment
+	// To replaces the user's address for transactional emails, e.g. to reach
+	// an account whose address has just been erased
+	To string
+}
+
+// Message is a rendered notification. HTML is empty for text only templates.
+type Message struct {
+	Subject     string
+	HTML        string
+	Text        string
+	Attachments []entity.EmailAttachment
+}
+
+type VerificationData struct {
+	Code string
+}
+
+type AccountExportData struct {
+	Bookings int
+	Reviews  int
+	Sessions int
+}
+
+type AccountErasedData struct {
+	// Bookings is the number of bookings kept for accounting
+	Bookings int
+}
+
+type BookingData struct {
+	BookingID  uuid.UUID
+	EventName  string
+	Location   string
+	StartDate  time.Time
+	Quantity   int
+	TotalPrice float64
+}
+
+type EventChangedData struct {
+	EventName string
+	Location  string
+	StartDate time.Time
+	EndDate   time.Time
+	// Changes describes each changed field, e.g. "Start: ..."
+	Changes []string
+}
+
+type EventCancelledData struct {
+	EventName string
+	Reason    string
+	Refund    float64
+}
+
+type EventReminderData struct {
+	EventName string
+	Location  string
+	StartDate time.Time
+}
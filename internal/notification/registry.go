@@ -0,0 +1,136 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"event-booking/internal/entity"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var ErrUnknownType = errors.New("unknown notification type")
+
+// funcs are available to every template.
+var funcs = map[string]any{
+	"date": func(t time.Time) string { return t.Format("Mon, 02 Jan 2006 15:04 MST") },
+	"money": func(amount float64) string {
+		return fmt.Sprintf("%.2f", amount)
+	},
+}
+
+// Registry renders notifications from templates. Every type has a text
+// template, which also defines its "subject", and may have an HTML template;
+// the text version is the fallback for mail clients and the body everywhere
+// else.
+type Registry struct {
+	mu        sync.RWMutex
+	templates map[string]templateSet
+}
+
+type templateSet struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+type templateData struct {
+	User *entity.User
+	Data any
+}
+
+// NewRegistry loads the built-in templates from templates/<type>.txt and
+// templates/<type>.html, with the dots of the type replaced by underscores.
+func NewRegistry() (*Registry, error) {
+	r := &Registry{templates: make(map[string]templateSet)}
+
+	for _, notificationType := range append(TransactionalTypes, Types...) {
+		name := "templates/" + strings.ReplaceAll(notificationType, ".", "_")
+
+		text, err := fs.ReadFile(templateFS, name+".txt")
+		if err != nil {
+			return nil, err
+		}
+
+		html, err := fs.ReadFile(templateFS, name+".html")
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		if err := r.Register(notificationType, string(text), string(html)); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Register adds or replaces the templates of a notification type. html may
+// be empty to send the type as text only.
+func (r *Registry) Register(notificationType, text, html string) error {
+	set := templateSet{}
+
+	var err error
+	set.text, err = texttemplate.New(notificationType).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse text template of %s: %w", notificationType, err)
+	}
+	if set.text.Lookup("subject") == nil {
+		return fmt.Errorf("text template of %s does not define a subject", notificationType)
+	}
+
+	if html != "" {
+		set.html, err = htmltemplate.New(notificationType).Funcs(funcs).Option("missingkey=error").Parse(html)
+		if err != nil {
+			return fmt.Errorf("parse html template of %s: %w", notificationType, err)
+		}
+	}
+
+	r.mu.Lock()
+	r.templates[notificationType] = set
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Render renders the notification for user.
+func (r *Registry) Render(notificationType string, user *entity.User, data any) (Message, error) {
+	r.mu.RLock()
+	set, ok := r.templates[notificationType]
+	r.mu.RUnlock()
+	if !ok {
+		return Message{}, fmt.Errorf("%w: %s", ErrUnknownType, notificationType)
+	}
+
+	td := templateData{User: user, Data: data}
+
+	var subject, text bytes.Buffer
+	if err := set.text.ExecuteTemplate(&subject, "subject", td); err != nil {
+		return Message{}, err
+	}
+	if err := set.text.Execute(&text, td); err != nil {
+		return Message{}, err
+	}
+
+	message := Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()),
+	}
+
+	if set.html != nil {
+		var html bytes.Buffer
+		if err := set.html.Execute(&html, td); err != nil {
+			return Message{}, err
+		}
+		message.HTML = html.String()
+	}
+
+	return message, nil
+}
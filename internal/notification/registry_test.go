@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"event-booking/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	user := &entity.User{ID: uuid.New(), Name: "Jane <Doe>", Email: "jane@example.com"}
+	start := time.Date(2024, 11, 1, 19, 0, 0, 0, time.UTC)
+
+	t.Run("every built-in type renders", func(t *testing.T) {
+		data := map[string]any{
+			TypeVerification:     VerificationData{Code: "123456"},
+			TypeAccountExport:    AccountExportData{Bookings: 2, Reviews: 1, Sessions: 5},
+			TypeAccountErased:    AccountErasedData{Bookings: 2},
+			TypeBookingConfirmed: BookingData{BookingID: uuid.New(), EventName: "Jazz Night", Location: "Blue Hall", StartDate: start, Quantity: 2, TotalPrice: 50},
+			TypeBookingCancelled: BookingData{BookingID: uuid.New(), EventName: "Jazz Night", StartDate: start, Quantity: 2},
+			TypeEventChanged:     EventChangedData{EventName: "Jazz Night", Location: "Blue Hall", StartDate: start, EndDate: start.Add(2 * time.Hour), Changes: []string{"Location: Blue Hall (was Red Hall)"}},
+			TypeEventCancelled:   EventCancelledData{EventName: "Jazz Night", Refund: 50},
+			TypeEventReminder:    EventReminderData{EventName: "Jazz Night", Location: "Blue Hall", StartDate: start},
+		}
+
+		for notificationType, d := range data {
+			message, err := registry.Render(notificationType, user, d)
+
+			assert.NoError(t, err, notificationType)
+			assert.NotEmpty(t, message.Subject, notificationType)
+			assert.NotEmpty(t, message.Text, notificationType)
+			assert.NotEmpty(t, message.HTML, notificationType)
+		}
+	})
+
+	t.Run("html is escaped, text is not", func(t *testing.T) {
+		message, err := registry.Render(TypeEventCancelled, user, EventCancelledData{EventName: "Jazz Night", Reason: "Storm & flooding", Refund: 50})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Jazz Night has been cancelled", message.Subject)
+		assert.Contains(t, message.HTML, "Jane &lt;Doe&gt;")
+		assert.Contains(t, message.HTML, "Storm &amp; flooding")
+		assert.Contains(t, message.Text, "Hi Jane <Doe>,")
+		assert.Contains(t, message.Text, "A refund of 50.00")
+	})
+
+	t.Run("default reason", func(t *testing.T) {
+		message, err := registry.Render(TypeEventCancelled, user, EventCancelledData{EventName: "Jazz Night"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, message.Text, "No reason was given.")
+	})
+
+	t.Run("text only template", func(t *testing.T) {
+		r, err := NewRegistry()
+		require.NoError(t, err)
+		require.NoError(t, r.Register("custom", `{{define "subject"}}Hello {{.User.Name}}{{end}}Plain body`, ""))
+
+		message, err := r.Render("custom", user, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello Jane <Doe>", message.Subject)
+		assert.Equal(t, "Plain body", message.Text)
+		assert.Empty(t, message.HTML)
+	})
+
+	t.Run("template without subject", func(t *testing.T) {
+		r, err := NewRegistry()
+		require.NoError(t, err)
+
+		assert.Error(t, r.Register("custom", "body", ""))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := registry.Render("unknown", user, nil)
+
+		assert.ErrorIs(t, err, ErrUnknownType)
+	})
+
+	t.Run("missing data fails instead of rendering blanks", func(t *testing.T) {
+		_, err := registry.Render(TypeVerification, user, map[string]string{})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+func (r *repo) FindUser(id string) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *repo) FindPreferences(userID string) ([]entity.NotificationPreference, error) {
+	var preferences []entity.NotificationPreference
+	if err := r.db.Where("user_id = ?", userID).Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (r *repo) SavePreferences(preferences []entity.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&preferences).Error
+}
+
+func (r *repo) CreateNotification(notification *entity.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *repo) FindNotifications(userID string, opts query.Options) ([]entity.Notification, int64, error) {
+	db := r.db.Model(&entity.Notification{}).Where("user_id = ?", userID).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []entity.Notification
+	if err := db.Scopes(opts.Paginate).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, total, nil
+}
+
+func (r *repo) MarkRead(userID, id string) error {
+	var notification entity.Notification
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		return err
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+
+	return r.db.Model(&notification).Update("read_at", time.Now()).Error
+}
+
+func (r *repo) MarkAllRead(userID string) (int64, error) {
+	res := r.db.Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,235 @@
+package notification
+
+import (
+	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"fmt"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	FindUser(id string) (*entity.User, error)
+	FindPreferences(userID string) ([]entity.NotificationPreference, error)
+	SavePreferences(preferences []entity.NotificationPreference) error
+	CreateNotification(notification *entity.Notification) error
+	FindNotifications(userID string, opts query.Options) ([]entity.Notification, int64, error)
+	MarkRead(userID, id string) error
+	MarkAllRead(userID string) (int64, error)
+}
+
+var (
+	ErrNotificationNotFound = apperror.NotFound("notification_not_found", "notification not found")
+	ErrInvalidPreference    = apperror.Validation("invalid_preference", "unknown notification type or channel")
+	ErrNoEmailChannel       = errors.New("email channel is not configured")
+)
+
+// Preference is whether a user receives a type of notification on a channel.
+type Preference struct {
+	Type    string
+	Channel string
+	Enabled bool
+}
+
+type Service struct {
+	repo     Repository
+	registry *Registry
+	channels []Channel
+}
+
+func NewService(repo Repository, registry *Registry, channels ...Channel) *Service {
+	return &Service{
+		repo:     repo,
+		registry: registry,
+		channels: channels,
+	}
+}
+
+// Notify delivers the notification in the background on every channel the
+// user has enabled for its type, so requests never wait for an SMTP server.
+func (s *Service) Notify(notification Notification) {
+	go func() {
+		if err := s.Deliver(notification); err != nil {
+			log.Error().Err(err).Msgf("failed to deliver %s notification to user %s", notification.Type, notification.UserID)
+		}
+	}()
+}
+
+// Deliver is Notify without the goroutine, for background jobs that have to
+// know whether the notification went out.
+func (s *Service) Deliver(notification Notification) error {
+	user, err := s.repo.FindUser(notification.UserID.String())
+	if err != nil {
+		return err
+	}
+
+	message, err := s.registry.Render(notification.Type, user, notification.Data)
+	if err != nil {
+		return err
+	}
+	message.Attachments = notification.Attachments
+
+	enabled, err := s.enabledChannels(user.ID.String(), notification.Type)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, channel := range s.channels {
+		if !enabled[channel.Name()] {
+			continue
+		}
+		if err := channel.Send(user, notification.Type, message); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SendTransactional emails a notification right away regardless of the
+// user's preferences, for messages such as verification codes that the
+// caller has to know were sent. It goes to notification.To when set.
+func (s *Service) SendTransactional(notification Notification) error {
+	user, err := s.repo.FindUser(notification.UserID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	message, err := s.registry.Render(notification.Type, user, notification.Data)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+	message.Attachments = notification.Attachments
+
+	if notification.To != "" {
+		recipient := *user
+		recipient.Email = notification.To
+		user = &recipient
+	}
+
+	for _, channel := range s.channels {
+		if channel.Name() == ChannelEmail {
+			return channel.Send(user, notification.Type, message)
+		}
+	}
+
+	return ErrNoEmailChannel
+}
+
+// enabledChannels applies the user's preferences for a type over the
+// channel defaults.
+func (s *Service) enabledChannels(userID, notificationType string) (map[string]bool, error) {
+	preferences, err := s.repo.FindPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool, len(defaults))
+	for channel, on := range defaults {
+		enabled[channel] = on
+	}
+	for _, preference := range preferences {
+		if preference.Type == notificationType {
+			enabled[preference.Channel] = preference.Enabled
+		}
+	}
+
+	return enabled, nil
+}
+
+// FindPreferencesService returns the user's setting for every type on every
+// configured channel.
+func (s *Service) FindPreferencesService(userID string) ([]Preference, error) {
+	stored, err := s.repo.FindPreferences(userID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	overrides := make(map[[2]string]bool, len(stored))
+	for _, preference := range stored {
+		overrides[[2]string{preference.Type, preference.Channel}] = preference.Enabled
+	}
+
+	var preferences []Preference
+	for _, notificationType := range Types {
+		for _, channel := range s.channelNames() {
+			enabled, ok := overrides[[2]string{notificationType, channel}]
+			if !ok {
+				enabled = defaults[channel]
+			}
+			preferences = append(preferences, Preference{Type: notificationType, Channel: channel, Enabled: enabled})
+		}
+	}
+
+	return preferences, nil
+}
+
+// SavePreferencesService stores the given settings; types and channels that
+// are left out keep their current setting.
+func (s *Service) SavePreferencesService(userID string, preferences []entity.NotificationPreference) ([]Preference, error) {
+	channels := s.channelNames()
+	for _, preference := range preferences {
+		if !slices.Contains(Types, preference.Type) || !slices.Contains(channels, preference.Channel) {
+			return nil, fmt.Errorf("%w: %s on %s", ErrInvalidPreference, preference.Type, preference.Channel)
+		}
+	}
+
+	if err := s.repo.SavePreferences(preferences); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return s.FindPreferencesService(userID)
+}
+
+func (s *Service) channelNames() []string {
+	var names []string
+	for _, name := range Channels {
+		for _, channel := range s.channels {
+			if channel.Name() == name {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+
+	return names
+}
+
+func (s *Service) FindNotificationsService(userID string, opts query.Options) ([]entity.Notification, int64, error) {
+	notifications, total, err := s.repo.FindNotifications(userID, opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return notifications, total, nil
+}
+
+func (s *Service) MarkReadService(userID, id string) error {
+	err := s.repo.MarkRead(userID, id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return apperror.IfNotFound(err, ErrNotificationNotFound)
+	}
+
+	return nil
+}
+
+func (s *Service) MarkAllReadService(userID string) (int64, error) {
+	count, err := s.repo.MarkAllRead(userID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,166 @@
+package notification
+
+import (
+	"errors"
+	"event-booking/internal/entity"
+	"event-booking/internal/notification/mocks"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingChannel remembers what it was asked to send.
+type recordingChannel struct {
+	name string
+	err  error
+
+	mu         sync.Mutex
+	sent       []Message
+	recipients []string
+}
+
+func (c *recordingChannel) Name() string {
+	return c.name
+}
+
+func (c *recordingChannel) Send(user *entity.User, _ string, message Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, message)
+	c.recipients = append(c.recipients, user.Email)
+	return c.err
+}
+
+func TestDeliver(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	user := &entity.User{ID: uuid.New(), Name: "Jane", Email: "jane@example.com"}
+	n := Notification{Type: TypeEventReminder, UserID: user.ID, Data: EventReminderData{EventName: "Jazz Night"}}
+
+	t.Run("defaults", func(t *testing.T) {
+		email, inApp, webhook := &recordingChannel{name: ChannelEmail}, &recordingChannel{name: ChannelInApp}, &recordingChannel{name: ChannelWebhook}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindUser", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("FindPreferences", user.ID.String()).Return(nil, nil).Once()
+
+		svc := NewService(mockRepo, registry, email, inApp, webhook)
+		err := svc.Deliver(n)
+
+		assert.NoError(t, err)
+		assert.Len(t, email.sent, 1)
+		assert.Len(t, inApp.sent, 1)
+		assert.Empty(t, webhook.sent)
+	})
+
+	t.Run("preferences override defaults for their type only", func(t *testing.T) {
+		email, webhook := &recordingChannel{name: ChannelEmail}, &recordingChannel{name: ChannelWebhook}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindUser", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("FindPreferences", user.ID.String()).Return([]entity.NotificationPreference{
+			{UserID: user.ID, Type: TypeEventReminder, Channel: ChannelEmail, Enabled: false},
+			{UserID: user.ID, Type: TypeEventReminder, Channel: ChannelWebhook, Enabled: true},
+			{UserID: user.ID, Type: TypeBookingConfirmed, Channel: ChannelEmail, Enabled: true},
+		}, nil).Once()
+
+		svc := NewService(mockRepo, registry, email, webhook)
+		err := svc.Deliver(n)
+
+		assert.NoError(t, err)
+		assert.Empty(t, email.sent)
+		assert.Len(t, webhook.sent, 1)
+	})
+
+	t.Run("a failing channel does not stop the others", func(t *testing.T) {
+		email, inApp := &recordingChannel{name: ChannelEmail, err: errors.New("smtp down")}, &recordingChannel{name: ChannelInApp}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindUser", user.ID.String()).Return(user, nil).Once()
+		mockRepo.On("FindPreferences", user.ID.String()).Return(nil, nil).Once()
+
+		svc := NewService(mockRepo, registry, email, inApp)
+		err := svc.Deliver(n)
+
+		assert.ErrorContains(t, err, "smtp down")
+		assert.Len(t, inApp.sent, 1)
+	})
+}
+
+func TestSendTransactional(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	user := &entity.User{ID: uuid.New(), Email: "jane@example.com"}
+	email, inApp := &recordingChannel{name: ChannelEmail}, &recordingChannel{name: ChannelInApp}
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FindUser", user.ID.String()).Return(user, nil).Once()
+
+	svc := NewService(mockRepo, registry, inApp, email)
+	err = svc.SendTransactional(Notification{Type: TypeVerification, UserID: user.ID, Data: VerificationData{Code: "123456"}})
+
+	assert.NoError(t, err)
+	require.Len(t, email.sent, 1)
+	assert.Contains(t, email.sent[0].Text, "123456")
+	assert.Equal(t, []string{"jane@example.com"}, email.recipients)
+	assert.Empty(t, inApp.sent)
+
+	t.Run("to replaces the address", func(t *testing.T) {
+		erased := &entity.User{ID: uuid.New(), Name: "Deleted user", Email: "erased@example.invalid"}
+		email := &recordingChannel{name: ChannelEmail}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindUser", erased.ID.String()).Return(erased, nil).Once()
+
+		svc := NewService(mockRepo, registry, email)
+		err := svc.SendTransactional(Notification{Type: TypeAccountErased, UserID: erased.ID, Data: AccountErasedData{Bookings: 1}, To: "jane@example.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"jane@example.com"}, email.recipients)
+		assert.Equal(t, "erased@example.invalid", erased.Email)
+	})
+}
+
+func TestPreferencesService(t *testing.T) {
+	userID := uuid.New()
+	email, inApp := &recordingChannel{name: ChannelEmail}, &recordingChannel{name: ChannelInApp}
+
+	t.Run("matrix of configured channels", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindPreferences", userID.String()).Return([]entity.NotificationPreference{
+			{UserID: userID, Type: TypeEventReminder, Channel: ChannelEmail, Enabled: false},
+		}, nil).Once()
+
+		svc := NewService(mockRepo, nil, inApp, email)
+		preferences, err := svc.FindPreferencesService(userID.String())
+
+		assert.NoError(t, err)
+		assert.Len(t, preferences, len(Types)*2)
+		assert.Contains(t, preferences, Preference{Type: TypeEventReminder, Channel: ChannelEmail, Enabled: false})
+		assert.Contains(t, preferences, Preference{Type: TypeEventReminder, Channel: ChannelInApp, Enabled: true})
+		assert.Equal(t, ChannelEmail, preferences[0].Channel)
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		svc := NewService(mocks.NewRepository(t), nil, inApp, email)
+		_, err := svc.SavePreferencesService(userID.String(), []entity.NotificationPreference{
+			{UserID: userID, Type: TypeEventReminder, Channel: ChannelWebhook, Enabled: true},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidPreference)
+	})
+
+	t.Run("transactional types cannot be turned off", func(t *testing.T) {
+		svc := NewService(mocks.NewRepository(t), nil, inApp, email)
+		_, err := svc.SavePreferencesService(userID.String(), []entity.NotificationPreference{
+			{UserID: userID, Type: TypeVerification, Channel: ChannelEmail, Enabled: false},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidPreference)
+	})
+}
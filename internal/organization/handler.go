@@ -0,0 +1,175 @@
+package organization
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/api/validator"
+	"event-booking/internal/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	svc       *Service
+	validator *validator.Validator
+}
+
+func NewHttpHandler(svc *Service, validator *validator.Validator) *httpHandler {
+	return &httpHandler{
+		svc:       svc,
+		validator: validator,
+	}
+}
+
+type OrganizationInputPayload struct {
+	Name string `json:"name" validate:"required,min=3,max=100"`
+}
+
+func (h *httpHandler) CreateOrganizationHandler(c *fiber.Ctx) error {
+	payload := new(OrganizationInputPayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	organization, err := h.svc.CreateOrganizationService(&entity.Organization{Name: payload.Name})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Organization created successfully", newOrganizationResponse(organization)))
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"name", "created_at"},
+	DefaultSort: "name",
+}
+
+func (h *httpHandler) FindAllOrganizationHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	organizations, total, err := h.svc.FindAllOrganizationService(opts)
+	if err != nil {
+		return err
+	}
+
+	organizationResponses := []responses.OrganizationResponseObject{}
+	for _, organization := range organizations {
+		organizationResponses = append(organizationResponses, newOrganizationResponse(&organization))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Organizations found", organizationResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) FindOrganizationHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	organization, err := h.svc.FindOrganizationService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Organization found", newOrganizationResponse(organization)))
+}
+
+// FindOwnOrganizationHandler returns the organization of the calling organizer.
+func (h *httpHandler) FindOwnOrganizationHandler(c *fiber.Ctx) error {
+	id, ok := c.Locals("organizationID").(string)
+	if !ok {
+		return ErrOrganizationNotFound
+	}
+
+	organization, err := h.svc.FindOrganizationService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Organization found", newOrganizationResponse(organization)))
+}
+
+func (h *httpHandler) DeleteOrganizationHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	err := h.svc.DeleteOrganizationService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Organization deleted successfully"))
+}
+
+type MemberInputPayload struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Role   string    `json:"role" validate:"omitempty,oneof=owner member"`
+}
+
+func (h *httpHandler) AddMemberHandler(c *fiber.Ctx) error {
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return ErrOrganizationNotFound
+	}
+
+	payload := new(MemberInputPayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	member := &entity.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         payload.UserID,
+		Role:           payload.Role,
+	}
+	if member.Role == "" {
+		member.Role = entity.OrganizationRoleMember
+	}
+
+	member, err = h.svc.AddMemberService(member)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Member added successfully", responses.OrganizationMemberResponseObject{
+		UserID:    member.UserID,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}))
+}
+
+func (h *httpHandler) RemoveMemberHandler(c *fiber.Ctx) error {
+	err := h.svc.RemoveMemberService(c.Params("id"), c.Params("userId"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Member removed successfully"))
+}
+
+func newOrganizationResponse(organization *entity.Organization) responses.OrganizationResponseObject {
+	res := responses.OrganizationResponseObject{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedAt: organization.CreatedAt,
+		UpdatedAt: organization.UpdatedAt,
+	}
+
+	for _, member := range organization.Members {
+		res.Members = append(res.Members, responses.OrganizationMemberResponseObject{
+			UserID:    member.UserID,
+			Name:      member.User.Name,
+			Email:     member.User.Email,
+			Role:      member.Role,
+			CreatedAt: member.CreatedAt,
+		})
+	}
+
+	return res
+}
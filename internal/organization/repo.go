@@ -0,0 +1,93 @@
+package organization
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+func (r *repo) Create(organization *entity.Organization) (*entity.Organization, error) {
+	if err := r.db.Create(organization).Error; err != nil {
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (r *repo) FindAll(opts query.Options) ([]entity.Organization, int64, error) {
+	db := r.db.Model(&entity.Organization{}).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var organizations []entity.Organization
+	if err := db.Scopes(opts.Paginate).Find(&organizations).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return organizations, total, nil
+}
+
+// Find returns the organization with its members and their users.
+func (r *repo) Find(id string) (*entity.Organization, error) {
+	var organization entity.Organization
+	err := r.db.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at")
+	}).Preload("Members.User").Where("id = ?", id).First(&organization).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &organization, nil
+}
+
+func (r *repo) Delete(id string) error {
+	err := r.db.Where("id = ?", id).First(&entity.Organization{}).Delete(&entity.Organization{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repo) AddMember(member *entity.OrganizationMember) (*entity.OrganizationMember, error) {
+	if err := r.db.Create(member).Error; err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (r *repo) RemoveMember(organizationID, userID string) error {
+	res := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&entity.OrganizationMember{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) FindMemberByUserID(userID string) (*entity.OrganizationMember, error) {
+	var member entity.OrganizationMember
+	if err := r.db.Where("user_id = ?", userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
@@ -0,0 +1,124 @@
+package organization
+
+import (
+	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOrganizationNotFound = apperror.NotFound("organization_not_found", "organization not found")
+	ErrOrganizationExists   = apperror.Conflict("organization_exists", "organization name already exists")
+	ErrOrganizationInUse    = apperror.Conflict("organization_in_use", "organization still has events")
+	ErrMemberNotFound       = apperror.NotFound("member_not_found", "member not found")
+	ErrAlreadyMember        = apperror.Conflict("already_member", "user already belongs to an organization")
+	ErrUserNotFound         = apperror.Validation("unknown_user", "user does not exist")
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	Create(organization *entity.Organization) (*entity.Organization, error)
+	FindAll(opts query.Options) ([]entity.Organization, int64, error)
+	Find(id string) (*entity.Organization, error)
+	Delete(id string) error
+	AddMember(member *entity.OrganizationMember) (*entity.OrganizationMember, error)
+	RemoveMember(organizationID, userID string) error
+	FindMemberByUserID(userID string) (*entity.OrganizationMember, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+func (s *Service) CreateOrganizationService(organization *entity.Organization) (*entity.Organization, error) {
+	organization, err := s.repo.Create(organization)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrOrganizationExists
+		}
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (s *Service) FindAllOrganizationService(opts query.Options) ([]entity.Organization, int64, error) {
+	organizations, total, err := s.repo.FindAll(opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return organizations, total, nil
+}
+
+func (s *Service) FindOrganizationService(id string) (*entity.Organization, error) {
+	organization, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrOrganizationNotFound)
+	}
+
+	return organization, nil
+}
+
+func (s *Service) DeleteOrganizationService(id string) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrOrganizationInUse
+		}
+		return apperror.IfNotFound(err, ErrOrganizationNotFound)
+	}
+
+	return nil
+}
+
+// AddMemberService makes the user an organizer of the organization. A user
+// organizes for at most one organization.
+func (s *Service) AddMemberService(member *entity.OrganizationMember) (*entity.OrganizationMember, error) {
+	if _, err := s.repo.Find(member.OrganizationID.String()); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrOrganizationNotFound)
+	}
+
+	member, err := s.repo.AddMember(member)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAlreadyMember
+		} else if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *Service) RemoveMemberService(organizationID, userID string) error {
+	err := s.repo.RemoveMember(organizationID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return apperror.IfNotFound(err, ErrMemberNotFound)
+	}
+
+	return nil
+}
+
+// FindMemberByUserID backs the organizer middleware.
+func (s *Service) FindMemberByUserID(userID string) (*entity.OrganizationMember, error) {
+	return s.repo.FindMemberByUserID(userID)
+}
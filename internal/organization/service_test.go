@@ -0,0 +1,95 @@
+package organization
+
+import (
+	"event-booking/internal/entity"
+	"event-booking/internal/organization/mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAddMemberService(t *testing.T) {
+	organization := &entity.Organization{ID: uuid.New(), Name: "Acme Events"}
+
+	newMember := func() *entity.OrganizationMember {
+		return &entity.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         uuid.New(),
+			Role:           entity.OrganizationRoleMember,
+		}
+	}
+
+	t.Run("add member successfully", func(t *testing.T) {
+		member := newMember()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", organization.ID.String()).Return(organization, nil).Once()
+		mockRepo.On("AddMember", member).Return(member, nil).Once()
+
+		svc := NewService(mockRepo)
+		added, err := svc.AddMemberService(member)
+
+		assert.NoError(t, err)
+		assert.Equal(t, member, added)
+	})
+
+	t.Run("organization not found", func(t *testing.T) {
+		member := newMember()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", organization.ID.String()).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo)
+		_, err := svc.AddMemberService(member)
+
+		assert.ErrorIs(t, err, ErrOrganizationNotFound)
+	})
+
+	t.Run("user already organizes for an organization", func(t *testing.T) {
+		member := newMember()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", organization.ID.String()).Return(organization, nil).Once()
+		mockRepo.On("AddMember", member).Return(nil, gorm.ErrDuplicatedKey).Once()
+
+		svc := NewService(mockRepo)
+		_, err := svc.AddMemberService(member)
+
+		assert.ErrorIs(t, err, ErrAlreadyMember)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		member := newMember()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", organization.ID.String()).Return(organization, nil).Once()
+		mockRepo.On("AddMember", member).Return(nil, gorm.ErrForeignKeyViolated).Once()
+
+		svc := NewService(mockRepo)
+		_, err := svc.AddMemberService(member)
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestDeleteOrganizationService(t *testing.T) {
+	id := uuid.NewString()
+
+	t.Run("delete organization successfully", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Delete", id).Return(nil).Once()
+
+		svc := NewService(mockRepo)
+		assert.NoError(t, svc.DeleteOrganizationService(id))
+	})
+
+	t.Run("organization with events", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Delete", id).Return(gorm.ErrForeignKeyViolated).Once()
+
+		svc := NewService(mockRepo)
+		assert.ErrorIs(t, svc.DeleteOrganizationService(id), ErrOrganizationInUse)
+	})
+}
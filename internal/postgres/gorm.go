@@ -13,6 +13,8 @@ import (
 func NewGORM(c config.Database) *gorm.DB {
 	db, err := gorm.Open(postgres.Open(c.DataSourceName()), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
+		// surface constraint violations as gorm.ErrDuplicatedKey / gorm.ErrForeignKeyViolated
+		TranslateError: true,
 	})
 
 	if err != nil {
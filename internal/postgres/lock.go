@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"gorm.io/gorm"
+)
+
+// WithAdvisoryLock runs fn on a single connection while holding the
+// session-level advisory lock called name, so only one process at a time runs
+// it across replicas. It returns false without running fn when another
+// session holds the lock.
+func WithAdvisoryLock(db *gorm.DB, name string, fn func(conn *gorm.DB) error) (bool, error) {
+	var acquired bool
+	err := db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Raw("SELECT pg_try_advisory_lock(hashtext(?))", name).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(hashtext(?))", name)
+
+		return fn(conn)
+	})
+
+	return acquired, err
+}
+
+// WaitAdvisoryLock is WithAdvisoryLock for work that has to run: it waits
+// for the session holding the lock instead of giving up.
+func WaitAdvisoryLock(db *gorm.DB, name string, fn func(conn *gorm.DB) error) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(hashtext(?))", name).Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(hashtext(?))", name)
+
+		return fn(conn)
+	})
+}
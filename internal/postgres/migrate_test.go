@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/20240201000000_add_tags.up.sql":         {Data: []byte("ALTER TABLE events ADD tags text;")},
+			"migrations/20240201000000_add_tags.down.sql":       {Data: []byte("ALTER TABLE events DROP tags;")},
+			"migrations/20240101000000_initial_schema.up.sql":   {Data: []byte("CREATE TABLE events ();")},
+			"migrations/20240301000000_backfill_tags.up.sql":    {Data: []byte("UPDATE events SET tags = '';")},
+			"migrations/20240101000000_initial_schema.down.sql": {Data: []byte("DROP TABLE events;")},
+		}
+
+		migrations, err := LoadMigrations(fsys, "migrations")
+
+		require.NoError(t, err)
+		require.Len(t, migrations, 3)
+		assert.Equal(t, "20240101000000_initial_schema", migrations[0].String())
+		assert.Equal(t, "ALTER TABLE events ADD tags text;", migrations[1].Up)
+		assert.Equal(t, "ALTER TABLE events DROP tags;", migrations[1].Down)
+		assert.Empty(t, migrations[2].Down)
+	})
+
+	t.Run("down without up", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/20240101000000_initial_schema.down.sql": {Data: []byte("DROP TABLE events;")},
+		}
+
+		_, err := LoadMigrations(fsys, "migrations")
+
+		assert.ErrorContains(t, err, "has no up file")
+	})
+
+	t.Run("version used twice", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/20240101000000_initial_schema.up.sql": {Data: []byte("CREATE TABLE events ();")},
+			"migrations/20240101000000_add_tags.up.sql":       {Data: []byte("ALTER TABLE events ADD tags text;")},
+		}
+
+		_, err := LoadMigrations(fsys, "migrations")
+
+		assert.ErrorContains(t, err, "is used by")
+	})
+
+	t.Run("unexpected file", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/initial.sql": {Data: []byte("CREATE TABLE events ();")},
+		}
+
+		_, err := LoadMigrations(fsys, "migrations")
+
+		assert.ErrorContains(t, err, "unexpected file")
+	})
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrator, err := NewMigrator(nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrator.migrations)
+
+	for _, migration := range migrator.migrations {
+		assert.NotEmpty(t, migration.Down, "%s has no down migration", migration)
+	}
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	up, down, err := CreateMigration(dir, "Add Event Tags!", now)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20240501123000_add_event_tags.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "20240501123000_add_event_tags.down.sql"), down)
+
+	migrations, err := LoadMigrations(os.DirFS(dir), ".")
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	assert.Equal(t, "add_event_tags", migrations[0].Name)
+
+	_, _, err = CreateMigration(dir, "add event tags", now)
+	assert.ErrorIs(t, err, os.ErrExist)
+}
+
+// The entities as of the last release that created the schema with GORM
+// AutoMigrate, before the migrations were introduced.
+type baselineUser struct {
+	ID                       uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name                     string    `gorm:"not null"`
+	Email                    string    `gorm:"unique;not null"`
+	Password                 string    `gorm:"not null"`
+	Role                     string    `gorm:"not null;default:'user'"`
+	EmailVerificationCode    string
+	VerificationExpiry       time.Time
+	VerificationAttemptsLeft int  `gorm:"default:3"`
+	IsVerified               bool `gorm:"default:false"`
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+	Bookings                 []baselineBooking `gorm:"foreignKey:UserID"`
+	Reviews                  []baselineReview  `gorm:"foreignKey:UserID"`
+}
+
+type baselineEvent struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name          string    `gorm:"unique;not null"`
+	Location      string
+	StartDate     time.Time
+	EndDate       time.Time
+	Price         float64
+	TotalSeat     int
+	AvailableSeat int
+	Category      string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Bookings      []baselineBooking `gorm:"foreignKey:EventID"`
+	Reviews       []baselineReview  `gorm:"foreignKey:EventID"`
+}
+
+type baselineBooking struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null"`
+	EventID    uuid.UUID `gorm:"type:uuid;not null"`
+	Quantity   int       `gorm:"not null"`
+	TotalPrice float64   `gorm:"not null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	User       baselineUser  `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Event      baselineEvent `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE;"`
+}
+
+type baselineHealthComponent struct {
+	Database string
+}
+
+type baselineReview struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	EventID   uuid.UUID
+	UserID    uuid.UUID
+	Review    string
+	Rating    int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	User      baselineUser
+	Event     baselineEvent
+}
+
+func (baselineUser) TableName() string            { return "users" }
+func (baselineEvent) TableName() string           { return "events" }
+func (baselineBooking) TableName() string         { return "bookings" }
+func (baselineHealthComponent) TableName() string { return "health_components" }
+func (baselineReview) TableName() string          { return "reviews" }
+
+// TestUpgradeFromAutoMigrate migrates a database created by AutoMigrate and
+// checks that it ends up with the same schema as a new database. It needs a
+// PostgreSQL database in TEST_DATABASE_DSN and works in throwaway schemas.
+func TestUpgradeFromAutoMigrate(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	fresh := openTestSchema(t, dsn, "migrate_test_fresh")
+	upgraded := openTestSchema(t, dsn, "migrate_test_upgraded")
+
+	err := upgraded.AutoMigrate(&baselineUser{}, &baselineEvent{}, &baselineBooking{}, &baselineHealthComponent{}, &baselineReview{})
+	require.NoError(t, err)
+	require.NoError(t, upgraded.Exec(`INSERT INTO users (name, email, password) VALUES ('John Doe', 'johndoe@gmail.com', 'secret')`).Error)
+	require.NoError(t, upgraded.Exec(`INSERT INTO events (name, start_date) VALUES ('Concert', now()), ('Festival', now())`).Error)
+
+	for _, db := range []*gorm.DB{fresh, upgraded} {
+		migrator, err := NewMigrator(db)
+		require.NoError(t, err)
+
+		_, err = migrator.Up(0)
+		require.NoError(t, err)
+
+		pending, err := migrator.Pending()
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	}
+
+	assert.Equal(t, describeSchema(t, fresh, "migrate_test_fresh"), describeSchema(t, upgraded, "migrate_test_upgraded"))
+
+	// names are only unique per start date now
+	err = upgraded.Exec(`INSERT INTO events (name, start_date) VALUES ('Concert', now() + interval '7 days')`).Error
+	assert.NoError(t, err)
+}
+
+// openTestSchema connects to a new, empty schema called name that is dropped
+// when the test ends. uuid-ossp lives in public, which every schema can see.
+func openTestSchema(t *testing.T, dsn, name string) *gorm.DB {
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	for _, stmt := range []string{
+		`CREATE EXTENSION IF NOT EXISTS "uuid-ossp" SCHEMA public`,
+		fmt.Sprintf(`DROP SCHEMA IF EXISTS %q CASCADE`, name),
+		fmt.Sprintf(`CREATE SCHEMA %q`, name),
+	} {
+		require.NoError(t, admin.Exec(stmt).Error)
+	}
+
+	db, err := gorm.Open(postgres.Open(fmt.Sprintf("%s search_path=%s,public", dsn, name)), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+		admin.Exec(fmt.Sprintf(`DROP SCHEMA IF EXISTS %q CASCADE`, name))
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return db
+}
+
+// describeSchema lists the columns, constraints and indexes in schema.
+func describeSchema(t *testing.T, db *gorm.DB, schema string) []string {
+	var description []string
+	for _, query := range []string{
+		`SELECT table_name || '.' || column_name || ' ' || data_type || ' nullable=' || is_nullable || ' default=' || coalesce(column_default, '')
+		FROM information_schema.columns WHERE table_schema = ? ORDER BY table_name, column_name`,
+		`SELECT c.relname || ' ' || con.conname || ' ' || pg_get_constraintdef(con.oid)
+		FROM pg_constraint con JOIN pg_class c ON c.oid = con.conrelid JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = ? ORDER BY c.relname, con.conname`,
+		`SELECT replace(indexdef, schemaname || '.', '') FROM pg_indexes WHERE schemaname = ? ORDER BY tablename, indexname`,
+	} {
+		var rows []string
+		require.NoError(t, db.Raw(query, schema).Scan(&rows).Error)
+		description = append(description, rows...)
+	}
+
+	return description
+}
+
+func TestCheckPending(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	db := openTestSchema(t, dsn, "migrate_test_check")
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+
+	t.Run("everything is pending without schema_migrations", func(t *testing.T) {
+		pending, err := migrator.CheckPending()
+		require.NoError(t, err)
+		assert.Equal(t, migrator.migrations, pending)
+		assert.False(t, db.Migrator().HasTable("schema_migrations"))
+	})
+
+	t.Run("lists the migrations not applied yet", func(t *testing.T) {
+		_, err := migrator.Up(1)
+		require.NoError(t, err)
+
+		pending, err := migrator.CheckPending()
+		require.NoError(t, err)
+		assert.Equal(t, migrator.migrations[1:], pending)
+	})
+}
+
+func TestAuditEntriesRedaction(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	db := openTestSchema(t, dsn, "migrate_test_audit")
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(0)
+	require.NoError(t, err)
+
+	id := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO audit_entries (id, action, target_type, target_id, ip, created_at)
+		VALUES (?, 'auth.sign_in_failed', 'user', 'johndoe@gmail.com', '10.0.0.1', now())`, id).Error)
+
+	redact := func(stmt string) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SET LOCAL audit.redact = 'on'").Error; err != nil {
+				return err
+			}
+			return tx.Exec(stmt, id).Error
+		})
+	}
+
+	t.Run("updates are refused", func(t *testing.T) {
+		assert.Error(t, db.Exec(`UPDATE audit_entries SET ip = '[redacted]' WHERE id = ?`, id).Error)
+	})
+
+	t.Run("redaction changes the personal data", func(t *testing.T) {
+		assert.NoError(t, redact(`UPDATE audit_entries SET ip = '[redacted]', target_id = 'erased@users.invalid' WHERE id = ?`))
+	})
+
+	t.Run("redaction cannot change what happened", func(t *testing.T) {
+		assert.Error(t, redact(`UPDATE audit_entries SET action = 'auth.sign_in' WHERE id = ?`))
+	})
+}
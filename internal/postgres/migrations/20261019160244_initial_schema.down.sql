@@ -0,0 +1,6 @@
+-- revert initial_schema
+DROP TABLE IF EXISTS "reviews";
+DROP TABLE IF EXISTS "health_components";
+DROP TABLE IF EXISTS "bookings";
+DROP TABLE IF EXISTS "events";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,68 @@
+-- initial_schema
+-- The schema GORM AutoMigrate created before migrations were introduced.
+-- Every statement is IF NOT EXISTS, so databases created by AutoMigrate adopt
+-- it as is; the following migrations bring them up to date.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "email" text NOT NULL,
+    "password" text NOT NULL,
+    "role" text NOT NULL DEFAULT 'user',
+    "email_verification_code" text,
+    "verification_expiry" timestamptz,
+    "verification_attempts_left" bigint DEFAULT 3,
+    "is_verified" boolean DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+
+CREATE TABLE IF NOT EXISTS "events" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "location" text,
+    "start_date" timestamptz,
+    "end_date" timestamptz,
+    "price" decimal,
+    "total_seat" bigint,
+    "available_seat" bigint,
+    "category" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_events_name" UNIQUE ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "bookings" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "quantity" bigint NOT NULL,
+    "total_price" decimal NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_bookings" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+    CONSTRAINT "fk_events_bookings" FOREIGN KEY ("event_id") REFERENCES "events"("id")
+);
+
+CREATE TABLE IF NOT EXISTS "health_components" (
+    "database" text
+);
+
+CREATE TABLE IF NOT EXISTS "reviews" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid,
+    "user_id" uuid,
+    "review" text,
+    "rating" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_events_reviews" FOREIGN KEY ("event_id") REFERENCES "events"("id"),
+    CONSTRAINT "fk_users_reviews" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
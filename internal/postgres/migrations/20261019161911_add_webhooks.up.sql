@@ -0,0 +1,31 @@
+-- add_webhooks
+CREATE TABLE IF NOT EXISTS "webhooks" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "url" text NOT NULL,
+    "secret" text NOT NULL,
+    "event_types" text NOT NULL,
+    "active" boolean NOT NULL DEFAULT true,
+    "failure_count" bigint NOT NULL DEFAULT 0,
+    "disabled_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "webhook_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "event_type" text NOT NULL,
+    "payload" text NOT NULL,
+    "attempt" bigint NOT NULL,
+    "status_code" bigint,
+    "response_body" text,
+    "error" text,
+    "success" boolean NOT NULL DEFAULT false,
+    "duration_ms" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_webhooks_deliveries" FOREIGN KEY ("webhook_id") REFERENCES "webhooks"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_webhook_id" ON "webhook_deliveries" ("webhook_id");
@@ -0,0 +1,2 @@
+-- revert add_events_search_index
+DROP INDEX IF EXISTS "idx_events_search";
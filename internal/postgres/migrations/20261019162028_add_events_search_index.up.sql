@@ -0,0 +1,3 @@
+-- add_events_search_index
+-- expression index backing the full-text event search
+CREATE INDEX IF NOT EXISTS "idx_events_search" ON "events" USING GIN (to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(location, '')));
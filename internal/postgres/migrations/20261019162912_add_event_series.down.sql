@@ -0,0 +1,8 @@
+-- revert add_event_series
+DROP INDEX IF EXISTS "idx_events_name_start_date";
+ALTER TABLE "events" ADD CONSTRAINT "uni_events_name" UNIQUE ("name");
+DROP INDEX IF EXISTS "idx_events_series_id";
+ALTER TABLE "events" DROP CONSTRAINT IF EXISTS "fk_event_series_events";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "detached";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "series_id";
+DROP TABLE IF EXISTS "event_series";
@@ -0,0 +1,26 @@
+-- add_event_series
+CREATE TABLE IF NOT EXISTS "event_series" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "location" text,
+    "start_date" timestamptz NOT NULL,
+    "end_date" timestamptz NOT NULL,
+    "price" decimal,
+    "total_seat" bigint,
+    "category" text,
+    "r_rule" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "series_id" uuid;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "detached" boolean NOT NULL DEFAULT false;
+ALTER TABLE "events" DROP CONSTRAINT IF EXISTS "fk_event_series_events";
+ALTER TABLE "events" ADD CONSTRAINT "fk_event_series_events" FOREIGN KEY ("series_id") REFERENCES "event_series"("id") ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS "idx_events_series_id" ON "events" ("series_id");
+
+-- occurrences of a series share their name, so names are only unique per
+-- start date
+ALTER TABLE "events" DROP CONSTRAINT IF EXISTS "uni_events_name";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_events_name_start_date" ON "events" ("name","start_date");
@@ -0,0 +1,47 @@
+-- add_venues
+CREATE TABLE IF NOT EXISTS "venues" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "address" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_venues_name" UNIQUE ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "sections" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "venue_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "position" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_venues_sections" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_sections_venue_name" ON "sections" ("venue_id","name");
+
+CREATE TABLE IF NOT EXISTS "seats" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "section_id" uuid NOT NULL,
+    "row_label" text NOT NULL,
+    "number" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_sections_seats" FOREIGN KEY ("section_id") REFERENCES "sections"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_seats_section_row_number" ON "seats" ("section_id","row_label","number");
+
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "venue_id" uuid;
+ALTER TABLE "events" DROP CONSTRAINT IF EXISTS "fk_events_venue";
+ALTER TABLE "events" ADD CONSTRAINT "fk_events_venue" FOREIGN KEY ("venue_id") REFERENCES "venues"("id") ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS "idx_events_venue_id" ON "events" ("venue_id");
+
+CREATE TABLE IF NOT EXISTS "booking_seats" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "seat_id" uuid NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_booking_seats_seat" FOREIGN KEY ("seat_id") REFERENCES "seats"("id") ON DELETE RESTRICT,
+    CONSTRAINT "fk_bookings_seats" FOREIGN KEY ("booking_id") REFERENCES "bookings"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_booking_seats_event_seat" ON "booking_seats" ("event_id","seat_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_seats_booking_id" ON "booking_seats" ("booking_id");
@@ -0,0 +1,12 @@
+-- revert add_event_lifecycle
+DROP TABLE IF EXISTS "refunds";
+DROP INDEX IF EXISTS "idx_bookings_status";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "cancelled_at";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "status";
+DROP INDEX IF EXISTS "idx_events_status";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "cancellation_reason";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "cancelled_at";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "sales_end_at";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "sales_start_at";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "publish_at";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "status";
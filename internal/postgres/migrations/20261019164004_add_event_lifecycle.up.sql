@@ -0,0 +1,30 @@
+-- add_event_lifecycle
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "status" text NOT NULL DEFAULT 'published';
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "publish_at" timestamptz;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "sales_start_at" timestamptz;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "sales_end_at" timestamptz;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "cancelled_at" timestamptz;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "cancellation_reason" text;
+CREATE INDEX IF NOT EXISTS "idx_events_status" ON "events" ("status");
+
+ALTER TABLE "bookings" ADD COLUMN IF NOT EXISTS "status" text NOT NULL DEFAULT 'confirmed';
+ALTER TABLE "bookings" ADD COLUMN IF NOT EXISTS "cancelled_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_bookings_status" ON "bookings" ("status");
+
+CREATE TABLE IF NOT EXISTS "refunds" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "amount" decimal NOT NULL,
+    "reason" text,
+    "status" text NOT NULL DEFAULT 'pending',
+    "processed_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_refunds_booking" FOREIGN KEY ("booking_id") REFERENCES "bookings"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_refunds_booking_id" ON "refunds" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_refunds_user_id" ON "refunds" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_refunds_event_id" ON "refunds" ("event_id");
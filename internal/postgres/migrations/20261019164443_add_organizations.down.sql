@@ -0,0 +1,6 @@
+-- revert add_organizations
+DROP INDEX IF EXISTS "idx_events_organizer_id";
+ALTER TABLE "events" DROP CONSTRAINT IF EXISTS "fk_organizations_events";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "organizer_id";
+DROP TABLE IF EXISTS "organization_members";
+DROP TABLE IF EXISTS "organizations";
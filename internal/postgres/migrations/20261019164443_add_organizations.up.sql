@@ -0,0 +1,28 @@
+-- add_organizations
+CREATE TABLE IF NOT EXISTS "organizations" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_organizations_name" UNIQUE ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "organization_members" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "organization_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "role" text NOT NULL DEFAULT 'member',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_organization_members_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_organizations_members" FOREIGN KEY ("organization_id") REFERENCES "organizations"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_organization_members_organization_id" ON "organization_members" ("organization_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_organization_members_user_id" ON "organization_members" ("user_id");
+
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "organizer_id" uuid;
+ALTER TABLE "events" DROP CONSTRAINT IF EXISTS "fk_organizations_events";
+ALTER TABLE "events" ADD CONSTRAINT "fk_organizations_events" FOREIGN KEY ("organizer_id") REFERENCES "organizations"("id");
+CREATE INDEX IF NOT EXISTS "idx_events_organizer_id" ON "events" ("organizer_id");
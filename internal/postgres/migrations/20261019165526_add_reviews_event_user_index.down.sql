@@ -0,0 +1,2 @@
+-- revert add_reviews_event_user_index
+DROP INDEX IF EXISTS "idx_reviews_event_user";
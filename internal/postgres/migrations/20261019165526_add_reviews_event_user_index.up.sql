@@ -0,0 +1,3 @@
+-- add_reviews_event_user_index
+-- one review per user and event
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_reviews_event_user" ON "reviews" ("event_id","user_id");
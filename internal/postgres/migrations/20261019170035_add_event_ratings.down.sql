@@ -0,0 +1,9 @@
+-- revert add_event_ratings
+DROP INDEX IF EXISTS "idx_events_rating";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "rating5_count";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "rating4_count";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "rating3_count";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "rating2_count";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "rating1_count";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "rating_count";
+ALTER TABLE "events" DROP COLUMN IF EXISTS "rating";
@@ -0,0 +1,9 @@
+-- add_event_ratings
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "rating" decimal NOT NULL DEFAULT 0;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "rating_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "rating1_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "rating2_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "rating3_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "rating4_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "rating5_count" bigint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS "idx_events_rating" ON "events" ("rating");
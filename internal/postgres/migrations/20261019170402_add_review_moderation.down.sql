@@ -0,0 +1,7 @@
+-- revert add_review_moderation
+DROP TABLE IF EXISTS "review_reports";
+DROP INDEX IF EXISTS "idx_reviews_status";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "moderated_by";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "moderated_at";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "moderation_reason";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "status";
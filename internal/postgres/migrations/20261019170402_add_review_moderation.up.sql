@@ -0,0 +1,19 @@
+-- add_review_moderation
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "status" text NOT NULL DEFAULT 'approved';
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "moderation_reason" text;
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "moderated_at" timestamptz;
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "moderated_by" uuid;
+CREATE INDEX IF NOT EXISTS "idx_reviews_status" ON "reviews" ("status");
+
+CREATE TABLE IF NOT EXISTS "review_reports" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "review_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "reason" text NOT NULL,
+    "resolved_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_review_reports_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_reviews_reports" FOREIGN KEY ("review_id") REFERENCES "reviews"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_review_reports_review_user" ON "review_reports" ("review_id","user_id");
@@ -0,0 +1,7 @@
+-- revert add_review_replies_and_votes
+DROP TABLE IF EXISTS "review_replies";
+DROP TABLE IF EXISTS "review_votes";
+DROP INDEX IF EXISTS "idx_reviews_helpfulness";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "helpfulness";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "unhelpful_count";
+ALTER TABLE "reviews" DROP COLUMN IF EXISTS "helpful_count";
@@ -0,0 +1,29 @@
+-- add_review_replies_and_votes
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "helpful_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "unhelpful_count" bigint NOT NULL DEFAULT 0;
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "helpfulness" bigint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS "idx_reviews_helpfulness" ON "reviews" ("helpfulness");
+
+CREATE TABLE IF NOT EXISTS "review_votes" (
+    "review_id" uuid,
+    "user_id" uuid,
+    "helpful" boolean NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("review_id","user_id"),
+    CONSTRAINT "fk_review_votes_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_reviews_votes" FOREIGN KEY ("review_id") REFERENCES "reviews"("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "review_replies" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "review_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "reply" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_review_replies_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_reviews_reply" FOREIGN KEY ("review_id") REFERENCES "reviews"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_review_replies_review_id" ON "review_replies" ("review_id");
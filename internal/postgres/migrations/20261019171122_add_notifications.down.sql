@@ -0,0 +1,3 @@
+-- revert add_notifications
+DROP TABLE IF EXISTS "notification_preferences";
+DROP TABLE IF EXISTS "notifications";
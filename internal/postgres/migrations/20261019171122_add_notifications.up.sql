@@ -0,0 +1,23 @@
+-- add_notifications
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "type" text NOT NULL,
+    "subject" text NOT NULL,
+    "body" text NOT NULL,
+    "read_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_notifications_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_user_id" ON "notifications" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "notification_preferences" (
+    "user_id" uuid,
+    "type" text,
+    "channel" text,
+    "enabled" boolean NOT NULL,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("user_id","type","channel"),
+    CONSTRAINT "fk_notification_preferences_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+-- revert add_email_messages
+DROP TABLE IF EXISTS "email_messages";
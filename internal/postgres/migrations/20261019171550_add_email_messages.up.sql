@@ -0,0 +1,18 @@
+-- add_email_messages
+CREATE TABLE IF NOT EXISTS "email_messages" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "to" text NOT NULL,
+    "subject" text NOT NULL,
+    "html_body" text,
+    "text_body" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'queued',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "last_error" text,
+    "sent_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_email_messages_to" ON "email_messages" ("to");
+CREATE INDEX IF NOT EXISTS "idx_email_messages_due" ON "email_messages" ("status","next_attempt_at");
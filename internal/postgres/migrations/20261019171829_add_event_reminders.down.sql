@@ -0,0 +1,2 @@
+-- revert add_event_reminders
+DROP TABLE IF EXISTS "event_reminders";
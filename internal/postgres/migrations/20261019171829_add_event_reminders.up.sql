@@ -0,0 +1,10 @@
+-- add_event_reminders
+CREATE TABLE IF NOT EXISTS "event_reminders" (
+    "event_id" uuid,
+    "user_id" uuid,
+    "offset_minutes" bigint,
+    "sent_at" timestamptz NOT NULL,
+    PRIMARY KEY ("event_id","user_id","offset_minutes"),
+    CONSTRAINT "fk_event_reminders_event" FOREIGN KEY ("event_id") REFERENCES "events"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_event_reminders_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- revert add_calendar_feeds_and_email_attachments
+DROP TABLE IF EXISTS "calendar_feeds";
+DROP TABLE IF EXISTS "email_attachments";
@@ -0,0 +1,21 @@
+-- add_calendar_feeds_and_email_attachments
+CREATE TABLE IF NOT EXISTS "email_attachments" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "email_message_id" uuid NOT NULL,
+    "filename" text NOT NULL,
+    "content_type" text NOT NULL,
+    "content" bytea NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_email_messages_attachments" FOREIGN KEY ("email_message_id") REFERENCES "email_messages"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_email_attachments_email_message_id" ON "email_attachments" ("email_message_id");
+
+CREATE TABLE IF NOT EXISTS "calendar_feeds" (
+    "user_id" uuid,
+    "token_hash" text NOT NULL,
+    "last_used_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("user_id"),
+    CONSTRAINT "fk_calendar_feeds_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_calendar_feeds_token_hash" ON "calendar_feeds" ("token_hash");
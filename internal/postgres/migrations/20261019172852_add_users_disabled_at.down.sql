@@ -0,0 +1,2 @@
+-- revert add_users_disabled_at
+ALTER TABLE "users" DROP COLUMN IF EXISTS "disabled_at";
@@ -0,0 +1,2 @@
+-- add_users_disabled_at
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "disabled_at" timestamptz;
@@ -0,0 +1,3 @@
+-- revert add_seat_reconciliations
+DROP TABLE IF EXISTS "seat_drifts";
+DROP TABLE IF EXISTS "seat_reconciliations";
@@ -0,0 +1,30 @@
+-- add_seat_reconciliations
+CREATE TABLE IF NOT EXISTS "seat_reconciliations" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "trigger" text NOT NULL,
+    "correct" boolean NOT NULL DEFAULT false,
+    "events_checked" bigint NOT NULL DEFAULT 0,
+    "drift_count" bigint NOT NULL DEFAULT 0,
+    "corrected_count" bigint NOT NULL DEFAULT 0,
+    "started_at" timestamptz NOT NULL,
+    "finished_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_seat_reconciliations_trigger" ON "seat_reconciliations" ("trigger");
+
+CREATE TABLE IF NOT EXISTS "seat_drifts" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "reconciliation_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "event_name" text,
+    "total_seat" bigint,
+    "booked_seat" bigint,
+    "recorded_seat" bigint,
+    "expected_seat" bigint,
+    "corrected" boolean NOT NULL DEFAULT false,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_seat_reconciliations_drifts" FOREIGN KEY ("reconciliation_id") REFERENCES "seat_reconciliations"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_seat_drifts_event_id" ON "seat_drifts" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_seat_drifts_reconciliation_id" ON "seat_drifts" ("reconciliation_id");
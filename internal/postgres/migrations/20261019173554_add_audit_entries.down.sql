@@ -0,0 +1,3 @@
+-- revert add_audit_entries
+DROP TABLE IF EXISTS "audit_entries";
+DROP FUNCTION IF EXISTS audit_entries_append_only();
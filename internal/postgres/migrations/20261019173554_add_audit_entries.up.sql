@@ -0,0 +1,37 @@
+-- add_audit_entries
+CREATE TABLE IF NOT EXISTS "audit_entries" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "actor_id" uuid,
+    "actor_role" text,
+    "action" text NOT NULL,
+    "target_type" text NOT NULL,
+    "target_id" text,
+    "changes" text,
+    "ip" text,
+    "user_agent" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_entries_actor_id" ON "audit_entries" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_entries_action" ON "audit_entries" ("action");
+CREATE INDEX IF NOT EXISTS "idx_audit_entries_target" ON "audit_entries" ("target_type","target_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_entries_created_at" ON "audit_entries" ("created_at");
+
+-- The audit log is append-only. Deletes are only let through for the
+-- retention purge, which sets audit.purge for its transaction.
+CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND current_setting('audit.purge', true) = 'on' THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'audit_entries is append-only, % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "audit_entries_append_only" ON "audit_entries";
+CREATE TRIGGER "audit_entries_append_only" BEFORE UPDATE OR DELETE ON "audit_entries"
+    FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only();
+
+DROP TRIGGER IF EXISTS "audit_entries_no_truncate" ON "audit_entries";
+CREATE TRIGGER "audit_entries_no_truncate" BEFORE TRUNCATE ON "audit_entries"
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_entries_append_only();
@@ -0,0 +1,62 @@
+-- soft_delete
+-- Users, event series, events, bookings and reviews are soft deleted. Unique
+-- constraints only hold among live rows, and deleting a parent no longer
+-- cascades: the services remove or soft delete dependents explicitly and the
+-- purge command deletes them permanently.
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+ALTER TABLE "event_series" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_event_series_deleted_at" ON "event_series" ("deleted_at");
+
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_events_deleted_at" ON "events" ("deleted_at");
+
+ALTER TABLE "bookings" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_bookings_deleted_at" ON "bookings" ("deleted_at");
+
+ALTER TABLE "reviews" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_reviews_deleted_at" ON "reviews" ("deleted_at");
+
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "uni_users_email";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email") WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS "idx_events_name_start_date";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_events_name_start_date" ON "events" ("name","start_date") WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS "idx_reviews_event_user";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_reviews_event_user" ON "reviews" ("event_id","user_id") WHERE deleted_at IS NULL;
+
+ALTER TABLE "organization_members" DROP CONSTRAINT IF EXISTS "fk_organization_members_user";
+ALTER TABLE "organization_members" ADD CONSTRAINT "fk_organization_members_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
+ALTER TABLE "events" DROP CONSTRAINT IF EXISTS "fk_event_series_events";
+ALTER TABLE "events" ADD CONSTRAINT "fk_event_series_events" FOREIGN KEY ("series_id") REFERENCES "event_series"("id") ON DELETE RESTRICT;
+ALTER TABLE "bookings" DROP CONSTRAINT IF EXISTS "fk_users_bookings";
+ALTER TABLE "bookings" ADD CONSTRAINT "fk_users_bookings" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
+ALTER TABLE "bookings" DROP CONSTRAINT IF EXISTS "fk_events_bookings";
+ALTER TABLE "bookings" ADD CONSTRAINT "fk_events_bookings" FOREIGN KEY ("event_id") REFERENCES "events"("id") ON DELETE RESTRICT;
+ALTER TABLE "booking_seats" DROP CONSTRAINT IF EXISTS "fk_bookings_seats";
+ALTER TABLE "booking_seats" ADD CONSTRAINT "fk_bookings_seats" FOREIGN KEY ("booking_id") REFERENCES "bookings"("id") ON DELETE RESTRICT;
+ALTER TABLE "refunds" DROP CONSTRAINT IF EXISTS "fk_refunds_booking";
+ALTER TABLE "refunds" ADD CONSTRAINT "fk_refunds_booking" FOREIGN KEY ("booking_id") REFERENCES "bookings"("id") ON DELETE RESTRICT;
+ALTER TABLE "review_reports" DROP CONSTRAINT IF EXISTS "fk_review_reports_user";
+ALTER TABLE "review_reports" ADD CONSTRAINT "fk_review_reports_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
+ALTER TABLE "review_reports" DROP CONSTRAINT IF EXISTS "fk_reviews_reports";
+ALTER TABLE "review_reports" ADD CONSTRAINT "fk_reviews_reports" FOREIGN KEY ("review_id") REFERENCES "reviews"("id") ON DELETE RESTRICT;
+ALTER TABLE "review_votes" DROP CONSTRAINT IF EXISTS "fk_review_votes_user";
+ALTER TABLE "review_votes" ADD CONSTRAINT "fk_review_votes_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
+ALTER TABLE "review_votes" DROP CONSTRAINT IF EXISTS "fk_reviews_votes";
+ALTER TABLE "review_votes" ADD CONSTRAINT "fk_reviews_votes" FOREIGN KEY ("review_id") REFERENCES "reviews"("id") ON DELETE RESTRICT;
+ALTER TABLE "review_replies" DROP CONSTRAINT IF EXISTS "fk_review_replies_user";
+ALTER TABLE "review_replies" ADD CONSTRAINT "fk_review_replies_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
+ALTER TABLE "review_replies" DROP CONSTRAINT IF EXISTS "fk_reviews_reply";
+ALTER TABLE "review_replies" ADD CONSTRAINT "fk_reviews_reply" FOREIGN KEY ("review_id") REFERENCES "reviews"("id") ON DELETE RESTRICT;
+ALTER TABLE "notifications" DROP CONSTRAINT IF EXISTS "fk_notifications_user";
+ALTER TABLE "notifications" ADD CONSTRAINT "fk_notifications_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
+ALTER TABLE "notification_preferences" DROP CONSTRAINT IF EXISTS "fk_notification_preferences_user";
+ALTER TABLE "notification_preferences" ADD CONSTRAINT "fk_notification_preferences_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
+ALTER TABLE "event_reminders" DROP CONSTRAINT IF EXISTS "fk_event_reminders_event";
+ALTER TABLE "event_reminders" ADD CONSTRAINT "fk_event_reminders_event" FOREIGN KEY ("event_id") REFERENCES "events"("id") ON DELETE RESTRICT;
+ALTER TABLE "event_reminders" DROP CONSTRAINT IF EXISTS "fk_event_reminders_user";
+ALTER TABLE "event_reminders" ADD CONSTRAINT "fk_event_reminders_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
+ALTER TABLE "calendar_feeds" DROP CONSTRAINT IF EXISTS "fk_calendar_feeds_user";
+ALTER TABLE "calendar_feeds" ADD CONSTRAINT "fk_calendar_feeds_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE RESTRICT;
@@ -0,0 +1,2 @@
+-- revert add_users_erased_at
+ALTER TABLE "users" DROP COLUMN IF EXISTS "erased_at";
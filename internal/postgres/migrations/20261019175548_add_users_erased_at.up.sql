@@ -0,0 +1,2 @@
+-- add_users_erased_at
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "erased_at" timestamptz;
@@ -0,0 +1,3 @@
+-- revert add_users_pending_email
+DROP INDEX IF EXISTS "idx_users_pending_email";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "pending_email";
@@ -0,0 +1,3 @@
+-- add_users_pending_email
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "pending_email" text NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_pending_email" ON "users" ("pending_email") WHERE pending_email <> '' AND deleted_at IS NULL;
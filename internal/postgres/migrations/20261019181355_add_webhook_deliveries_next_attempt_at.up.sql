@@ -0,0 +1,3 @@
+-- add_webhook_deliveries_next_attempt_at
+ALTER TABLE "webhook_deliveries" ADD COLUMN IF NOT EXISTS "next_attempt_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_due" ON "webhook_deliveries" ("next_attempt_at") WHERE next_attempt_at IS NOT NULL;
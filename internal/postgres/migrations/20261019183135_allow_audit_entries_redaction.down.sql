@@ -0,0 +1,9 @@
+-- revert allow_audit_entries_redaction
+CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND current_setting('audit.purge', true) = 'on' THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'audit_entries is append-only, % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,17 @@
+-- allow_audit_entries_redaction
+-- Erasing an account redacts the personal data in its entries, setting
+-- audit.redact for its transaction. Only target_id, changes, ip and
+-- user_agent may change; who did what and when stays as recorded.
+CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND current_setting('audit.purge', true) = 'on' THEN
+        RETURN OLD;
+    END IF;
+    IF TG_OP = 'UPDATE' AND current_setting('audit.redact', true) = 'on'
+        AND (NEW.id, NEW.actor_id, NEW.actor_role, NEW.action, NEW.target_type, NEW.created_at)
+            IS NOT DISTINCT FROM (OLD.id, OLD.actor_id, OLD.actor_role, OLD.action, OLD.target_type, OLD.created_at) THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_entries is append-only, % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
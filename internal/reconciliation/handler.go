@@ -0,0 +1,100 @@
+package reconciliation
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/api/validator"
+	"event-booking/internal/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type httpHandler struct {
+	svc *Service
+}
+
+func NewHttpHandler(svc *Service) *httpHandler {
+	return &httpHandler{
+		svc: svc,
+	}
+}
+
+type ReconcilePayload struct {
+	// Correct recounts the drifted events; without it drift is only reported
+	Correct bool `json:"correct"`
+}
+
+func (h *httpHandler) ReconcileSeatsHandler(c *fiber.Ctx) error {
+	payload := new(ReconcilePayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	report, err := h.svc.ReconcileSeatsService(entity.ReconciliationTriggerAdmin, payload.Correct)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Seats reconciled", newReportResponse(report)))
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"started_at", "drift_count"},
+	Filters:     []string{"trigger", "correct"},
+	DefaultSort: "-started_at",
+}
+
+// FindAllReportHandler lists the reports without their drifts.
+func (h *httpHandler) FindAllReportHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	reports, total, err := h.svc.FindAllReportService(opts)
+	if err != nil {
+		return err
+	}
+
+	reportResponses := []responses.SeatReconciliationResponseObject{}
+	for i := range reports {
+		reportResponses = append(reportResponses, newReportResponse(&reports[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Seat reconciliations found", reportResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) FindReportHandler(c *fiber.Ctx) error {
+	report, err := h.svc.FindReportService(c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Seat reconciliation found", newReportResponse(report)))
+}
+
+func newReportResponse(report *entity.SeatReconciliation) responses.SeatReconciliationResponseObject {
+	response := responses.SeatReconciliationResponseObject{
+		ID:             report.ID,
+		Trigger:        report.Trigger,
+		Correct:        report.Correct,
+		EventsChecked:  report.EventsChecked,
+		DriftCount:     report.DriftCount,
+		CorrectedCount: report.CorrectedCount,
+		StartedAt:      report.StartedAt,
+		FinishedAt:     report.FinishedAt,
+	}
+	for _, drift := range report.Drifts {
+		response.Drifts = append(response.Drifts, responses.SeatDriftResponseObject{
+			EventID:      drift.EventID,
+			EventName:    drift.EventName,
+			TotalSeat:    drift.TotalSeat,
+			BookedSeat:   drift.BookedSeat,
+			RecordedSeat: drift.RecordedSeat,
+			ExpectedSeat: drift.ExpectedSeat,
+			Corrected:    drift.Corrected,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,100 @@
+package reconciliation
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"event-booking/internal/postgres"
+
+	"gorm.io/gorm"
+)
+
+// lockName is the advisory lock that keeps two reconciliations from running
+// at the same time.
+const lockName = "seat_reconciliation"
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+func (r *repo) Lock(fn func() error) (bool, error) {
+	return postgres.WithAdvisoryLock(r.db, lockName, func(*gorm.DB) error {
+		return fn()
+	})
+}
+
+// notCancelled skips cancelled events: cancelling cancels their bookings but
+// leaves their available seats as they were, and no more seats are sold.
+func notCancelled(db *gorm.DB) *gorm.DB {
+	return db.Where("events.status <> ?", entity.EventStatusCancelled)
+}
+
+// FindDrift compares every event that is not cancelled with the quantities
+// of its confirmed bookings. It returns the events whose available seats are
+// off, or whose bookings exceed the capacity, together with the number of
+// events checked.
+func (r *repo) FindDrift() ([]entity.SeatDrift, int64, error) {
+	var checked int64
+	if err := r.db.Model(&entity.Event{}).Scopes(notCancelled).Count(&checked).Error; err != nil {
+		return nil, 0, err
+	}
+
+	booked := r.db.Model(&entity.Booking{}).
+		Select("event_id, SUM(quantity) AS booked").
+		Where("status = ?", entity.BookingStatusConfirmed).
+		Group("event_id")
+
+	var drifts []entity.SeatDrift
+	err := r.db.Model(&entity.Event{}).
+		Select(`events.id AS event_id, events.name AS event_name, events.total_seat,
+			COALESCE(b.booked, 0) AS booked_seat, events.available_seat AS recorded_seat,
+			GREATEST(events.total_seat - COALESCE(b.booked, 0), 0) AS expected_seat`).
+		Joins("LEFT JOIN (?) AS b ON b.event_id = events.id", booked).
+		Scopes(notCancelled).
+		Where("events.available_seat <> GREATEST(events.total_seat - COALESCE(b.booked, 0), 0) OR COALESCE(b.booked, 0) > events.total_seat").
+		Order("events.start_date").
+		Scan(&drifts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return drifts, checked, nil
+}
+
+// CreateReport stores the report with its drifts.
+func (r *repo) CreateReport(report *entity.SeatReconciliation) error {
+	return r.db.Create(report).Error
+}
+
+func (r *repo) FindAll(opts query.Options) ([]entity.SeatReconciliation, int64, error) {
+	db := r.db.Model(&entity.SeatReconciliation{}).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reports []entity.SeatReconciliation
+	if err := db.Scopes(opts.Paginate).Find(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return reports, total, nil
+}
+
+func (r *repo) Find(id string) (*entity.SeatReconciliation, error) {
+	var report entity.SeatReconciliation
+	err := r.db.Preload("Drifts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("event_name")
+	}).Where("id = ?", id).First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,85 @@
+package reconciliation
+
+import (
+	"event-booking/internal/entity"
+	"event-booking/internal/postgres"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB migrates a throwaway schema in the PostgreSQL database in
+// TEST_DATABASE_DSN, and skips the test without one.
+func openTestDB(t *testing.T, schema string) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	config := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+	admin, err := gorm.Open(gormpostgres.Open(dsn), config)
+	require.NoError(t, err)
+	for _, stmt := range []string{
+		`CREATE EXTENSION IF NOT EXISTS "uuid-ossp" SCHEMA public`,
+		fmt.Sprintf(`DROP SCHEMA IF EXISTS %q CASCADE`, schema),
+		fmt.Sprintf(`CREATE SCHEMA %q`, schema),
+	} {
+		require.NoError(t, admin.Exec(stmt).Error)
+	}
+
+	db, err := gorm.Open(gormpostgres.Open(fmt.Sprintf("%s search_path=%s,public", dsn, schema)), config)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+		admin.Exec(fmt.Sprintf(`DROP SCHEMA IF EXISTS %q CASCADE`, schema))
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrator, err := postgres.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(0)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestFindDrift(t *testing.T) {
+	db := openTestDB(t, "reconciliation_test")
+
+	user := &entity.User{Name: "John Doe", Email: "johndoe@gmail.com", Password: "hash"}
+	require.NoError(t, db.Create(user).Error)
+
+	start := time.Now().Add(24 * time.Hour)
+	drifted := &entity.Event{Name: "Drifted", StartDate: start, EndDate: start, TotalSeat: 10, AvailableSeat: 10}
+	cancelled := &entity.Event{Name: "Cancelled", StartDate: start, EndDate: start, TotalSeat: 10, AvailableSeat: 8, Status: entity.EventStatusCancelled}
+	require.NoError(t, db.Create(drifted).Error)
+	require.NoError(t, db.Create(cancelled).Error)
+
+	// the cancelled event's booking was cancelled without freeing its seats
+	for _, booking := range []*entity.Booking{
+		{UserID: user.ID, EventID: drifted.ID, Quantity: 2, TotalPrice: 20},
+		{UserID: user.ID, EventID: cancelled.ID, Quantity: 2, TotalPrice: 20, Status: entity.BookingStatusCancelled},
+	} {
+		require.NoError(t, db.Create(booking).Error)
+	}
+
+	drifts, checked, err := NewRepository(db).FindDrift()
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), checked)
+	if assert.Len(t, drifts, 1) {
+		assert.Equal(t, drifted.ID, drifts[0].EventID)
+		assert.Equal(t, 8, drifts[0].ExpectedSeat)
+	}
+}
@@ -0,0 +1,160 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	Lock(fn func() error) (bool, error)
+	FindDrift() ([]entity.SeatDrift, int64, error)
+	CreateReport(report *entity.SeatReconciliation) error
+	FindAll(opts query.Options) ([]entity.SeatReconciliation, int64, error)
+	Find(id string) (*entity.SeatReconciliation, error)
+}
+
+// EventRepository corrects the seats of a single event, see
+// event.Repository.RecountSeats.
+//
+//go:generate mockery --case snake --name EventRepository
+type EventRepository interface {
+	RecountSeats(eventID string) (*entity.Event, int, error)
+}
+
+var (
+	ErrReportNotFound = apperror.NotFound("reconciliation_not_found", "reconciliation report not found")
+	ErrRunning        = apperror.Conflict("reconciliation_running", "a seat reconciliation is already running")
+)
+
+// Service finds events whose available seats drifted from their bookings,
+// because booking writes read and write the event without a lock, and
+// optionally corrects them.
+type Service struct {
+	repo   Repository
+	events EventRepository
+}
+
+func NewService(repo Repository, events EventRepository) *Service {
+	return &Service{
+		repo:   repo,
+		events: events,
+	}
+}
+
+// Run reconciles every interval until ctx is done. Runs that find another
+// reconciliation in progress are skipped.
+func (s *Service) Run(ctx context.Context, interval time.Duration, correct bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.ReconcileSeatsService(entity.ReconciliationTriggerSchedule, correct)
+		switch {
+		case errors.Is(err, ErrRunning):
+			log.Debug().Msg("seats are being reconciled by another process")
+		case err != nil:
+			log.Error().Err(err).Msg("failed to reconcile seats")
+		case report.DriftCount > 0:
+			log.Warn().Msgf("seat reconciliation %s found %d drifted events, corrected %d", report.ID, report.DriftCount, report.CorrectedCount)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileSeatsService checks every event and stores the result as a
+// report. With correct, each drifted event is recounted in its own
+// transaction with the event row locked, so bookings made since the check
+// are counted too. Overbooked events are reported but cannot be corrected.
+func (s *Service) ReconcileSeatsService(trigger string, correct bool) (*entity.SeatReconciliation, error) {
+	var report *entity.SeatReconciliation
+	acquired, err := s.repo.Lock(func() error {
+		var err error
+		report, err = s.reconcile(trigger, correct)
+		return err
+	})
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrRunning
+	}
+
+	return report, nil
+}
+
+func (s *Service) reconcile(trigger string, correct bool) (*entity.SeatReconciliation, error) {
+	report := &entity.SeatReconciliation{
+		Trigger:   trigger,
+		Correct:   correct,
+		StartedAt: time.Now(),
+	}
+
+	drifts, checked, err := s.repo.FindDrift()
+	if err != nil {
+		return nil, err
+	}
+	report.EventsChecked = int(checked)
+	report.DriftCount = len(drifts)
+
+	if correct {
+		for i := range drifts {
+			drift := &drifts[i]
+			event, previous, err := s.events.RecountSeats(drift.EventID.String())
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			drift.RecordedSeat = previous
+			drift.ExpectedSeat = event.AvailableSeat
+			drift.Corrected = previous != event.AvailableSeat
+			if drift.Corrected {
+				report.CorrectedCount++
+			}
+		}
+	}
+
+	report.Drifts = drifts
+	report.FinishedAt = time.Now()
+	if err := s.repo.CreateReport(report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *Service) FindAllReportService(opts query.Options) ([]entity.SeatReconciliation, int64, error) {
+	reports, total, err := s.repo.FindAll(opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return reports, total, nil
+}
+
+func (s *Service) FindReportService(id string) (*entity.SeatReconciliation, error) {
+	report, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrReportNotFound)
+	}
+
+	return report, nil
+}
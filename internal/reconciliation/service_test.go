@@ -0,0 +1,116 @@
+package reconciliation
+
+import (
+	"event-booking/internal/entity"
+	"event-booking/internal/reconciliation/mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func locked(fn func() error) (bool, error) {
+	return true, fn()
+}
+
+func TestReconcileSeats(t *testing.T) {
+	newDrifts := func() []entity.SeatDrift {
+		return []entity.SeatDrift{
+			{EventID: uuid.New(), EventName: "Jazz Night", TotalSeat: 100, BookedSeat: 30, RecordedSeat: 75, ExpectedSeat: 70},
+			{EventID: uuid.New(), EventName: "Rock Fest", TotalSeat: 10, BookedSeat: 12, RecordedSeat: 0, ExpectedSeat: 0},
+		}
+	}
+
+	t.Run("report only", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockEvents := mocks.NewEventRepository(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(locked).Once()
+		mockRepo.On("FindDrift").Return(newDrifts(), int64(5), nil).Once()
+		mockRepo.On("CreateReport", mock.Anything).Return(nil).Once()
+
+		report, err := NewService(mockRepo, mockEvents).ReconcileSeatsService(entity.ReconciliationTriggerCLI, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ReconciliationTriggerCLI, report.Trigger)
+		assert.Equal(t, 5, report.EventsChecked)
+		assert.Equal(t, 2, report.DriftCount)
+		assert.Zero(t, report.CorrectedCount)
+		assert.Len(t, report.Drifts, 2)
+		assert.False(t, report.FinishedAt.Before(report.StartedAt))
+	})
+
+	t.Run("correct drifted events", func(t *testing.T) {
+		drifts := newDrifts()
+		mockRepo := mocks.NewRepository(t)
+		mockEvents := mocks.NewEventRepository(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(locked).Once()
+		mockRepo.On("FindDrift").Return(drifts, int64(5), nil).Once()
+		// a booking came in between the check and the recount
+		mockEvents.On("RecountSeats", drifts[0].EventID.String()).
+			Return(&entity.Event{ID: drifts[0].EventID, AvailableSeat: 68}, 76, nil).Once()
+		mockEvents.On("RecountSeats", drifts[1].EventID.String()).
+			Return(&entity.Event{ID: drifts[1].EventID, AvailableSeat: 0}, 0, nil).Once()
+		mockRepo.On("CreateReport", mock.Anything).Return(nil).Once()
+
+		report, err := NewService(mockRepo, mockEvents).ReconcileSeatsService(entity.ReconciliationTriggerAdmin, true)
+
+		assert.NoError(t, err)
+		assert.True(t, report.Correct)
+		assert.Equal(t, 2, report.DriftCount)
+		assert.Equal(t, 1, report.CorrectedCount)
+		assert.Equal(t, 76, report.Drifts[0].RecordedSeat)
+		assert.Equal(t, 68, report.Drifts[0].ExpectedSeat)
+		assert.True(t, report.Drifts[0].Corrected)
+		assert.False(t, report.Drifts[1].Corrected)
+	})
+
+	t.Run("deleted events are skipped", func(t *testing.T) {
+		drifts := newDrifts()[:1]
+		mockRepo := mocks.NewRepository(t)
+		mockEvents := mocks.NewEventRepository(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(locked).Once()
+		mockRepo.On("FindDrift").Return(drifts, int64(1), nil).Once()
+		mockEvents.On("RecountSeats", drifts[0].EventID.String()).Return(nil, 0, gorm.ErrRecordNotFound).Once()
+		mockRepo.On("CreateReport", mock.Anything).Return(nil).Once()
+
+		report, err := NewService(mockRepo, mockEvents).ReconcileSeatsService(entity.ReconciliationTriggerAdmin, true)
+
+		assert.NoError(t, err)
+		assert.Zero(t, report.CorrectedCount)
+	})
+
+	t.Run("another reconciliation is running", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(false, nil).Once()
+
+		_, err := NewService(mockRepo, nil).ReconcileSeatsService(entity.ReconciliationTriggerAdmin, true)
+
+		assert.ErrorIs(t, err, ErrRunning)
+	})
+
+	t.Run("find drift failed", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(locked).Once()
+		mockRepo.On("FindDrift").Return(nil, int64(0), assert.AnError).Once()
+
+		_, err := NewService(mockRepo, nil).ReconcileSeatsService(entity.ReconciliationTriggerSchedule, true)
+
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestFindReport(t *testing.T) {
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("Find", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := NewService(mockRepo, nil).FindReportService("missing")
+
+	assert.ErrorIs(t, err, ErrReportNotFound)
+}
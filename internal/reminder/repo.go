@@ -0,0 +1,75 @@
+package reminder
+
+import (
+	"event-booking/internal/entity"
+	"event-booking/internal/postgres"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lockName is the advisory lock that keeps replicas from scanning at the same
+// time.
+const lockName = "event_reminders"
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+func (r *repo) Lock(fn func() error) (bool, error) {
+	return postgres.WithAdvisoryLock(r.db, lockName, func(*gorm.DB) error {
+		return fn()
+	})
+}
+
+// FindUpcomingEvents returns the published events starting after from and
+// no later than to.
+func (r *repo) FindUpcomingEvents(from, to time.Time) ([]entity.Event, error) {
+	var events []entity.Event
+	err := r.db.Where("status = ? AND start_date > ? AND start_date <= ?", entity.EventStatusPublished, from, to).
+		Order("start_date").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// FindHolders returns every user with a confirmed booking for the event.
+func (r *repo) FindHolders(eventID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.Model(&entity.Booking{}).
+		Distinct("user_id").
+		Where("event_id = ? AND status = ?", eventID, entity.BookingStatusConfirmed).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+// RecordReminder stores the reminder unless it was recorded before and
+// reports whether it was new.
+func (r *repo) RecordReminder(reminder *entity.EventReminder) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *repo) DeleteReminder(reminder *entity.EventReminder) error {
+	return r.db.Where("event_id = ? AND user_id = ? AND offset_minutes = ?", reminder.EventID, reminder.UserID, reminder.OffsetMinutes).
+		Delete(&entity.EventReminder{}).Error
+}
@@ -0,0 +1,167 @@
+package reminder
+
+import (
+	"context"
+	"event-booking/internal/entity"
+	"event-booking/internal/notification"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	Lock(fn func() error) (bool, error)
+	FindUpcomingEvents(from, to time.Time) ([]entity.Event, error)
+	FindHolders(eventID uuid.UUID) ([]uuid.UUID, error)
+	RecordReminder(reminder *entity.EventReminder) (bool, error)
+	DeleteReminder(reminder *entity.EventReminder) error
+}
+
+//go:generate mockery --case snake --name Notifier
+type Notifier interface {
+	Deliver(notification notification.Notification) error
+}
+
+// Scheduler reminds booking holders of their events at fixed offsets before
+// the start, e.g. a week and a day before.
+type Scheduler struct {
+	repo     Repository
+	notifier Notifier
+	offsets  []time.Duration
+}
+
+func NewScheduler(repo Repository, notifier Notifier, offsets []time.Duration) *Scheduler {
+	offsets = slices.Clone(offsets)
+	slices.Sort(offsets)
+
+	return &Scheduler{
+		repo:     repo,
+		notifier: notifier,
+		offsets:  offsets,
+	}
+}
+
+// Run sends due reminders every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SendDueReminders(time.Now()); err != nil {
+			log.Error().Err(err).Msg("failed to send event reminders")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDueReminders reminds every holder of an event that starts within one
+// of the offsets of now, once per offset. When the scheduler was down or the
+// booking was made late only the closest offset is sent, so nobody gets a
+// week-ahead and a day-ahead reminder at once. It returns how many reminders
+// were sent; while another replica holds the lock it sends none.
+func (s *Scheduler) SendDueReminders(now time.Time) (int, error) {
+	if len(s.offsets) == 0 {
+		return 0, nil
+	}
+
+	var sent int
+	acquired, err := s.repo.Lock(func() error {
+		var err error
+		sent, err = s.sendDue(now)
+		return err
+	})
+	if err != nil {
+		return sent, err
+	}
+	if !acquired {
+		log.Debug().Msg("event reminders are being sent by another scheduler")
+	}
+
+	return sent, nil
+}
+
+func (s *Scheduler) sendDue(now time.Time) (int, error) {
+	events, err := s.repo.FindUpcomingEvents(now, now.Add(s.offsets[len(s.offsets)-1]))
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	for _, event := range events {
+		offset, ok := s.closestDueOffset(event.StartDate, now)
+		if !ok {
+			continue
+		}
+
+		holders, err := s.repo.FindHolders(event.ID)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, userID := range holders {
+			reminded, err := s.remind(event, userID, offset, now)
+			if err != nil {
+				return sent, err
+			}
+			if reminded {
+				sent++
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// closestDueOffset returns the smallest offset whose reminder time has
+// passed.
+func (s *Scheduler) closestDueOffset(start, now time.Time) (time.Duration, bool) {
+	for _, offset := range s.offsets {
+		if !now.Before(start.Add(-offset)) {
+			return offset, true
+		}
+	}
+
+	return 0, false
+}
+
+// remind records the reminder before delivering it, so a concurrent or
+// restarted scheduler cannot send it again. A failed delivery removes the
+// record to be retried on the next run; only errors from the database abort
+// the run.
+func (s *Scheduler) remind(event entity.Event, userID uuid.UUID, offset time.Duration, now time.Time) (bool, error) {
+	reminder := &entity.EventReminder{
+		EventID:       event.ID,
+		UserID:        userID,
+		OffsetMinutes: int(offset / time.Minute),
+		SentAt:        now,
+	}
+
+	recorded, err := s.repo.RecordReminder(reminder)
+	if err != nil || !recorded {
+		return false, err
+	}
+
+	err = s.notifier.Deliver(notification.Notification{
+		Type:   notification.TypeEventReminder,
+		UserID: userID,
+		Data: notification.EventReminderData{
+			EventName: event.Name,
+			Location:  event.Location,
+			StartDate: event.StartDate,
+		},
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("failed to remind user %s of event %s", userID, event.ID)
+		return false, s.repo.DeleteReminder(reminder)
+	}
+
+	return true, nil
+}
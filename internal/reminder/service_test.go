@@ -0,0 +1,116 @@
+package reminder
+
+import (
+	"errors"
+	"event-booking/internal/entity"
+	"event-booking/internal/notification"
+	"event-booking/internal/reminder/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func locked(fn func() error) (bool, error) {
+	return true, fn()
+}
+
+func TestSendDueReminders(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{24 * time.Hour, 7 * 24 * time.Hour}
+	window := now.Add(7 * 24 * time.Hour)
+
+	event := entity.Event{ID: uuid.New(), Name: "Jazz Night", Location: "Blue Note", StartDate: now.Add(20 * time.Hour)}
+	alice, bob := uuid.New(), uuid.New()
+
+	isReminder := func(userID uuid.UUID) interface{} {
+		return mock.MatchedBy(func(reminder *entity.EventReminder) bool {
+			return reminder.EventID == event.ID && reminder.UserID == userID && reminder.OffsetMinutes == 24*60
+		})
+	}
+
+	t.Run("holders get the closest due reminder", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(locked).Once()
+		mockRepo.On("FindUpcomingEvents", now, window).Return([]entity.Event{event}, nil).Once()
+		mockRepo.On("FindHolders", event.ID).Return([]uuid.UUID{alice, bob}, nil).Once()
+		mockRepo.On("RecordReminder", isReminder(alice)).Return(true, nil).Once()
+		mockRepo.On("RecordReminder", isReminder(bob)).Return(true, nil).Once()
+		mockNotifier.On("Deliver", mock.MatchedBy(func(n notification.Notification) bool {
+			data, ok := n.Data.(notification.EventReminderData)
+			return n.Type == notification.TypeEventReminder && ok && data.EventName == "Jazz Night" && data.StartDate.Equal(event.StartDate)
+		})).Return(nil).Twice()
+
+		sent, err := NewScheduler(mockRepo, mockNotifier, offsets).SendDueReminders(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+	})
+
+	t.Run("recorded reminders are not sent again", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(locked).Once()
+		mockRepo.On("FindUpcomingEvents", now, window).Return([]entity.Event{event}, nil).Once()
+		mockRepo.On("FindHolders", event.ID).Return([]uuid.UUID{alice}, nil).Once()
+		mockRepo.On("RecordReminder", isReminder(alice)).Return(false, nil).Once()
+
+		sent, err := NewScheduler(mockRepo, mockNotifier, offsets).SendDueReminders(now)
+
+		assert.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("failed delivery is retried on the next run", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(locked).Once()
+		mockRepo.On("FindUpcomingEvents", now, window).Return([]entity.Event{event}, nil).Once()
+		mockRepo.On("FindHolders", event.ID).Return([]uuid.UUID{alice}, nil).Once()
+		mockRepo.On("RecordReminder", isReminder(alice)).Return(true, nil).Once()
+		mockNotifier.On("Deliver", mock.Anything).Return(errors.New("user not found")).Once()
+		mockRepo.On("DeleteReminder", isReminder(alice)).Return(nil).Once()
+
+		sent, err := NewScheduler(mockRepo, mockNotifier, offsets).SendDueReminders(now)
+
+		assert.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("events further out get the week reminder", func(t *testing.T) {
+		later := event
+		later.StartDate = now.Add(3 * 24 * time.Hour)
+
+		mockRepo := mocks.NewRepository(t)
+		mockNotifier := mocks.NewNotifier(t)
+
+		mockRepo.On("Lock", mock.Anything).Return(locked).Once()
+		mockRepo.On("FindUpcomingEvents", now, window).Return([]entity.Event{later}, nil).Once()
+		mockRepo.On("FindHolders", event.ID).Return([]uuid.UUID{alice}, nil).Once()
+		mockRepo.On("RecordReminder", mock.MatchedBy(func(reminder *entity.EventReminder) bool {
+			return reminder.UserID == alice && reminder.OffsetMinutes == 7*24*60
+		})).Return(true, nil).Once()
+		mockNotifier.On("Deliver", mock.Anything).Return(nil).Once()
+
+		sent, err := NewScheduler(mockRepo, mockNotifier, offsets).SendDueReminders(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("another replica holds the lock", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Lock", mock.Anything).Return(false, nil).Once()
+
+		sent, err := NewScheduler(mockRepo, mocks.NewNotifier(t), offsets).SendDueReminders(now)
+
+		assert.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+}
@@ -1,8 +1,12 @@
 package review
 
 import (
+	"event-booking/internal/api/query"
 	"event-booking/internal/api/responses"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/api/validator"
+	"event-booking/internal/apperror"
+	"event-booking/internal/auth"
 	"event-booking/internal/entity"
 
 	"github.com/gofiber/fiber/v2"
@@ -26,16 +30,33 @@ type ReviewPayload struct {
 	UserID  uuid.UUID `json:"user_id" validate:"required"`
 	Review  string    `json:"review" validate:"required"`
 	Rating  int       `json:"rating" validate:"required,min=1,max=5"`
+	// Override skips the attendance and event end checks, admins only
+	Override bool `json:"override"`
 }
 
+var (
+	ErrReviewForOther    = apperror.Forbidden("review_for_other_user", "users can only review as themselves")
+	ErrOverrideForbidden = apperror.Forbidden("review_override_forbidden", "only admins can override review eligibility")
+	ErrReasonRequired    = apperror.Validation("reason_required", "rejecting a review needs a reason")
+)
+
 func (h *httpHandler) CreateReviewHandler(c *fiber.Ctx) error {
 	review := new(ReviewPayload)
 	if err := c.BodyParser(review); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(review); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
+	}
+
+	if c.Locals("role") != "admin" {
+		if review.UserID.String() != c.Locals("userID") {
+			return ErrReviewForOther
+		}
+		if review.Override {
+			return ErrOverrideForbidden
+		}
 	}
 
 	newReview := &entity.Review{
@@ -45,100 +66,99 @@ func (h *httpHandler) CreateReviewHandler(c *fiber.Ctx) error {
 		Rating:  review.Rating,
 	}
 
-	createdReview, err := h.svc.CreateReviewService(newReview)
+	createdReview, err := h.svc.CreateReviewService(newReview, review.Override)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
-	reviewResponse := responses.ReviewResponseObject{
-		ID:        createdReview.ID,
-		EventID:   createdReview.EventID,
-		UserID:    createdReview.UserID,
-		Review:    createdReview.Review,
-		Rating:    createdReview.Rating,
-		CreatedAt: createdReview.CreatedAt,
-		UpdatedAt: createdReview.UpdatedAt,
-	}
+	reviewResponse := newReviewResponse(createdReview)
 
 	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Review created", reviewResponse))
 }
 
 func (h *httpHandler) UpdateReviewHandler(c *fiber.Ctx) error {
-	id := c.Params("id")
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return ErrReviewNotFound
+	}
+
 	review := new(ReviewPayload)
 	if err := c.BodyParser(review); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse("Bad Request"))
+		return validator.ErrInvalidBody
 	}
 
 	if err := h.validator.ValidateStruct(review); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	newReview := &entity.Review{
-		ID:      uuid.MustParse(id),
+		ID:      id,
 		EventID: review.EventID,
 		UserID:  review.UserID,
 		Review:  review.Review,
 		Rating:  review.Rating,
 	}
 
-	updatedReview, err := h.svc.SaveReviewService(newReview)
+	editorID, err := callerID(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
-	reviewResponse := responses.ReviewResponseObject{
-		ID:        updatedReview.ID,
-		EventID:   updatedReview.EventID,
-		UserID:    updatedReview.UserID,
-		Review:    updatedReview.Review,
-		Rating:    updatedReview.Rating,
-		CreatedAt: updatedReview.CreatedAt,
-		UpdatedAt: updatedReview.UpdatedAt,
+	updatedReview, err := h.svc.SaveReviewService(newReview, editorID, c.Locals("role") == "admin")
+	if err != nil {
+		return err
 	}
 
+	reviewResponse := newReviewResponse(updatedReview)
+
 	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Review updated", reviewResponse))
 }
 
+var listQueryConfig = query.Config{
+	Sorts:       []string{"created_at", "rating", "helpfulness"},
+	Filters:     []string{"rating"},
+	DefaultSort: "-created_at",
+}
+
 func (h *httpHandler) FindAllReviewHandler(c *fiber.Ctx) error {
-	reviews, err := h.svc.FindAllReviewService()
+	opts, err := query.Parse(c, listQueryConfig)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse(err.Error()))
+		return err
+	}
+
+	var reviews []entity.Review
+	var total int64
+	if canModerate(c) {
+		reviews, total, err = h.svc.FindAllReviewService(tenant.FromContext(c), opts)
+	} else {
+		reviews, total, err = h.svc.FindApprovedReviewService(opts)
+	}
+	if err != nil {
+		return err
 	}
 
 	var reviewResponses []responses.ReviewResponseObject
 	for _, review := range reviews {
-		reviewResponses = append(reviewResponses, responses.ReviewResponseObject{
-			ID:        review.ID,
-			EventID:   review.EventID,
-			UserID:    review.UserID,
-			Review:    review.Review,
-			Rating:    review.Rating,
-			CreatedAt: review.CreatedAt,
-			UpdatedAt: review.UpdatedAt,
-		})
+		reviewResponses = append(reviewResponses, newReviewResponse(&review))
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Reviews found", reviewResponses))
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Reviews found", reviewResponses, opts.Pagination(total)))
 }
 
 func (h *httpHandler) FindReviewHandler(c *fiber.Ctx) error {
 	id := c.Params("id")
 	review, err := h.svc.FindReviewService(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(responses.NewErrorResponse("Review not found"))
+		return err
 	}
 
-	reviewResponse := responses.ReviewResponseObject{
-		ID:        review.ID,
-		EventID:   review.EventID,
-		UserID:    review.UserID,
-		Review:    review.Review,
-		Rating:    review.Rating,
-		CreatedAt: review.CreatedAt,
-		UpdatedAt: review.UpdatedAt,
+	// authors and admins can follow a review through moderation
+	if review.Status != entity.ReviewStatusApproved && c.Locals("role") != "admin" && review.UserID.String() != c.Locals("userID") {
+		return ErrReviewNotFound
 	}
 
+	reviewResponse := newReviewResponse(review)
+
 	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Review found", reviewResponse))
 }
 
@@ -149,13 +169,20 @@ type CustomReviewEventResponse struct {
 
 func (h *httpHandler) FindReviewByEventIDHandler(c *fiber.Ctx) error {
 	eventID := c.Params("id")
-	reviews, err := h.svc.FindReviewByEventIDService(eventID)
+	opts, err := query.Parse(c, listQueryConfig)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
-	res := CustomReviewEventResponse{
-		Event: responses.EventResponseObject{
+	reviews, total, err := h.svc.FindReviewByEventIDService(eventID, opts)
+	if err != nil {
+		return err
+	}
+
+	// a page past the end has no rows to take the event from
+	var res CustomReviewEventResponse
+	if len(reviews) > 0 {
+		res.Event = responses.EventResponseObject{
 			ID:            reviews[0].Event.ID,
 			Name:          reviews[0].Event.Name,
 			Location:      reviews[0].Event.Location,
@@ -165,22 +192,14 @@ func (h *httpHandler) FindReviewByEventIDHandler(c *fiber.Ctx) error {
 			TotalSeat:     reviews[0].Event.TotalSeat,
 			AvailableSeat: reviews[0].Event.AvailableSeat,
 			Category:      reviews[0].Event.Category,
-		},
+		}
 	}
 
 	for _, review := range reviews {
-		res.Reviews = append(res.Reviews, responses.ReviewResponseObject{
-			ID:        review.ID,
-			EventID:   review.EventID,
-			UserID:    review.UserID,
-			Review:    review.Review,
-			Rating:    review.Rating,
-			CreatedAt: review.CreatedAt,
-			UpdatedAt: review.UpdatedAt,
-		})
+		res.Reviews = append(res.Reviews, newReviewResponse(&review))
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Reviews found", res))
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Reviews found", res, opts.Pagination(total)))
 }
 
 type CustomReviewUserResponse struct {
@@ -190,41 +209,313 @@ type CustomReviewUserResponse struct {
 
 func (h *httpHandler) FindReviewByUserIDHandler(c *fiber.Ctx) error {
 	userID := c.Params("id")
-	reviews, err := h.svc.FindReviewByUserIDService(userID)
+	opts, err := query.Parse(c, listQueryConfig)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
-	res := CustomReviewUserResponse{
-		User: responses.UserResponseObject{
+	reviews, total, err := h.svc.FindReviewByUserIDService(userID, opts)
+	if err != nil {
+		return err
+	}
+
+	var res CustomReviewUserResponse
+	if len(reviews) > 0 {
+		res.User = responses.UserResponseObject{
 			ID:    reviews[0].User.ID,
 			Name:  reviews[0].User.Name,
 			Email: reviews[0].User.Email,
 			Role:  reviews[0].User.Role,
-		},
+		}
 	}
 
 	for _, review := range reviews {
-		res.Reviews = append(res.Reviews, responses.ReviewResponseObject{
-			ID:        review.ID,
-			EventID:   review.EventID,
-			UserID:    review.UserID,
-			Review:    review.Review,
-			Rating:    review.Rating,
-			CreatedAt: review.CreatedAt,
-			UpdatedAt: review.UpdatedAt,
-		})
+		res.Reviews = append(res.Reviews, newReviewResponse(&review))
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Reviews found", res))
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Reviews found", res, opts.Pagination(total)))
 }
 
 func (h *httpHandler) DeleteReviewHandler(c *fiber.Ctx) error {
 	id := c.Params("id")
-	err := h.svc.DeleteReviewService(id)
+	err := h.svc.DeleteReviewService(tenant.FromContext(c), id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(responses.NewErrorResponse(err.Error()))
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Review deleted", nil))
 }
+
+type ReportPayload struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+func (h *httpHandler) ReportReviewHandler(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return ErrReviewNotFound
+	}
+
+	userID, err := callerID(c)
+	if err != nil {
+		return err
+	}
+
+	payload := new(ReportPayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	report, err := h.svc.ReportReviewService(&entity.ReviewReport{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Reason:   payload.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Review reported", newReportResponse(report)))
+}
+
+var moderationQueryConfig = query.Config{
+	Sorts:       []string{"created_at", "updated_at", "rating"},
+	Filters:     []string{"status", "event_id"},
+	DefaultSort: "created_at",
+}
+
+// FindModerationQueueHandler lists the reviews waiting for a decision,
+// oldest first.
+func (h *httpHandler) FindModerationQueueHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, moderationQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	reviews, total, err := h.svc.FindModerationQueueService(opts)
+	if err != nil {
+		return err
+	}
+
+	queue := []responses.ModerationReviewResponseObject{}
+	for _, review := range reviews {
+		item := responses.ModerationReviewResponseObject{
+			ReviewResponseObject: newReviewResponse(&review),
+			Reports:              []responses.ReviewReportResponseObject{},
+		}
+		for _, report := range review.Reports {
+			item.Reports = append(item.Reports, newReportResponse(&report))
+		}
+		queue = append(queue, item)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Reviews found", queue, opts.Pagination(total)))
+}
+
+type ModerationPayload struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+func (h *httpHandler) ApproveReviewHandler(c *fiber.Ctx) error {
+	return h.moderate(c, entity.ReviewStatusApproved, "Review approved")
+}
+
+// RejectReviewHandler hides a review; unlike approving, it needs a reason
+// the author can see.
+func (h *httpHandler) RejectReviewHandler(c *fiber.Ctx) error {
+	return h.moderate(c, entity.ReviewStatusRejected, "Review rejected")
+}
+
+func (h *httpHandler) moderate(c *fiber.Ctx, status, message string) error {
+	moderatorID, err := callerID(c)
+	if err != nil {
+		return err
+	}
+
+	payload := new(ModerationPayload)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(payload); err != nil {
+			return validator.ErrInvalidBody
+		}
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+	if status == entity.ReviewStatusRejected && payload.Reason == "" {
+		return ErrReasonRequired
+	}
+
+	review, err := h.svc.ModerateReviewService(c.Params("id"), status, payload.Reason, moderatorID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse(message, newReviewResponse(review)))
+}
+
+type VotePayload struct {
+	Helpful *bool `json:"helpful" validate:"required"`
+}
+
+// VoteReviewHandler records the caller's vote; voting again changes it.
+func (h *httpHandler) VoteReviewHandler(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return ErrReviewNotFound
+	}
+
+	userID, err := callerID(c)
+	if err != nil {
+		return err
+	}
+
+	payload := new(VotePayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	vote, err := h.svc.VoteReviewService(&entity.ReviewVote{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Helpful:  *payload.Helpful,
+	})
+	if err != nil {
+		return err
+	}
+
+	voteResponse := responses.ReviewVoteResponseObject{
+		ReviewID:  vote.ReviewID,
+		UserID:    vote.UserID,
+		Helpful:   vote.Helpful,
+		UpdatedAt: vote.UpdatedAt,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Vote saved", voteResponse))
+}
+
+func (h *httpHandler) DeleteVoteHandler(c *fiber.Ctx) error {
+	userID, err := callerID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.svc.DeleteVoteService(c.Params("id"), userID.String()); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Vote deleted", nil))
+}
+
+type ReplyPayload struct {
+	Reply string `json:"reply" validate:"required,max=2000"`
+}
+
+// ReplyReviewHandler creates or replaces the organizer's public reply.
+func (h *httpHandler) ReplyReviewHandler(c *fiber.Ctx) error {
+	reviewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return ErrReviewNotFound
+	}
+
+	userID, err := callerID(c)
+	if err != nil {
+		return err
+	}
+
+	payload := new(ReplyPayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	reply, err := h.svc.ReplyReviewService(tenant.FromContext(c), &entity.ReviewReply{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Reply:    payload.Reply,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Reply saved", newReplyResponse(reply)))
+}
+
+func (h *httpHandler) DeleteReplyHandler(c *fiber.Ctx) error {
+	if err := h.svc.DeleteReplyService(tenant.FromContext(c), c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Reply deleted", nil))
+}
+
+func callerID(c *fiber.Ctx) (uuid.UUID, error) {
+	id, _ := c.Locals("userID").(string)
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, auth.ErrUnauthorized
+	}
+
+	return userID, nil
+}
+
+// canModerate reports whether the caller is a platform admin or an
+// organizer on an organizer route; everyone else only sees approved reviews.
+func canModerate(c *fiber.Ctx) bool {
+	return c.Locals("role") == "admin" || !tenant.FromContext(c).Global()
+}
+
+func newReviewResponse(review *entity.Review) responses.ReviewResponseObject {
+	res := responses.ReviewResponseObject{
+		ID:               review.ID,
+		EventID:          review.EventID,
+		UserID:           review.UserID,
+		Review:           review.Review,
+		Rating:           review.Rating,
+		Status:           review.Status,
+		ModerationReason: review.ModerationReason,
+		HelpfulCount:     review.HelpfulCount,
+		UnhelpfulCount:   review.UnhelpfulCount,
+		Helpfulness:      review.Helpfulness,
+		CreatedAt:        review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt,
+	}
+	if review.Reply != nil {
+		reply := newReplyResponse(review.Reply)
+		res.Reply = &reply
+	}
+
+	return res
+}
+
+func newReplyResponse(reply *entity.ReviewReply) responses.ReviewReplyResponseObject {
+	return responses.ReviewReplyResponseObject{
+		ID:        reply.ID,
+		ReviewID:  reply.ReviewID,
+		UserID:    reply.UserID,
+		Reply:     reply.Reply,
+		CreatedAt: reply.CreatedAt,
+		UpdatedAt: reply.UpdatedAt,
+	}
+}
+
+func newReportResponse(report *entity.ReviewReport) responses.ReviewReportResponseObject {
+	return responses.ReviewReportResponseObject{
+		ID:        report.ID,
+		ReviewID:  report.ReviewID,
+		UserID:    report.UserID,
+		Reason:    report.Reason,
+		CreatedAt: report.CreatedAt,
+	}
+}
@@ -0,0 +1,119 @@
+package review
+
+import (
+	"event-booking/internal/entity"
+	"strings"
+	"unicode"
+)
+
+// Verdict is the outcome of moderating a review: approved reviews go live,
+// flagged ones wait in the admin queue and rejected ones stay hidden.
+type Verdict struct {
+	Status string
+	Reason string
+}
+
+// Moderator screens a review before it is published. Reviews a moderator
+// fails on are kept pending for an admin to decide.
+type Moderator interface {
+	Moderate(review *entity.Review) (Verdict, error)
+}
+
+// DefaultBlockedWords are rejected by the word list moderator unless it is
+// given its own list.
+var DefaultBlockedWords = []string{
+	"asshole", "bastard", "bitch", "bullshit", "cunt", "dickhead", "fuck", "fucking", "motherfucker", "shit",
+}
+
+const (
+	maxRepeatedRunes = 6
+	minShoutLetters  = 20
+	shoutRatio       = 0.7
+	minRepeatedWords = 8
+)
+
+// WordListModerator rejects reviews containing a blocked word and flags
+// reviews that look like spam: links, long runs of the same character,
+// mostly upper case text or one word making up most of the review.
+type WordListModerator struct {
+	blocked map[string]struct{}
+}
+
+func NewWordListModerator(words []string) *WordListModerator {
+	if len(words) == 0 {
+		words = DefaultBlockedWords
+	}
+
+	blocked := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			blocked[word] = struct{}{}
+		}
+	}
+
+	return &WordListModerator{blocked: blocked}
+}
+
+func (m *WordListModerator) Moderate(review *entity.Review) (Verdict, error) {
+	text := review.Review
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		if _, ok := m.blocked[word]; ok {
+			return Verdict{Status: entity.ReviewStatusRejected, Reason: "contains blocked language"}, nil
+		}
+	}
+
+	if reason := spamReason(text, words); reason != "" {
+		return Verdict{Status: entity.ReviewStatusFlagged, Reason: reason}, nil
+	}
+
+	return Verdict{Status: entity.ReviewStatusApproved}, nil
+}
+
+func spamReason(text string, words []string) string {
+	lower := strings.ToLower(text)
+	if strings.Contains(lower, "http://") || strings.Contains(lower, "https://") || strings.Contains(lower, "www.") {
+		return "contains links"
+	}
+
+	var prev rune
+	run := 0
+	for _, r := range lower {
+		if r == prev && !unicode.IsSpace(r) {
+			run++
+			if run >= maxRepeatedRunes {
+				return "repeated characters"
+			}
+			continue
+		}
+		prev, run = r, 1
+	}
+
+	var letters, upper int
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters >= minShoutLetters && float64(upper) > shoutRatio*float64(letters) {
+		return "mostly upper case"
+	}
+
+	if len(words) >= minRepeatedWords {
+		counts := make(map[string]int, len(words))
+		for _, word := range words {
+			counts[word]++
+			if counts[word]*2 > len(words) {
+				return "repeated words"
+			}
+		}
+	}
+
+	return ""
+}
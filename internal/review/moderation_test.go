@@ -0,0 +1,41 @@
+package review
+
+import (
+	"event-booking/internal/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordListModerator(t *testing.T) {
+	tests := []struct {
+		name   string
+		words  []string
+		text   string
+		status string
+	}{
+		{name: "clean review", text: "Great talks and friendly people, would go again.", status: entity.ReviewStatusApproved},
+		{name: "blocked word", text: "The sound was shit.", status: entity.ReviewStatusRejected},
+		{name: "blocked word in any case", text: "Total BullShit", status: entity.ReviewStatusRejected},
+		{name: "blocked word inside another word", text: "Parking was in Scunthorpe", status: entity.ReviewStatusApproved},
+		{name: "custom word list", words: []string{"boring"}, text: "So boring", status: entity.ReviewStatusRejected},
+		{name: "custom list replaces the default", words: []string{"boring"}, text: "The sound was shit.", status: entity.ReviewStatusApproved},
+		{name: "link", text: "Cheap tickets at https://example.com", status: entity.ReviewStatusFlagged},
+		{name: "repeated characters", text: "Amazing!!!!!!!!", status: entity.ReviewStatusFlagged},
+		{name: "shouting", text: "THIS WAS THE BEST EVENT EVER", status: entity.ReviewStatusFlagged},
+		{name: "short upper case", text: "WOW, loved it", status: entity.ReviewStatusApproved},
+		{name: "repeated words", text: "buy buy buy buy buy now buy cheap", status: entity.ReviewStatusFlagged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, err := NewWordListModerator(tt.words).Moderate(&entity.Review{Review: tt.text})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, verdict.Status)
+			if tt.status != entity.ReviewStatusApproved {
+				assert.NotEmpty(t, verdict.Reason)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package review
 
 import (
+	"database/sql"
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
 	"event-booking/internal/entity"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type repo struct {
@@ -17,7 +22,14 @@ func NewRepository(db *gorm.DB) *repo {
 }
 
 func (r *repo) Create(review *entity.Review) (*entity.Review, error) {
-	if err := r.db.Create(review).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+
+		return RefreshRating(tx, review.EventID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -25,54 +37,299 @@ func (r *repo) Create(review *entity.Review) (*entity.Review, error) {
 }
 
 func (r *repo) Save(review *entity.Review) (*entity.Review, error) {
-	if err := r.db.Save(review).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+
+		return RefreshRating(tx, review.EventID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return review, nil
 }
 
-func (r *repo) FindAll() ([]entity.Review, error) {
+// RefreshRating recomputes the rating aggregates of an event from its
+// approved, not deleted reviews. The event row is locked first so the
+// recount of concurrent review changes starts after theirs has committed and
+// sees it. The trash repository calls it when it restores or purges reviews.
+func RefreshRating(tx *gorm.DB, eventID uuid.UUID) error {
+	if err := tx.Exec("SELECT id FROM events WHERE id = ? FOR UPDATE", eventID).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`UPDATE events SET
+		(rating, rating_count, rating1_count, rating2_count, rating3_count, rating4_count, rating5_count) = (
+			SELECT COALESCE(ROUND(AVG(rating), 2), 0), COUNT(*),
+				COUNT(*) FILTER (WHERE rating = 1), COUNT(*) FILTER (WHERE rating = 2),
+				COUNT(*) FILTER (WHERE rating = 3), COUNT(*) FILTER (WHERE rating = 4),
+				COUNT(*) FILTER (WHERE rating = 5)
+			FROM reviews WHERE event_id = @event AND status = @approved AND deleted_at IS NULL
+		)
+		WHERE id = @event`, sql.Named("event", eventID), sql.Named("approved", entity.ReviewStatusApproved)).Error
+}
+
+func (r *repo) FindAll(scope tenant.Scope, opts query.Options) ([]entity.Review, int64, error) {
+	return r.findPage(r.db.Model(&entity.Review{}).Scopes(scope.EventRows), opts)
+}
+
+// FindByUserIDWithDeleted returns every review of a user, soft deleted ones
+// included, for the personal data export.
+func (r *repo) FindByUserIDWithDeleted(userID string) ([]entity.Review, error) {
 	var reviews []entity.Review
-	if err := r.db.Find(&reviews).Error; err != nil {
+	if err := r.db.Unscoped().Where("user_id = ?", userID).Find(&reviews).Error; err != nil {
 		return nil, err
 	}
 
 	return reviews, nil
 }
 
+// approved limits a query to reviews everyone may see.
+func approved(db *gorm.DB) *gorm.DB {
+	return db.Where("status = ?", entity.ReviewStatusApproved)
+}
+
+func (r *repo) FindApproved(opts query.Options) ([]entity.Review, int64, error) {
+	return r.findPage(r.db.Model(&entity.Review{}).Scopes(approved), opts)
+}
+
 func (r *repo) Find(id string) (*entity.Review, error) {
 	var review entity.Review
-	if err := r.db.Where("id = ?", id).First(&review).Error; err != nil {
+	if err := r.db.Preload("Reply").Where("id = ?", id).First(&review).Error; err != nil {
 		return nil, err
 	}
 
 	return &review, nil
 }
 
-func (r *repo) FindByEventID(eventID string) ([]entity.Review, error) {
-	var reviews []entity.Review
-	if err := r.db.Preload("Event").Where("event_id = ?", eventID).Find(&reviews).Error; err != nil {
+func (r *repo) FindInScope(scope tenant.Scope, id string) (*entity.Review, error) {
+	var review entity.Review
+	if err := r.db.Preload("Reply").Scopes(scope.EventRows).Where("id = ?", id).First(&review).Error; err != nil {
 		return nil, err
 	}
 
-	return reviews, nil
+	return &review, nil
+}
+
+func (r *repo) FindByEventID(eventID string, opts query.Options) ([]entity.Review, int64, error) {
+	return r.findPage(r.db.Model(&entity.Review{}).Preload("Event").Scopes(approved).Where("event_id = ?", eventID), opts)
+}
+
+func (r *repo) FindByUserID(userID string, opts query.Options) ([]entity.Review, int64, error) {
+	return r.findPage(r.db.Model(&entity.Review{}).Preload("User").Scopes(approved).Where("user_id = ?", userID), opts)
+}
+
+// FindModerationQueue lists pending and flagged reviews with their open
+// reports.
+func (r *repo) FindModerationQueue(opts query.Options) ([]entity.Review, int64, error) {
+	db := r.db.Model(&entity.Review{}).
+		Preload("Reports", "resolved_at IS NULL").
+		Where("status IN ?", []string{entity.ReviewStatusPending, entity.ReviewStatusFlagged})
+
+	return r.findPage(db, opts)
 }
 
-func (r *repo) FindByUserID(userID string) ([]entity.Review, error) {
+func (r *repo) findPage(db *gorm.DB, opts query.Options) ([]entity.Review, int64, error) {
+	db = db.Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var reviews []entity.Review
-	if err := r.db.Preload("User").Where("user_id = ?", userID).Find(&reviews).Error; err != nil {
-		return nil, err
+	if err := db.Preload("Reply").Scopes(opts.Paginate).Find(&reviews).Error; err != nil {
+		return nil, 0, err
 	}
 
-	return reviews, nil
+	return reviews, total, nil
+}
+
+func (r *repo) HasConfirmedBooking(userID, eventID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.Booking{}).
+		Where("user_id = ? AND event_id = ? AND status = ?", userID, eventID, entity.BookingStatusConfirmed).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
 }
 
+// Delete soft deletes the review; its votes, reports and reply stay until
+// the review is purged.
 func (r *repo) Delete(id string) error {
-	err := r.db.Where("id = ?", id).First(&entity.Review{}).Delete(&entity.Review{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var review entity.Review
+		if err := tx.Where("id = ?", id).First(&review).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+
+		return RefreshRating(tx, review.EventID)
+	})
+}
+
+// Moderate stores a moderation decision, resolves the open reports of the
+// review and refreshes the rating of its event.
+func (r *repo) Moderate(review *entity.Review) (*entity.Review, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(review).Select("status", "moderation_reason", "moderated_at", "moderated_by").Updates(review).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entity.ReviewReport{}).
+			Where("review_id = ? AND resolved_at IS NULL", review.ID).
+			Update("resolved_at", review.ModeratedAt).Error
+		if err != nil {
+			return err
+		}
+
+		return RefreshRating(tx, review.EventID)
+	})
 	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *repo) CreateReport(report *entity.ReviewReport) (*entity.ReviewReport, error) {
+	if err := r.db.Create(report).Error; err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (r *repo) CountOpenReports(reviewID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.ReviewReport{}).
+		Where("review_id = ? AND resolved_at IS NULL", reviewID).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Flag moves an approved review into the moderation queue. It returns false
+// when the review was no longer approved, e.g. an admin decided meanwhile.
+func (r *repo) Flag(review *entity.Review) (bool, error) {
+	var flagged bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&entity.Review{}).
+			Where("id = ? AND status = ?", review.ID, entity.ReviewStatusApproved).
+			Updates(map[string]any{"status": entity.ReviewStatusFlagged, "moderation_reason": review.ModerationReason})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+
+		flagged = true
+		return RefreshRating(tx, review.EventID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return flagged, nil
+}
+
+// Vote stores the user's vote, replacing an earlier one, and recounts the
+// votes of the review.
+func (r *repo) Vote(vote *entity.ReviewVote) (*entity.ReviewVote, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "review_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"helpful", "updated_at"}),
+		}).Create(vote).Error
+		if err != nil {
+			return err
+		}
+
+		return RefreshVotes(tx, vote.ReviewID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return vote, nil
+}
+
+func (r *repo) DeleteVote(reviewID, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).Delete(&entity.ReviewVote{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		id, err := uuid.Parse(reviewID)
+		if err != nil {
+			return err
+		}
+
+		return RefreshVotes(tx, id)
+	})
+}
+
+// RefreshVotes recomputes the vote counts of a review, locking its row
+// first for the same reason as RefreshRating.
+func RefreshVotes(tx *gorm.DB, reviewID uuid.UUID) error {
+	if err := tx.Exec("SELECT id FROM reviews WHERE id = ? FOR UPDATE", reviewID).Error; err != nil {
 		return err
 	}
 
+	return tx.Exec(`UPDATE reviews SET
+		(helpful_count, unhelpful_count, helpfulness) = (
+			SELECT COUNT(*) FILTER (WHERE helpful), COUNT(*) FILTER (WHERE NOT helpful),
+				COUNT(*) FILTER (WHERE helpful) - COUNT(*) FILTER (WHERE NOT helpful)
+			FROM review_votes WHERE review_id = @review
+		)
+		WHERE id = @review`, sql.Named("review", reviewID)).Error
+}
+
+// SaveReply creates the reply of a review or replaces its text.
+func (r *repo) SaveReply(reply *entity.ReviewReply) (*entity.ReviewReply, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "review_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"user_id", "reply", "updated_at"}),
+		}).Create(reply).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("review_id = ?", reply.ReviewID).First(reply).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (r *repo) DeleteReply(reviewID string) error {
+	res := r.db.Where("review_id = ?", reviewID).Delete(&entity.ReviewReply{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
 	return nil
 }
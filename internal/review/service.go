@@ -1,46 +1,160 @@
 package review
 
 import (
+	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/tenant"
+	"event-booking/internal/apperror"
 	"event-booking/internal/entity"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
+var (
+	ErrReviewNotFound   = apperror.NotFound("review_not_found", "review not found")
+	ErrInvalidReference = apperror.Validation("invalid_reference", "event or user does not exist")
+	ErrEventNotFound    = apperror.NotFound("event_not_found", "event not found")
+	ErrNotAttended      = apperror.Forbidden("review_not_attended", "only users with a confirmed booking can review the event")
+	ErrEventNotEnded    = apperror.Conflict("review_event_not_ended", "the event can only be reviewed after it has ended")
+	ErrAlreadyReviewed  = apperror.Conflict("already_reviewed", "user has already reviewed this event")
+	ErrReportOwnReview  = apperror.Forbidden("report_own_review", "users cannot report their own review")
+	ErrAlreadyReported  = apperror.Conflict("already_reported", "user has already reported this review")
+	ErrVoteOwnReview    = apperror.Forbidden("vote_own_review", "users cannot vote on their own review")
+	ErrNotAuthor        = apperror.Forbidden("review_not_author", "users can only edit their own reviews")
+	ErrVoteNotFound     = apperror.NotFound("vote_not_found", "vote not found")
+	ErrReplyNotFound    = apperror.NotFound("reply_not_found", "reply not found")
+)
+
+//go:generate mockery --case snake --name Repository
 type Repository interface {
 	Create(review *entity.Review) (*entity.Review, error)
 	Save(review *entity.Review) (*entity.Review, error)
-	FindAll() ([]entity.Review, error)
+	FindAll(scope tenant.Scope, opts query.Options) ([]entity.Review, int64, error)
 	Find(id string) (*entity.Review, error)
-	FindByEventID(eventID string) ([]entity.Review, error)
-	FindByUserID(userID string) ([]entity.Review, error)
+	FindInScope(scope tenant.Scope, id string) (*entity.Review, error)
+	FindByEventID(eventID string, opts query.Options) ([]entity.Review, int64, error)
+	FindByUserID(userID string, opts query.Options) ([]entity.Review, int64, error)
+	FindApproved(opts query.Options) ([]entity.Review, int64, error)
+	FindModerationQueue(opts query.Options) ([]entity.Review, int64, error)
 	Delete(id string) error
+	HasConfirmedBooking(userID, eventID string) (bool, error)
+	Moderate(review *entity.Review) (*entity.Review, error)
+	Flag(review *entity.Review) (bool, error)
+	CreateReport(report *entity.ReviewReport) (*entity.ReviewReport, error)
+	CountOpenReports(reviewID string) (int64, error)
+	Vote(vote *entity.ReviewVote) (*entity.ReviewVote, error)
+	DeleteVote(reviewID, userID string) error
+	SaveReply(reply *entity.ReviewReply) (*entity.ReviewReply, error)
+	DeleteReply(reviewID string) error
+}
+
+//go:generate mockery --case snake --name EventRepository
+type EventRepository interface {
+	Find(id string) (*entity.Event, error)
 }
 
 type Service struct {
-	repo Repository
+	repo            Repository
+	eventRepository EventRepository
+	moderator       Moderator
+	reportThreshold int
 }
 
-func NewService(repo Repository) *Service {
+// NewService builds the review service; reportThreshold is the number of
+// open reports that sends an approved review back to moderation.
+func NewService(repo Repository, eventRepository EventRepository, moderator Moderator, reportThreshold int) *Service {
 	return &Service{
-		repo: repo,
+		repo:            repo,
+		eventRepository: eventRepository,
+		moderator:       moderator,
+		reportThreshold: reportThreshold,
 	}
 }
 
-func (s *Service) CreateReviewService(review *entity.Review) (*entity.Review, error) {
+// CreateReviewService stores a review by an attendee: the user needs a
+// confirmed booking and the event has to be over. override skips both
+// checks and is meant for admins; a user still reviews an event only once.
+// The review is published once the moderator approves it.
+func (s *Service) CreateReviewService(review *entity.Review, override bool) (*entity.Review, error) {
+	if !override {
+		if err := s.checkEligible(review.UserID.String(), review.EventID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	s.moderate(review)
+
 	review, err := s.repo.Create(review)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAlreadyReviewed
+		} else if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return nil, ErrInvalidReference
+		}
 		return nil, err
 	}
 
 	return review, nil
 }
 
-func (s *Service) SaveReviewService(review *entity.Review) (*entity.Review, error) {
-	_, err := s.repo.Find(review.ID.String())
+func (s *Service) checkEligible(userID, eventID string) error {
+	event, err := s.eventRepository.Find(eventID)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	attended, err := s.repo.HasConfirmedBooking(userID, eventID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+	if !attended {
+		return ErrNotAttended
+	}
+
+	if end := event.EndDate; !end.Before(time.Now()) {
+		return fmt.Errorf("%w: it ends at %s", ErrEventNotEnded, end.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// SaveReviewService edits the text and rating of a review for its author, or
+// any review for an admin. The event and author of a review never change, so
+// eligibility keeps holding. The new text goes through moderation again,
+// except that rejected and flagged reviews go back to pending for an admin.
+func (s *Service) SaveReviewService(review *entity.Review, editorID uuid.UUID, admin bool) (*entity.Review, error) {
+	existing, err := s.repo.Find(review.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrReviewNotFound)
+	}
+
+	if !admin && existing.UserID != editorID {
+		return nil, ErrNotAuthor
+	}
+
+	review.EventID = existing.EventID
+	review.UserID = existing.UserID
+	review.CreatedAt = existing.CreatedAt
+	review.ReviewVotes = existing.ReviewVotes
+	review.Reply = existing.Reply
+
+	// an admin or user reports held the review back, so the moderator does
+	// not get to overrule them: the edit waits for an admin
+	if existing.Status == entity.ReviewStatusRejected || existing.Status == entity.ReviewStatusFlagged {
+		review.Status = entity.ReviewStatusPending
+		review.ModerationReason = existing.ModerationReason
+		review.ModeratedAt = nil
+		review.ModeratedBy = nil
+	} else {
+		s.moderate(review)
 	}
 
 	review, err = s.repo.Save(review)
@@ -52,18 +166,159 @@ func (s *Service) SaveReviewService(review *entity.Review) (*entity.Review, erro
 	return review, nil
 }
 
-func (s *Service) FindAllReviewService() ([]entity.Review, error) {
-	reviews, err := s.repo.FindAll()
+func (s *Service) FindAllReviewService(scope tenant.Scope, opts query.Options) ([]entity.Review, int64, error) {
+	reviews, total, err := s.repo.FindAll(scope, opts)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return nil, err
+		return nil, 0, err
 	}
 
-	return reviews, nil
+	return reviews, total, nil
+}
+
+// moderate sets the status of a review from the moderator's verdict. When
+// the moderator fails the review waits for an admin instead of going live.
+func (s *Service) moderate(review *entity.Review) {
+	review.ModeratedAt = nil
+	review.ModeratedBy = nil
+
+	verdict, err := s.moderator.Moderate(review)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		review.Status = entity.ReviewStatusPending
+		review.ModerationReason = ""
+		return
+	}
+
+	review.Status = verdict.Status
+	review.ModerationReason = verdict.Reason
+}
+
+func (s *Service) FindApprovedReviewService(opts query.Options) ([]entity.Review, int64, error) {
+	reviews, total, err := s.repo.FindApproved(opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
 }
 
 func (s *Service) FindReviewService(id string) (*entity.Review, error) {
 	review, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrReviewNotFound)
+	}
+
+	return review, nil
+}
+
+func (s *Service) FindReviewByEventIDService(eventID string, opts query.Options) ([]entity.Review, int64, error) {
+	reviews, total, err := s.repo.FindByEventID(eventID, opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+func (s *Service) FindReviewByUserIDService(userID string, opts query.Options) ([]entity.Review, int64, error) {
+	reviews, total, err := s.repo.FindByUserID(userID, opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// DeleteReviewService soft deletes a review; organizers can only delete reviews
+// of their own events.
+func (s *Service) DeleteReviewService(scope tenant.Scope, id string) error {
+	if _, err := s.repo.FindInScope(scope, id); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return apperror.IfNotFound(err, ErrReviewNotFound)
+	}
+
+	err := s.repo.Delete(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// ReportReviewService records a user's report of a published review. Once
+// the review has reportThreshold open reports it is flagged for moderation
+// and hidden until an admin decides.
+func (s *Service) ReportReviewService(report *entity.ReviewReport) (*entity.ReviewReport, error) {
+	review, err := s.repo.Find(report.ReviewID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrReviewNotFound)
+	}
+	if review.Status != entity.ReviewStatusApproved {
+		return nil, ErrReviewNotFound
+	}
+	if review.UserID == report.UserID {
+		return nil, ErrReportOwnReview
+	}
+
+	report, err = s.repo.CreateReport(report)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAlreadyReported
+		}
+		return nil, err
+	}
+
+	reports, err := s.repo.CountOpenReports(review.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	if reports >= int64(s.reportThreshold) {
+		review.ModerationReason = fmt.Sprintf("reported by %d users", reports)
+		if _, err := s.repo.Flag(review); err != nil {
+			log.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+func (s *Service) FindModerationQueueService(opts query.Options) ([]entity.Review, int64, error) {
+	reviews, total, err := s.repo.FindModerationQueue(opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// ModerateReviewService records an admin's decision. Admins can approve or
+// reject a review in any status, which also resolves its open reports.
+func (s *Service) ModerateReviewService(id string, status, reason string, moderatorID uuid.UUID) (*entity.Review, error) {
+	review, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrReviewNotFound)
+	}
+
+	now := time.Now()
+	review.Status = status
+	review.ModerationReason = reason
+	review.ModeratedAt = &now
+	review.ModeratedBy = &moderatorID
+
+	review, err = s.repo.Moderate(review)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return nil, err
@@ -72,31 +327,67 @@ func (s *Service) FindReviewService(id string) (*entity.Review, error) {
 	return review, nil
 }
 
-func (s *Service) FindReviewByEventIDService(eventID string) ([]entity.Review, error) {
-	reviews, err := s.repo.FindByEventID(eventID)
+// VoteReviewService records whether a user found a published review
+// helpful. Voting again replaces the user's earlier vote.
+func (s *Service) VoteReviewService(vote *entity.ReviewVote) (*entity.ReviewVote, error) {
+	review, err := s.repo.Find(vote.ReviewID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrReviewNotFound)
+	}
+	if review.Status != entity.ReviewStatusApproved {
+		return nil, ErrReviewNotFound
+	}
+	if review.UserID == vote.UserID {
+		return nil, ErrVoteOwnReview
+	}
+
+	vote, err = s.repo.Vote(vote)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return nil, err
 	}
 
-	return reviews, nil
+	return vote, nil
 }
 
-func (s *Service) FindReviewByUserIDService(userID string) ([]entity.Review, error) {
-	reviews, err := s.repo.FindByUserID(userID)
+func (s *Service) DeleteVoteService(reviewID, userID string) error {
+	err := s.repo.DeleteVote(reviewID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return apperror.IfNotFound(err, ErrVoteNotFound)
+	}
+
+	return nil
+}
+
+// ReplyReviewService stores the organizer's public reply to a review of one
+// of their events, replacing an earlier reply.
+func (s *Service) ReplyReviewService(scope tenant.Scope, reply *entity.ReviewReply) (*entity.ReviewReply, error) {
+	if _, err := s.repo.FindInScope(scope, reply.ReviewID.String()); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrReviewNotFound)
+	}
+
+	reply, err := s.repo.SaveReply(reply)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
 		return nil, err
 	}
 
-	return reviews, nil
+	return reply, nil
 }
 
-func (s *Service) DeleteReviewService(id string) error {
-	err := s.repo.Delete(id)
+func (s *Service) DeleteReplyService(scope tenant.Scope, reviewID string) error {
+	if _, err := s.repo.FindInScope(scope, reviewID); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return apperror.IfNotFound(err, ErrReviewNotFound)
+	}
+
+	err := s.repo.DeleteReply(reviewID)
 	if err != nil {
 		log.Error().Err(err).Msg(err.Error())
-		return err
+		return apperror.IfNotFound(err, ErrReplyNotFound)
 	}
 
 	return nil
@@ -0,0 +1,382 @@
+package review
+
+import (
+	"errors"
+	"event-booking/internal/api/tenant"
+	"event-booking/internal/entity"
+	"event-booking/internal/review/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateReviewService(t *testing.T) {
+	ended := &entity.Event{ID: uuid.New(), EndDate: time.Now().Add(-time.Hour)}
+	upcoming := &entity.Event{ID: uuid.New(), EndDate: time.Now().Add(time.Hour)}
+	userID := uuid.New()
+
+	newReview := func(event *entity.Event) *entity.Review {
+		return &entity.Review{EventID: event.ID, UserID: userID, Review: "Great show", Rating: 5}
+	}
+
+	t.Run("attendee reviews an ended event", func(t *testing.T) {
+		review := newReview(ended)
+
+		mockRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockEventRepo.On("Find", ended.ID.String()).Return(ended, nil).Once()
+		mockRepo.On("HasConfirmedBooking", userID.String(), ended.ID.String()).Return(true, nil).Once()
+		mockRepo.On("Create", review).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mockEventRepo, NewWordListModerator(nil), 3)
+		_, err := svc.CreateReviewService(review, false)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("user without a confirmed booking", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockEventRepo.On("Find", ended.ID.String()).Return(ended, nil).Once()
+		mockRepo.On("HasConfirmedBooking", userID.String(), ended.ID.String()).Return(false, nil).Once()
+
+		svc := NewService(mockRepo, mockEventRepo, NewWordListModerator(nil), 3)
+		_, err := svc.CreateReviewService(newReview(ended), false)
+
+		assert.ErrorIs(t, err, ErrNotAttended)
+	})
+
+	t.Run("event has not ended", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockEventRepo.On("Find", upcoming.ID.String()).Return(upcoming, nil).Once()
+		mockRepo.On("HasConfirmedBooking", userID.String(), upcoming.ID.String()).Return(true, nil).Once()
+
+		svc := NewService(mockRepo, mockEventRepo, NewWordListModerator(nil), 3)
+		_, err := svc.CreateReviewService(newReview(upcoming), false)
+
+		assert.ErrorIs(t, err, ErrEventNotEnded)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockEventRepo.On("Find", ended.ID.String()).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, mockEventRepo, NewWordListModerator(nil), 3)
+		_, err := svc.CreateReviewService(newReview(ended), false)
+
+		assert.ErrorIs(t, err, ErrEventNotFound)
+	})
+
+	t.Run("override skips eligibility", func(t *testing.T) {
+		review := newReview(upcoming)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Create", review).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.CreateReviewService(review, true)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("blocked language is rejected", func(t *testing.T) {
+		review := newReview(upcoming)
+		review.Review = "What a load of shit"
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Create", review).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		created, err := svc.CreateReviewService(review, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ReviewStatusRejected, created.Status)
+		assert.NotEmpty(t, created.ModerationReason)
+	})
+
+	t.Run("moderator failure keeps the review pending", func(t *testing.T) {
+		review := newReview(upcoming)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Create", review).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), failingModerator{}, 3)
+		created, err := svc.CreateReviewService(review, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ReviewStatusPending, created.Status)
+	})
+
+	t.Run("second review of the same event", func(t *testing.T) {
+		review := newReview(ended)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Create", review).Return(nil, gorm.ErrDuplicatedKey).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.CreateReviewService(review, true)
+
+		assert.ErrorIs(t, err, ErrAlreadyReviewed)
+	})
+}
+
+func TestSaveReviewService(t *testing.T) {
+	existing := &entity.Review{
+		ID: uuid.New(), EventID: uuid.New(), UserID: uuid.New(), Review: "Good", Rating: 4,
+		ReviewVotes: entity.ReviewVotes{HelpfulCount: 2, Helpfulness: 2},
+		Reply:       &entity.ReviewReply{Reply: "Thanks!"},
+	}
+
+	t.Run("event and author stay the same", func(t *testing.T) {
+		review := &entity.Review{ID: existing.ID, EventID: uuid.New(), UserID: uuid.New(), Review: "Great", Rating: 5}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", existing.ID.String()).Return(existing, nil).Once()
+		mockRepo.On("Save", review).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		saved, err := svc.SaveReviewService(review, existing.UserID, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, existing.EventID, saved.EventID)
+		assert.Equal(t, existing.UserID, saved.UserID)
+		assert.Equal(t, 5, saved.Rating)
+		assert.Equal(t, entity.ReviewStatusApproved, saved.Status)
+		assert.Equal(t, existing.ReviewVotes, saved.ReviewVotes)
+		assert.Equal(t, existing.Reply, saved.Reply)
+	})
+
+	t.Run("admin edits another user's review", func(t *testing.T) {
+		review := &entity.Review{ID: existing.ID, Review: "Great", Rating: 5}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", existing.ID.String()).Return(existing, nil).Once()
+		mockRepo.On("Save", review).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.SaveReviewService(review, uuid.New(), true)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("user edits another user's review", func(t *testing.T) {
+		review := &entity.Review{ID: existing.ID, Review: "Terrible", Rating: 1}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", existing.ID.String()).Return(existing, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.SaveReviewService(review, uuid.New(), false)
+
+		assert.ErrorIs(t, err, ErrNotAuthor)
+	})
+
+	for _, status := range []string{entity.ReviewStatusRejected, entity.ReviewStatusFlagged} {
+		t.Run(status+" review goes back to the admins", func(t *testing.T) {
+			held := &entity.Review{ID: uuid.New(), EventID: uuid.New(), UserID: uuid.New(), Review: "Bad", Rating: 1,
+				Status: status, ModerationReason: "spam"}
+			review := &entity.Review{ID: held.ID, Review: "Great", Rating: 5}
+
+			mockRepo := mocks.NewRepository(t)
+			mockRepo.On("Find", held.ID.String()).Return(held, nil).Once()
+			mockRepo.On("Save", review).Return(review, nil).Once()
+
+			svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+			saved, err := svc.SaveReviewService(review, held.UserID, false)
+
+			assert.NoError(t, err)
+			assert.Equal(t, entity.ReviewStatusPending, saved.Status)
+			assert.Equal(t, "spam", saved.ModerationReason)
+		})
+	}
+}
+
+type failingModerator struct{}
+
+func (failingModerator) Moderate(*entity.Review) (Verdict, error) {
+	return Verdict{}, errors.New("moderation service unavailable")
+}
+
+func TestReportReviewService(t *testing.T) {
+	newFixtures := func() (*entity.Review, *entity.ReviewReport) {
+		review := &entity.Review{ID: uuid.New(), EventID: uuid.New(), UserID: uuid.New(), Status: entity.ReviewStatusApproved}
+		report := &entity.ReviewReport{ReviewID: review.ID, UserID: uuid.New(), Reason: "spam"}
+		return review, report
+	}
+
+	t.Run("below the threshold", func(t *testing.T) {
+		review, report := newFixtures()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", review.ID.String()).Return(review, nil).Once()
+		mockRepo.On("CreateReport", report).Return(report, nil).Once()
+		mockRepo.On("CountOpenReports", review.ID.String()).Return(int64(2), nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.ReportReviewService(report)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("threshold flags the review", func(t *testing.T) {
+		review, report := newFixtures()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", review.ID.String()).Return(review, nil).Once()
+		mockRepo.On("CreateReport", report).Return(report, nil).Once()
+		mockRepo.On("CountOpenReports", review.ID.String()).Return(int64(3), nil).Once()
+		mockRepo.On("Flag", review).Return(true, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.ReportReviewService(report)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "reported by 3 users", review.ModerationReason)
+	})
+
+	t.Run("own review", func(t *testing.T) {
+		review, report := newFixtures()
+		report.UserID = review.UserID
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", review.ID.String()).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.ReportReviewService(report)
+
+		assert.ErrorIs(t, err, ErrReportOwnReview)
+	})
+
+	t.Run("review not published", func(t *testing.T) {
+		review, report := newFixtures()
+		review.Status = entity.ReviewStatusFlagged
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", review.ID.String()).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.ReportReviewService(report)
+
+		assert.ErrorIs(t, err, ErrReviewNotFound)
+	})
+
+	t.Run("second report by the same user", func(t *testing.T) {
+		review, report := newFixtures()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", review.ID.String()).Return(review, nil).Once()
+		mockRepo.On("CreateReport", report).Return(nil, gorm.ErrDuplicatedKey).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.ReportReviewService(report)
+
+		assert.ErrorIs(t, err, ErrAlreadyReported)
+	})
+}
+
+func TestModerateReviewService(t *testing.T) {
+	review := &entity.Review{ID: uuid.New(), EventID: uuid.New(), Status: entity.ReviewStatusFlagged}
+	moderatorID := uuid.New()
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("Find", review.ID.String()).Return(review, nil).Once()
+	mockRepo.On("Moderate", review).Return(review, nil).Once()
+
+	svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+	moderated, err := svc.ModerateReviewService(review.ID.String(), entity.ReviewStatusRejected, "off topic", moderatorID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ReviewStatusRejected, moderated.Status)
+	assert.Equal(t, "off topic", moderated.ModerationReason)
+	assert.Equal(t, &moderatorID, moderated.ModeratedBy)
+	assert.NotNil(t, moderated.ModeratedAt)
+}
+
+func TestVoteReviewService(t *testing.T) {
+	review := &entity.Review{ID: uuid.New(), UserID: uuid.New(), Status: entity.ReviewStatusApproved}
+
+	t.Run("vote on a published review", func(t *testing.T) {
+		vote := &entity.ReviewVote{ReviewID: review.ID, UserID: uuid.New(), Helpful: true}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", review.ID.String()).Return(review, nil).Once()
+		mockRepo.On("Vote", vote).Return(vote, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.VoteReviewService(vote)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("own review", func(t *testing.T) {
+		vote := &entity.ReviewVote{ReviewID: review.ID, UserID: review.UserID, Helpful: true}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", review.ID.String()).Return(review, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.VoteReviewService(vote)
+
+		assert.ErrorIs(t, err, ErrVoteOwnReview)
+	})
+
+	t.Run("review not published", func(t *testing.T) {
+		pending := &entity.Review{ID: uuid.New(), UserID: uuid.New(), Status: entity.ReviewStatusPending}
+		vote := &entity.ReviewVote{ReviewID: pending.ID, UserID: uuid.New()}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", pending.ID.String()).Return(pending, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.VoteReviewService(vote)
+
+		assert.ErrorIs(t, err, ErrReviewNotFound)
+	})
+
+	t.Run("delete a vote that does not exist", func(t *testing.T) {
+		userID := uuid.New().String()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("DeleteVote", review.ID.String(), userID).Return(gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		err := svc.DeleteVoteService(review.ID.String(), userID)
+
+		assert.ErrorIs(t, err, ErrVoteNotFound)
+	})
+}
+
+func TestReplyReviewService(t *testing.T) {
+	review := &entity.Review{ID: uuid.New(), EventID: uuid.New()}
+	scope := tenant.Of(uuid.New())
+
+	t.Run("organizer replies to a review of their event", func(t *testing.T) {
+		reply := &entity.ReviewReply{ReviewID: review.ID, UserID: uuid.New(), Reply: "Thanks for coming!"}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", scope, review.ID.String()).Return(review, nil).Once()
+		mockRepo.On("SaveReply", reply).Return(reply, nil).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.ReplyReviewService(scope, reply)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("review of another organization", func(t *testing.T) {
+		reply := &entity.ReviewReply{ReviewID: review.ID, UserID: uuid.New(), Reply: "Thanks for coming!"}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindInScope", scope, review.ID.String()).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, mocks.NewEventRepository(t), NewWordListModerator(nil), 3)
+		_, err := svc.ReplyReviewService(scope, reply)
+
+		assert.ErrorIs(t, err, ErrReviewNotFound)
+	})
+}
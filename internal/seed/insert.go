@@ -0,0 +1,48 @@
+package seed
+
+import (
+	"event-booking/internal/review"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const batchSize = 500
+
+// Insert writes the dataset in one transaction, so a failed run leaves the
+// database untouched. It fails on a database that was already seeded,
+// because the accounts' emails are unique.
+func Insert(db *gorm.DB, ds *Dataset) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		insert := tx.Omit(clause.Associations).Session(&gorm.Session{})
+
+		if err := insert.CreateInBatches(ds.Users, batchSize).Error; err != nil {
+			return err
+		}
+		if err := insert.Create(&ds.Organization).Error; err != nil {
+			return err
+		}
+		if err := insert.Create(ds.Members).Error; err != nil {
+			return err
+		}
+		if err := insert.CreateInBatches(ds.Events, batchSize).Error; err != nil {
+			return err
+		}
+		if len(ds.Bookings) > 0 {
+			if err := insert.CreateInBatches(ds.Bookings, batchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		// through the review repository, which keeps the events' rating
+		// aggregates up to date
+		reviewRepo := review.NewRepository(tx)
+		for i := range ds.Reviews {
+			if _, err := reviewRepo.Create(&ds.Reviews[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,279 @@
+// Package seed generates realistic, deterministic fake data: users, an
+// organization with its events, bookings and reviews. The same Config always
+// produces the same rows, so tests can build fixtures from it and local
+// databases can be seeded reproducibly.
+package seed
+
+import (
+	"errors"
+	"event-booking/internal/entity"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Per unit of Config.Scale.
+const (
+	usersPerScale  = 20
+	eventsPerScale = 10
+)
+
+type Config struct {
+	// Seed drives every random choice; the same seed gives the same data
+	Seed int64
+	// Scale multiplies the number of users and events, 1 gives 20 users and
+	// 10 events
+	Scale int
+	// Password of every generated account
+	Password string
+	// Now anchors the event dates, a third of the events have ended by then
+	Now time.Time
+}
+
+// Credential is a generated account to log in with.
+type Credential struct {
+	Role     string
+	Email    string
+	Password string
+}
+
+type Dataset struct {
+	Organization entity.Organization
+	Members      []entity.OrganizationMember
+	Users        []entity.User
+	Events       []entity.Event
+	Bookings     []entity.Booking
+	Reviews      []entity.Review
+	Credentials  []Credential
+}
+
+var (
+	adjectives = []string{"Summer", "Midnight", "Golden", "Urban", "Acoustic", "Electric", "Grand", "Indie", "Sunset", "Winter"}
+	subjects   = []string{"Jazz", "Rock", "Food", "Film", "Tech", "Comedy", "Art", "Wine", "Poetry", "Startup"}
+	formats    = []string{"Night", "Festival", "Summit", "Showcase", "Market", "Workshop", "Session", "Fair", "Meetup", "Gala"}
+	categories = []string{"music", "food", "film", "technology", "comedy", "art"}
+	locations  = []string{"Jakarta", "Bandung", "Yogyakarta", "Surabaya", "Bali", "Medan", "Makassar", "Semarang"}
+	firstNames = []string{"Ayu", "Budi", "Citra", "Dimas", "Eka", "Fajar", "Gita", "Hadi", "Indah", "Joko", "Kartika", "Lestari", "Maya", "Nanda", "Putri", "Rizky"}
+	lastNames  = []string{"Santoso", "Wijaya", "Pratama", "Saputra", "Hidayat", "Kusuma", "Nugroho", "Halim", "Siregar", "Wibowo"}
+	comments   = map[int][]string{
+		1: {"Disappointing, the schedule was a mess.", "Not worth the ticket price."},
+		2: {"Long queues and poor sound.", "Some good moments but badly organized."},
+		3: {"Decent, nothing special.", "Okay evening, the venue was crowded."},
+		4: {"Really enjoyed it, would go again.", "Great line-up and friendly staff."},
+		5: {"Absolutely fantastic, the highlight of my year!", "Perfectly organized, loved every minute."},
+	}
+)
+
+// Generate builds the dataset for cfg without touching a database.
+func Generate(cfg Config) (*Dataset, error) {
+	if cfg.Scale < 1 {
+		return nil, errors.New("scale must be at least 1")
+	}
+	if cfg.Password == "" {
+		return nil, errors.New("password is required")
+	}
+	if cfg.Now.IsZero() {
+		return nil, errors.New("now is required")
+	}
+
+	// one hash for every account, bcrypt is too slow to run per user
+	hash, err := bcrypt.GenerateFromPassword([]byte(cfg.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		cfg:  cfg,
+		rand: rand.New(rand.NewSource(cfg.Seed)),
+		hash: string(hash),
+		ds:   &Dataset{},
+	}
+	g.users()
+	g.events()
+	g.bookings()
+	g.reviews()
+
+	return g.ds, nil
+}
+
+type generator struct {
+	cfg  Config
+	rand *rand.Rand
+	hash string
+	ds   *Dataset
+}
+
+func (g *generator) id() uuid.UUID {
+	id, err := uuid.NewRandomFromReader(g.rand)
+	if err != nil {
+		// reading from math/rand never fails
+		panic(err)
+	}
+	return id
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rand.Intn(len(values))]
+}
+
+func (g *generator) user(name, email, role string) entity.User {
+	created := g.cfg.Now.AddDate(0, 0, -90-g.rand.Intn(90))
+	user := entity.User{
+		ID:                       g.id(),
+		Name:                     name,
+		Email:                    email,
+		Password:                 g.hash,
+		Role:                     role,
+		VerificationAttemptsLeft: 3,
+		IsVerified:               true,
+		CreatedAt:                created,
+		UpdatedAt:                created,
+	}
+	g.ds.Users = append(g.ds.Users, user)
+	g.ds.Credentials = append(g.ds.Credentials, Credential{Role: role, Email: email, Password: g.cfg.Password})
+
+	return user
+}
+
+func (g *generator) users() {
+	g.user("Admin", "admin@example.com", "admin")
+
+	organizer := g.user("Olivia Organizer", "organizer@example.com", "user")
+	g.ds.Credentials[len(g.ds.Credentials)-1].Role = "organizer"
+
+	g.ds.Organization = entity.Organization{
+		ID:        g.id(),
+		Name:      "Nusantara Live",
+		CreatedAt: organizer.CreatedAt,
+		UpdatedAt: organizer.CreatedAt,
+	}
+	g.ds.Members = append(g.ds.Members, entity.OrganizationMember{
+		ID:             g.id(),
+		OrganizationID: g.ds.Organization.ID,
+		UserID:         organizer.ID,
+		Role:           entity.OrganizationRoleOwner,
+		CreatedAt:      organizer.CreatedAt,
+		UpdatedAt:      organizer.CreatedAt,
+	})
+
+	for i := 1; i <= usersPerScale*g.cfg.Scale; i++ {
+		name := g.pick(firstNames) + " " + g.pick(lastNames)
+		g.user(name, fmt.Sprintf("user%04d@example.com", i), "user")
+	}
+}
+
+func (g *generator) events() {
+	organizerID := g.ds.Organization.ID
+	count := eventsPerScale * g.cfg.Scale
+
+	for i := 0; i < count; i++ {
+		// a third of the events have ended, the rest are spread over the
+		// next three months
+		day := 1 + g.rand.Intn(90)
+		if i%3 == 0 {
+			day = -day
+		}
+		start := g.cfg.Now.Truncate(24*time.Hour).AddDate(0, 0, day).Add(time.Duration(17+g.rand.Intn(4)) * time.Hour)
+		seats := 20 + 10*g.rand.Intn(19)
+		created := start.AddDate(0, 0, -30-g.rand.Intn(60))
+
+		g.ds.Events = append(g.ds.Events, entity.Event{
+			ID: g.id(),
+			// the number keeps names unique, events are unique by name and
+			// start
+			Name:          fmt.Sprintf("%s %s %s #%d", g.pick(adjectives), g.pick(subjects), g.pick(formats), i+1),
+			Location:      g.pick(locations),
+			StartDate:     start,
+			EndDate:       start.Add(time.Duration(2+g.rand.Intn(3)) * time.Hour),
+			Price:         float64(5 * (2 + g.rand.Intn(30))),
+			TotalSeat:     seats,
+			AvailableSeat: seats,
+			Category:      g.pick(categories),
+			OrganizerID:   &organizerID,
+			Status:        entity.EventStatusPublished,
+			CreatedAt:     created,
+			UpdatedAt:     created,
+		})
+	}
+}
+
+// bookings sells between a fifth and nine tenths of every event's seats.
+// Cancelled bookings give their seats back, so AvailableSeat always equals
+// TotalSeat minus the confirmed quantities.
+func (g *generator) bookings() {
+	// the admin and organizer do not book
+	attendees := g.ds.Users[2:]
+
+	for i := range g.ds.Events {
+		event := &g.ds.Events[i]
+		target := event.TotalSeat * (20 + g.rand.Intn(71)) / 100
+
+		for _, n := range g.rand.Perm(len(attendees)) {
+			quantity := 1 + g.rand.Intn(4)
+			if quantity > event.AvailableSeat || event.TotalSeat-event.AvailableSeat >= target {
+				break
+			}
+
+			created := event.CreatedAt.Add(time.Duration(g.rand.Int63n(int64(event.StartDate.Sub(event.CreatedAt)))))
+			booking := entity.Booking{
+				ID:         g.id(),
+				UserID:     attendees[n].ID,
+				EventID:    event.ID,
+				Quantity:   quantity,
+				TotalPrice: float64(quantity) * event.Price,
+				Status:     entity.BookingStatusConfirmed,
+				CreatedAt:  created,
+				UpdatedAt:  created,
+			}
+
+			if g.rand.Intn(10) == 0 {
+				cancelled := created.Add(time.Duration(g.rand.Int63n(int64(event.StartDate.Sub(created)))))
+				booking.Status = entity.BookingStatusCancelled
+				booking.CancelledAt = &cancelled
+				booking.UpdatedAt = cancelled
+			} else {
+				event.AvailableSeat -= quantity
+			}
+
+			g.ds.Bookings = append(g.ds.Bookings, booking)
+		}
+	}
+}
+
+// reviews lets about half of the attendees of ended events review them,
+// mostly favourably.
+func (g *generator) reviews() {
+	ended := make(map[uuid.UUID]entity.Event)
+	for _, event := range g.ds.Events {
+		if event.EndDate.Before(g.cfg.Now) {
+			ended[event.ID] = event
+		}
+	}
+
+	for _, booking := range g.ds.Bookings {
+		event, ok := ended[booking.EventID]
+		if !ok || booking.Status != entity.BookingStatusConfirmed || g.rand.Intn(2) == 0 {
+			continue
+		}
+
+		rating := []int{1, 2, 3, 3, 4, 4, 4, 5, 5, 5}[g.rand.Intn(10)]
+		created := event.EndDate.Add(time.Duration(1+g.rand.Intn(72)) * time.Hour)
+		if created.After(g.cfg.Now) {
+			created = g.cfg.Now
+		}
+
+		g.ds.Reviews = append(g.ds.Reviews, entity.Review{
+			ID:        g.id(),
+			EventID:   event.ID,
+			UserID:    booking.UserID,
+			Review:    comments[rating][g.rand.Intn(len(comments[rating]))],
+			Rating:    rating,
+			Status:    entity.ReviewStatusApproved,
+			CreatedAt: created,
+			UpdatedAt: created,
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package seed
+
+import (
+	"event-booking/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testConfig = Config{
+	Seed:     42,
+	Scale:    2,
+	Password: "secret",
+	Now:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	first, err := Generate(testConfig)
+	require.NoError(t, err)
+	second, err := Generate(testConfig)
+	require.NoError(t, err)
+
+	// password hashes are salted, everything else must match
+	for i := range first.Users {
+		first.Users[i].Password, second.Users[i].Password = "", ""
+	}
+	assert.Equal(t, first, second)
+
+	other := testConfig
+	other.Seed = 7
+	third, err := Generate(other)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Events[0].ID, third.Events[0].ID)
+}
+
+func TestGenerateIsConsistent(t *testing.T) {
+	ds, err := Generate(testConfig)
+	require.NoError(t, err)
+
+	assert.Len(t, ds.Users, 2+2*usersPerScale)
+	assert.Len(t, ds.Events, 2*eventsPerScale)
+	assert.Len(t, ds.Credentials, len(ds.Users))
+	assert.Equal(t, "admin", ds.Credentials[0].Role)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(ds.Users[0].Password), []byte("secret")))
+
+	events := make(map[uuid.UUID]entity.Event)
+	for _, event := range ds.Events {
+		events[event.ID] = event
+	}
+
+	sold := make(map[uuid.UUID]int)
+	confirmed := make(map[[2]uuid.UUID]bool)
+	for _, booking := range ds.Bookings {
+		event := events[booking.EventID]
+		assert.True(t, booking.Quantity >= 1 && booking.Quantity <= 4)
+		assert.Equal(t, float64(booking.Quantity)*event.Price, booking.TotalPrice)
+		assert.True(t, booking.CreatedAt.Before(event.StartDate))
+		if booking.Status == entity.BookingStatusConfirmed {
+			sold[booking.EventID] += booking.Quantity
+			confirmed[[2]uuid.UUID{booking.EventID, booking.UserID}] = true
+		}
+	}
+
+	for _, event := range ds.Events {
+		assert.GreaterOrEqual(t, event.AvailableSeat, 0, event.Name)
+		assert.Equal(t, event.TotalSeat-sold[event.ID], event.AvailableSeat, event.Name)
+	}
+
+	require.NotEmpty(t, ds.Reviews)
+	reviewed := make(map[[2]uuid.UUID]bool)
+	for _, review := range ds.Reviews {
+		key := [2]uuid.UUID{review.EventID, review.UserID}
+		assert.True(t, events[review.EventID].EndDate.Before(testConfig.Now), "only ended events are reviewed")
+		assert.True(t, confirmed[key], "only attendees review")
+		assert.False(t, reviewed[key], "one review per user and event")
+		reviewed[key] = true
+	}
+}
+
+func TestGenerateValidatesConfig(t *testing.T) {
+	cfg := testConfig
+	cfg.Scale = 0
+
+	_, err := Generate(cfg)
+
+	assert.Error(t, err)
+}
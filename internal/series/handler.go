@@ -0,0 +1,207 @@
+package series
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/api/validator"
+	"event-booking/internal/entity"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type httpHandler struct {
+	svc       *Service
+	validator *validator.Validator
+}
+
+func NewHttpHandler(svc *Service, validator *validator.Validator) *httpHandler {
+	return &httpHandler{
+		svc:       svc,
+		validator: validator,
+	}
+}
+
+type SeriesInputPayload struct {
+	Name      string    `json:"name" validate:"required,min=3,max=50"`
+	Location  string    `json:"location" validate:"required,min=3,max=50"`
+	StartDate time.Time `json:"start_date" validate:"required"`
+	EndDate   time.Time `json:"end_date" validate:"required"`
+	Price     float64   `json:"price" validate:"required"`
+	TotalSeat int       `json:"total_seat" validate:"required"`
+	Category  string    `json:"category" validate:"required"`
+	RRule     string    `json:"rrule" validate:"required"`
+	// Status applies to every occurrence and defaults to draft
+	Status string `json:"status" validate:"omitempty,oneof=draft published"`
+}
+
+func (h *httpHandler) CreateSeriesHandler(c *fiber.Ctx) error {
+	payload := new(SeriesInputPayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	newSeries := &entity.EventSeries{
+		Name:      payload.Name,
+		Location:  payload.Location,
+		StartDate: payload.StartDate,
+		EndDate:   payload.EndDate,
+		Price:     payload.Price,
+		TotalSeat: payload.TotalSeat,
+		Category:  payload.Category,
+		RRule:     payload.RRule,
+	}
+
+	status := payload.Status
+	if status == "" {
+		status = entity.EventStatusDraft
+	}
+
+	series, err := h.svc.CreateSeriesService(newSeries, status)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Event series created successfully", newSeriesResponse(series)))
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"start_date", "name", "created_at"},
+	Filters:     []string{"category", "location"},
+	DefaultSort: "start_date",
+}
+
+func (h *httpHandler) FindAllSeriesHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	series, total, err := h.svc.FindAllSeriesService(opts)
+	if err != nil {
+		return err
+	}
+
+	var seriesResponses []responses.EventSeriesResponseObject
+	for _, s := range series {
+		seriesResponses = append(seriesResponses, newSeriesResponse(&s))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Event series found", seriesResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) FindSeriesHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	series, err := h.svc.FindSeriesService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Event series found", newSeriesResponse(series)))
+}
+
+func (h *httpHandler) PublishSeriesHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	published, err := h.svc.PublishSeriesService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Event series published successfully", fiber.Map{
+		"published": published,
+	}))
+}
+
+func (h *httpHandler) DeleteSeriesHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	err := h.svc.DeleteSeriesService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Event series deleted successfully"))
+}
+
+type OccurrenceUpdatePayload struct {
+	Scope     string    `json:"scope" validate:"required,oneof=this following"`
+	Name      string    `json:"name" validate:"required,min=3,max=50"`
+	Location  string    `json:"location" validate:"required,min=3,max=50"`
+	StartDate time.Time `json:"start_date" validate:"required"`
+	EndDate   time.Time `json:"end_date" validate:"required"`
+	Price     float64   `json:"price" validate:"required"`
+	TotalSeat int       `json:"total_seat" validate:"required"`
+	Category  string    `json:"category" validate:"required"`
+}
+
+func (h *httpHandler) UpdateOccurrenceHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	eventID := c.Params("eventId")
+	payload := new(OccurrenceUpdatePayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	events, err := h.svc.UpdateOccurrenceService(id, eventID, *payload)
+	if err != nil {
+		return err
+	}
+
+	var eventResponses []responses.EventResponseObject
+	for _, event := range events {
+		eventResponses = append(eventResponses, newOccurrenceResponse(event))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Occurrences updated successfully", eventResponses))
+}
+
+func newSeriesResponse(series *entity.EventSeries) responses.EventSeriesResponseObject {
+	res := responses.EventSeriesResponseObject{
+		ID:        series.ID,
+		Name:      series.Name,
+		Location:  series.Location,
+		StartDate: series.StartDate,
+		EndDate:   series.EndDate,
+		Price:     series.Price,
+		TotalSeat: series.TotalSeat,
+		Category:  series.Category,
+		RRule:     series.RRule,
+	}
+
+	for _, event := range series.Events {
+		res.Occurrences = append(res.Occurrences, newOccurrenceResponse(event))
+	}
+
+	return res
+}
+
+func newOccurrenceResponse(event entity.Event) responses.EventResponseObject {
+	now := time.Now()
+	return responses.EventResponseObject{
+		ID:                 event.ID,
+		Name:               event.Name,
+		Location:           event.Location,
+		StartDate:          event.StartDate,
+		EndDate:            event.EndDate,
+		Price:              event.Price,
+		TotalSeat:          event.TotalSeat,
+		AvailableSeat:      event.AvailableSeat,
+		Category:           event.Category,
+		SeriesID:           event.SeriesID,
+		Detached:           event.Detached,
+		Status:             event.Lifecycle(now),
+		PublishAt:          event.PublishAt,
+		SalesStartAt:       event.SalesStartAt,
+		SalesEndAt:         event.SalesEndAt,
+		SalesOpen:          event.SalesOpen(now),
+		CancelledAt:        event.CancelledAt,
+		CancellationReason: event.CancellationReason,
+	}
+}
@@ -0,0 +1,224 @@
+package series
+
+import (
+	"event-booking/internal/apperror"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+// MaxOccurrences caps how many events a single series may generate.
+const MaxOccurrences = 366
+
+const untilLayout = "20060102T150405Z"
+
+var ErrInvalidRule = apperror.Validation("invalid_rrule", "invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is the subset of an RFC 5545 RRULE supported for event series:
+// FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY (weekly only) and
+// either COUNT or UNTIL.
+//
+//	FREQ=WEEKLY;BYDAY=TU,TH;COUNT=8
+//	FREQ=MONTHLY;INTERVAL=2;UNTIL=20251231T235959Z
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	Until    *time.Time
+}
+
+func ParseRule(value string) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return Rule{}, fmt.Errorf("%w: rule is empty", ErrInvalidRule)
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(val))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRule)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return Rule{}, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRule)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := time.Parse(untilLayout, val)
+			if err != nil {
+				// a plain date includes the whole day
+				until, err = time.Parse("20060102", val)
+				if err != nil {
+					return Rule{}, fmt.Errorf("%w: UNTIL must look like 20060102 or 20060102T150405Z", ErrInvalidRule)
+				}
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return Rule{}, fmt.Errorf("%w: unknown BYDAY value %q", ErrInvalidRule, day)
+				}
+				if !slices.Contains(rule.ByDay, weekday) {
+					rule.ByDay = append(rule.ByDay, weekday)
+				}
+			}
+		default:
+			return Rule{}, fmt.Errorf("%w: %s is not supported", ErrInvalidRule, key)
+		}
+	}
+
+	switch rule.Freq {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	case "":
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	default:
+		return Rule{}, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrInvalidRule)
+	}
+
+	if len(rule.ByDay) > 0 && rule.Freq != FrequencyWeekly {
+		return Rule{}, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRule)
+	}
+	if rule.Count == 0 && rule.Until == nil {
+		return Rule{}, fmt.Errorf("%w: COUNT or UNTIL is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRule)
+	}
+	if rule.Count > MaxOccurrences {
+		return Rule{}, fmt.Errorf("%w: COUNT must not exceed %d", ErrInvalidRule, MaxOccurrences)
+	}
+
+	slices.SortFunc(rule.ByDay, func(a, b time.Weekday) int {
+		return mondayOffset(a) - mondayOffset(b)
+	})
+
+	return rule, nil
+}
+
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		var days []string
+		for _, weekday := range r.ByDay {
+			days = append(days, strings.ToUpper(weekday.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences expands the rule into start times, beginning with start.
+// Dates that do not exist in a month (e.g. the 31st) are skipped, as in RFC 5545.
+func (r Rule) Occurrences(start time.Time) ([]time.Time, error) {
+	var occurrences []time.Time
+
+	// add reports whether generation should continue
+	add := func(t time.Time) (bool, error) {
+		if t.Before(start) {
+			return true, nil
+		}
+		if r.Until != nil && t.After(*r.Until) {
+			return false, nil
+		}
+		if len(occurrences) == MaxOccurrences {
+			return false, fmt.Errorf("%w: rule yields more than %d occurrences", ErrInvalidRule, MaxOccurrences)
+		}
+
+		occurrences = append(occurrences, t)
+		return r.Count == 0 || len(occurrences) < r.Count, nil
+	}
+
+	switch r.Freq {
+	case FrequencyDaily:
+		for i := 0; ; i++ {
+			next, err := add(start.AddDate(0, 0, i*r.Interval))
+			if err != nil || !next {
+				return occurrences, err
+			}
+		}
+	case FrequencyWeekly:
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{start.Weekday()}
+		}
+
+		weekStart := start.AddDate(0, 0, -mondayOffset(start.Weekday()))
+		for i := 0; ; i++ {
+			week := weekStart.AddDate(0, 0, 7*i*r.Interval)
+			for _, weekday := range byDay {
+				next, err := add(week.AddDate(0, 0, mondayOffset(weekday)))
+				if err != nil || !next {
+					return occurrences, err
+				}
+			}
+		}
+	case FrequencyMonthly:
+		// give up eventually when the day never fits, e.g. the 31st every other February
+		for i := 0; i < MaxOccurrences*12; i++ {
+			t := time.Date(start.Year(), start.Month()+time.Month(i*r.Interval), start.Day(),
+				start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+			if t.Day() != start.Day() {
+				continue
+			}
+
+			next, err := add(t)
+			if err != nil || !next {
+				return occurrences, err
+			}
+		}
+		return occurrences, nil
+	}
+
+	return nil, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrInvalidRule)
+}
+
+// mondayOffset is the number of days since Monday, weeks start on Monday as in RFC 5545.
+func mondayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
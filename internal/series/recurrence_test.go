@@ -0,0 +1,106 @@
+package series
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "weekly with days", rule: "RRULE:FREQ=WEEKLY;BYDAY=TH,TU;COUNT=4", expected: "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4"},
+		{name: "monthly with interval and until date", rule: "freq=monthly;interval=2;until=20250630", expected: "FREQ=MONTHLY;INTERVAL=2;UNTIL=20250630T235959Z"},
+		{name: "missing FREQ", rule: "COUNT=3", wantErr: true},
+		{name: "unsupported FREQ", rule: "FREQ=YEARLY;COUNT=3", wantErr: true},
+		{name: "unbounded", rule: "FREQ=DAILY", wantErr: true},
+		{name: "COUNT and UNTIL", rule: "FREQ=DAILY;COUNT=3;UNTIL=20250101", wantErr: true},
+		{name: "BYDAY outside weekly", rule: "FREQ=DAILY;BYDAY=MO;COUNT=3", wantErr: true},
+		{name: "unknown day", rule: "FREQ=WEEKLY;BYDAY=XX;COUNT=3", wantErr: true},
+		{name: "too many occurrences", rule: "FREQ=DAILY;COUNT=1000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRule(tt.rule)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRule)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rule.String())
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	// a Wednesday
+	start := time.Date(2025, time.January, 1, 18, 30, 0, 0, time.UTC)
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 18, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		start    time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "daily every other day",
+			rule:     "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			start:    start,
+			expected: []time.Time{date(time.January, 1), date(time.January, 3), date(time.January, 5)},
+		},
+		{
+			name:     "weekly defaults to the start weekday",
+			rule:     "FREQ=WEEKLY;COUNT=3",
+			start:    start,
+			expected: []time.Time{date(time.January, 1), date(time.January, 8), date(time.January, 15)},
+		},
+		{
+			name:     "weekly skips days before the start",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4",
+			start:    start,
+			expected: []time.Time{date(time.January, 1), date(time.January, 3), date(time.January, 6), date(time.January, 8)},
+		},
+		{
+			name:     "weekly until is inclusive",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;UNTIL=20250129",
+			start:    start,
+			expected: []time.Time{date(time.January, 1), date(time.January, 15), date(time.January, 29)},
+		},
+		{
+			name:     "monthly skips months without the day",
+			rule:     "FREQ=MONTHLY;COUNT=3",
+			start:    date(time.January, 31),
+			expected: []time.Time{date(time.January, 31), date(time.March, 31), date(time.May, 31)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRule(tt.rule)
+			assert.NoError(t, err)
+
+			occurrences, err := rule.Occurrences(tt.start)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, occurrences)
+		})
+	}
+
+	t.Run("too many occurrences", func(t *testing.T) {
+		rule, err := ParseRule("FREQ=DAILY;UNTIL=20300101")
+		assert.NoError(t, err)
+
+		_, err = rule.Occurrences(start)
+		assert.ErrorIs(t, err, ErrInvalidRule)
+	})
+}
@@ -0,0 +1,152 @@
+package series
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+// Create inserts the series together with its generated occurrences.
+func (r *repo) Create(series *entity.EventSeries) (*entity.EventSeries, error) {
+	if err := r.db.Create(series).Error; err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r *repo) FindAll(opts query.Options) ([]entity.EventSeries, int64, error) {
+	db := r.db.Model(&entity.EventSeries{}).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var series []entity.EventSeries
+	if err := db.Scopes(opts.Paginate).Find(&series).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return series, total, nil
+}
+
+// Find returns the series with its occurrences in chronological order.
+func (r *repo) Find(id string) (*entity.EventSeries, error) {
+	var series entity.EventSeries
+	err := r.db.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("start_date")
+	}).Where("id = ?", id).First(&series).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &series, nil
+}
+
+func (r *repo) FindOccurrence(seriesID, eventID string) (*entity.Event, error) {
+	var event entity.Event
+	if err := r.db.Where("id = ? AND series_id = ?", eventID, seriesID).First(&event).Error; err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (r *repo) SaveOccurrence(event *entity.Event) (*entity.Event, error) {
+	if err := r.db.Save(event).Error; err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Update saves the series template and the given occurrences atomically.
+func (r *repo) Update(series *entity.EventSeries, events []entity.Event) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Events").Save(series).Error; err != nil {
+			return err
+		}
+
+		for i := range events {
+			if err := tx.Save(&events[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Split saves the truncated series, creates next and moves the given
+// occurrences over to it, all in one transaction.
+func (r *repo) Split(series, next *entity.EventSeries, events []entity.Event) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Events").Save(series).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Omit("Events").Create(next).Error; err != nil {
+			return err
+		}
+
+		for i := range events {
+			events[i].SeriesID = &next.ID
+			if err := tx.Save(&events[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete soft deletes the series, its occurrences and their bookings and
+// reviews with one deleted_at, so restoring the series can bring back exactly
+// these rows.
+func (r *repo) Delete(series *entity.EventSeries) error {
+	series.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		events := tx.Model(&entity.Event{}).Select("id").Where("series_id = ?", series.ID)
+
+		err := tx.Model(&entity.Booking{}).Where("event_id IN (?)", events).Update("deleted_at", series.DeletedAt).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entity.Review{}).Where("event_id IN (?)", events).Update("deleted_at", series.DeletedAt).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entity.Event{}).Where("series_id = ?", series.ID).Update("deleted_at", series.DeletedAt).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(series).Update("deleted_at", series.DeletedAt).Error
+	})
+}
+
+func (r *repo) Publish(id string) (int64, error) {
+	res := r.db.Model(&entity.Event{}).
+		Where("series_id = ? AND status = ?", id, entity.EventStatusDraft).
+		Update("status", entity.EventStatusPublished)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
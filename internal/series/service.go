@@ -0,0 +1,329 @@
+package series
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ScopeThis      = "this"
+	ScopeFollowing = "following"
+)
+
+var (
+	ErrSeriesNotFound     = apperror.NotFound("series_not_found", "event series not found")
+	ErrInvalidSeries      = apperror.Validation("invalid_series", "invalid event series")
+	ErrOccurrenceNotFound = apperror.NotFound("occurrence_not_found", "occurrence not found")
+	ErrSeatsAlreadyBooked = apperror.Conflict("capacity_below_booked", "total seat is lower than the seats already booked")
+	ErrSeriesHasBookings  = apperror.Conflict("series_has_bookings", "occurrences with confirmed bookings must be cancelled before the series is deleted")
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	Create(series *entity.EventSeries) (*entity.EventSeries, error)
+	FindAll(opts query.Options) ([]entity.EventSeries, int64, error)
+	Find(id string) (*entity.EventSeries, error)
+	FindOccurrence(seriesID, eventID string) (*entity.Event, error)
+	SaveOccurrence(event *entity.Event) (*entity.Event, error)
+	Update(series *entity.EventSeries, events []entity.Event) error
+	Split(series, next *entity.EventSeries, events []entity.Event) error
+	Delete(series *entity.EventSeries) error
+	Publish(id string) (int64, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateSeriesService expands the recurrence rule and stores one event per
+// occurrence. Occurrences share the series name and are told apart by date.
+// CreateSeriesService generates the occurrences of the series, all starting
+// out with the given status.
+func (s *Service) CreateSeriesService(series *entity.EventSeries, status string) (*entity.EventSeries, error) {
+	if !series.EndDate.After(series.StartDate) {
+		return nil, fmt.Errorf("%w: end_date must be after start_date", ErrInvalidSeries)
+	}
+
+	rule, err := ParseRule(series.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	starts, err := rule.Occurrences(series.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := series.EndDate.Sub(series.StartDate)
+	series.RRule = rule.String()
+	series.Events = nil
+	for _, start := range starts {
+		series.Events = append(series.Events, entity.Event{
+			Name:          series.Name,
+			Location:      series.Location,
+			StartDate:     start,
+			EndDate:       start.Add(duration),
+			Price:         series.Price,
+			TotalSeat:     series.TotalSeat,
+			AvailableSeat: series.TotalSeat,
+			Category:      series.Category,
+			Status:        status,
+		})
+	}
+
+	series, err = s.repo.Create(series)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (s *Service) FindAllSeriesService(opts query.Options) ([]entity.EventSeries, int64, error) {
+	series, total, err := s.repo.FindAll(opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return series, total, nil
+}
+
+// PublishSeriesService publishes every draft occurrence of the series and
+// returns how many were published. Cancelled occurrences stay cancelled.
+func (s *Service) PublishSeriesService(id string) (int64, error) {
+	if _, err := s.repo.Find(id); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return 0, apperror.IfNotFound(err, ErrSeriesNotFound)
+	}
+
+	published, err := s.repo.Publish(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return 0, err
+	}
+
+	return published, nil
+}
+
+func (s *Service) FindSeriesService(id string) (*entity.EventSeries, error) {
+	series, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrSeriesNotFound)
+	}
+
+	return series, nil
+}
+
+// DeleteSeriesService soft deletes the series with its occurrences and their
+// bookings and reviews. Occurrences with confirmed bookings have to be
+// cancelled first.
+func (s *Service) DeleteSeriesService(id string) error {
+	series, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return apperror.IfNotFound(err, ErrSeriesNotFound)
+	}
+
+	for _, event := range series.Events {
+		if event.Status != entity.EventStatusCancelled && event.AvailableSeat < event.TotalSeat {
+			return fmt.Errorf("%w: %s has bookings", ErrSeriesHasBookings, event.StartDate.Format(time.DateOnly))
+		}
+	}
+
+	if err := s.repo.Delete(series); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// UpdateOccurrenceService edits a single occurrence (ScopeThis) or the
+// occurrence and every later one (ScopeFollowing). It returns the events
+// that were changed.
+func (s *Service) UpdateOccurrenceService(seriesID, eventID string, payload OccurrenceUpdatePayload) ([]entity.Event, error) {
+	if !payload.EndDate.After(payload.StartDate) {
+		return nil, fmt.Errorf("%w: end_date must be after start_date", ErrInvalidSeries)
+	}
+
+	if payload.Scope == ScopeThis {
+		return s.updateThis(seriesID, eventID, payload)
+	}
+
+	return s.updateFollowing(seriesID, eventID, payload)
+}
+
+// updateThis detaches the occurrence so later "following" edits leave it alone.
+func (s *Service) updateThis(seriesID, eventID string, payload OccurrenceUpdatePayload) ([]entity.Event, error) {
+	event, err := s.repo.FindOccurrence(seriesID, eventID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrOccurrenceNotFound)
+	}
+
+	if err := applyOccurrence(event, payload, payload.StartDate); err != nil {
+		return nil, err
+	}
+	event.Detached = true
+
+	event, err = s.repo.SaveOccurrence(event)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return []entity.Event{*event}, nil
+}
+
+// updateFollowing splits the series at the chosen occurrence: the original
+// series now ends just before it and a new series, carrying the edited
+// template, owns it and every later occurrence. Editing the first
+// occurrence updates the series in place instead. Occurrences are updated
+// rather than regenerated so their bookings stay attached.
+func (s *Service) updateFollowing(seriesID, eventID string, payload OccurrenceUpdatePayload) ([]entity.Event, error) {
+	series, err := s.repo.Find(seriesID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrSeriesNotFound)
+	}
+
+	index := -1
+	for i, event := range series.Events {
+		if event.ID.String() == eventID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, ErrOccurrenceNotFound
+	}
+
+	rule, err := ParseRule(series.RRule)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	target := series.Events[index]
+	shift := payload.StartDate.Sub(target.StartDate)
+
+	following := series.Events[index:]
+	var changed []entity.Event
+	for i := range following {
+		event := &following[i]
+		if event.Detached && event.ID != target.ID {
+			continue
+		}
+
+		if err := applyOccurrence(event, payload, event.StartDate.Add(shift)); err != nil {
+			return nil, err
+		}
+		event.Detached = false
+		changed = append(changed, *event)
+	}
+
+	next := &entity.EventSeries{
+		Name:      payload.Name,
+		Location:  payload.Location,
+		StartDate: payload.StartDate,
+		EndDate:   payload.EndDate,
+		Price:     payload.Price,
+		TotalSeat: payload.TotalSeat,
+		Category:  payload.Category,
+		RRule:     shiftRule(rule, target.StartDate, payload.StartDate, len(following)).String(),
+	}
+
+	if index == 0 {
+		next.ID = series.ID
+		next.CreatedAt = series.CreatedAt
+
+		if err := s.repo.Update(next, following); err != nil {
+			log.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+
+		return changed, nil
+	}
+
+	until := target.StartDate.Add(-time.Second)
+	rule.Count = 0
+	rule.Until = &until
+	series.RRule = rule.String()
+
+	if err := s.repo.Split(series, next, following); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	for i := range changed {
+		changed[i].SeriesID = &next.ID
+	}
+
+	return changed, nil
+}
+
+// applyOccurrence copies the payload onto the event, keeping the already
+// booked seats booked.
+func applyOccurrence(event *entity.Event, payload OccurrenceUpdatePayload, start time.Time) error {
+	booked := event.TotalSeat - event.AvailableSeat
+	if payload.TotalSeat < booked {
+		return fmt.Errorf("%w: %d seats are booked on %s", ErrSeatsAlreadyBooked, booked, event.StartDate.Format(time.DateOnly))
+	}
+
+	event.Name = payload.Name
+	event.Location = payload.Location
+	event.StartDate = start
+	event.EndDate = start.Add(payload.EndDate.Sub(payload.StartDate))
+	event.Price = payload.Price
+	event.TotalSeat = payload.TotalSeat
+	event.AvailableSeat = payload.TotalSeat - booked
+	event.Category = payload.Category
+
+	return nil
+}
+
+// shiftRule describes the remaining occurrences after they moved from
+// one start to another.
+func shiftRule(rule Rule, from, to time.Time, remaining int) Rule {
+	if rule.Count > 0 {
+		rule.Count = remaining
+	}
+	if rule.Until != nil {
+		until := rule.Until.Add(to.Sub(from))
+		rule.Until = &until
+	}
+
+	// moving e.g. Tuesday to Wednesday moves every BYDAY along with it
+	days := int(dateOf(to).Sub(dateOf(from)).Hours() / 24)
+	if days%7 != 0 && len(rule.ByDay) > 0 {
+		byDay := make([]time.Weekday, len(rule.ByDay))
+		for i, weekday := range rule.ByDay {
+			byDay[i] = time.Weekday(((int(weekday)+days)%7 + 7) % 7)
+		}
+		slices.SortFunc(byDay, func(a, b time.Weekday) int {
+			return mondayOffset(a) - mondayOffset(b)
+		})
+		rule.ByDay = byDay
+	}
+
+	return rule
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,293 @@
+package series
+
+import (
+	"event-booking/internal/entity"
+	"event-booking/internal/series/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSeries() *entity.EventSeries {
+	seriesID := uuid.New()
+	start := time.Date(2025, time.January, 7, 18, 0, 0, 0, time.UTC)
+
+	series := &entity.EventSeries{
+		ID:        seriesID,
+		Name:      "Weekly Workshop",
+		Location:  "Room 1",
+		StartDate: start,
+		EndDate:   start.Add(2 * time.Hour),
+		Price:     50,
+		TotalSeat: 20,
+		Category:  "Workshop",
+		RRule:     "FREQ=WEEKLY;BYDAY=TU;COUNT=4",
+	}
+
+	for i := 0; i < 4; i++ {
+		occurrenceStart := start.AddDate(0, 0, 7*i)
+		series.Events = append(series.Events, entity.Event{
+			ID:            uuid.New(),
+			Name:          series.Name,
+			Location:      series.Location,
+			StartDate:     occurrenceStart,
+			EndDate:       occurrenceStart.Add(2 * time.Hour),
+			Price:         series.Price,
+			TotalSeat:     series.TotalSeat,
+			AvailableSeat: series.TotalSeat,
+			Category:      series.Category,
+			SeriesID:      &seriesID,
+		})
+	}
+
+	return series
+}
+
+func TestCreateSeriesService(t *testing.T) {
+	start := time.Date(2025, time.January, 7, 18, 0, 0, 0, time.UTC)
+
+	t.Run("generates one event per occurrence", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Create", mock.Anything).Return(func(series *entity.EventSeries) (*entity.EventSeries, error) {
+			return series, nil
+		}).Once()
+
+		svc := NewService(mockRepo)
+		series, err := svc.CreateSeriesService(&entity.EventSeries{
+			Name:      "Weekly Workshop",
+			StartDate: start,
+			EndDate:   start.Add(2 * time.Hour),
+			TotalSeat: 20,
+			RRule:     "FREQ=WEEKLY;COUNT=3",
+		}, entity.EventStatusDraft)
+
+		assert.NoError(t, err)
+		assert.Len(t, series.Events, 3)
+		for i, event := range series.Events {
+			assert.Equal(t, "Weekly Workshop", event.Name)
+			assert.Equal(t, start.AddDate(0, 0, 7*i), event.StartDate)
+			assert.Equal(t, 2*time.Hour, event.EndDate.Sub(event.StartDate))
+			assert.Equal(t, 20, event.AvailableSeat)
+			assert.Equal(t, entity.EventStatusDraft, event.Status)
+		}
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+
+		svc := NewService(mockRepo)
+		_, err := svc.CreateSeriesService(&entity.EventSeries{
+			StartDate: start,
+			EndDate:   start.Add(time.Hour),
+			RRule:     "FREQ=WEEKLY",
+		}, entity.EventStatusDraft)
+
+		assert.ErrorIs(t, err, ErrInvalidRule)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+
+		svc := NewService(mockRepo)
+		_, err := svc.CreateSeriesService(&entity.EventSeries{
+			StartDate: start,
+			EndDate:   start.Add(-time.Hour),
+			RRule:     "FREQ=WEEKLY;COUNT=3",
+		}, entity.EventStatusDraft)
+
+		assert.ErrorIs(t, err, ErrInvalidSeries)
+	})
+}
+
+func TestUpdateOccurrenceService(t *testing.T) {
+	t.Run("this only changes and detaches the occurrence", func(t *testing.T) {
+		series := newTestSeries()
+		event := series.Events[1]
+		event.AvailableSeat = 15
+
+		payload := OccurrenceUpdatePayload{
+			Scope:     ScopeThis,
+			Name:      "Weekly Workshop (moved)",
+			Location:  "Room 2",
+			StartDate: event.StartDate.Add(time.Hour),
+			EndDate:   event.StartDate.Add(4 * time.Hour),
+			Price:     50,
+			TotalSeat: 30,
+			Category:  "Workshop",
+		}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindOccurrence", series.ID.String(), event.ID.String()).Return(&event, nil).Once()
+		mockRepo.On("SaveOccurrence", &event).Return(&event, nil).Once()
+
+		svc := NewService(mockRepo)
+		events, err := svc.UpdateOccurrenceService(series.ID.String(), event.ID.String(), payload)
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.True(t, events[0].Detached)
+		assert.Equal(t, "Room 2", events[0].Location)
+		assert.Equal(t, 3*time.Hour, events[0].EndDate.Sub(events[0].StartDate))
+		assert.Equal(t, 25, events[0].AvailableSeat)
+	})
+
+	t.Run("this rejects fewer seats than booked", func(t *testing.T) {
+		series := newTestSeries()
+		event := series.Events[1]
+		event.AvailableSeat = 5
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindOccurrence", series.ID.String(), event.ID.String()).Return(&event, nil).Once()
+
+		svc := NewService(mockRepo)
+		_, err := svc.UpdateOccurrenceService(series.ID.String(), event.ID.String(), OccurrenceUpdatePayload{
+			Scope:     ScopeThis,
+			StartDate: event.StartDate,
+			EndDate:   event.EndDate,
+			TotalSeat: 10,
+		})
+
+		assert.ErrorIs(t, err, ErrSeatsAlreadyBooked)
+	})
+
+	t.Run("following splits the series and skips detached occurrences", func(t *testing.T) {
+		series := newTestSeries()
+		series.Events[3].Detached = true
+		series.Events[3].Location = "Rooftop"
+		target := series.Events[1]
+
+		// move from Tuesday to Wednesday
+		payload := OccurrenceUpdatePayload{
+			Scope:     ScopeFollowing,
+			Name:      "Weekly Workshop",
+			Location:  "Room 3",
+			StartDate: target.StartDate.AddDate(0, 0, 1),
+			EndDate:   target.StartDate.AddDate(0, 0, 1).Add(90 * time.Minute),
+			Price:     60,
+			TotalSeat: 20,
+			Category:  "Workshop",
+		}
+
+		nextID := uuid.New()
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", series.ID.String()).Return(series, nil).Once()
+		mockRepo.On("Split", series, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.EventSeries).ID = nextID
+		}).Return(nil).Once()
+
+		svc := NewService(mockRepo)
+		events, err := svc.UpdateOccurrenceService(series.ID.String(), target.ID.String(), payload)
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		for _, event := range events {
+			assert.Equal(t, "Room 3", event.Location)
+			assert.Equal(t, time.Wednesday, event.StartDate.Weekday())
+			assert.Equal(t, 90*time.Minute, event.EndDate.Sub(event.StartDate))
+			assert.Equal(t, &nextID, event.SeriesID)
+		}
+
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=TU;UNTIL=20250114T175959Z", series.RRule)
+		assert.Equal(t, "Rooftop", series.Events[3].Location)
+
+		next := mockRepo.Calls[1].Arguments.Get(1).(*entity.EventSeries)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=WE;COUNT=3", next.RRule)
+		assert.Equal(t, payload.StartDate, next.StartDate)
+
+		moved := mockRepo.Calls[1].Arguments.Get(2).([]entity.Event)
+		assert.Len(t, moved, 3)
+	})
+
+	t.Run("following from the first occurrence updates the series in place", func(t *testing.T) {
+		series := newTestSeries()
+		target := series.Events[0]
+
+		payload := OccurrenceUpdatePayload{
+			Scope:     ScopeFollowing,
+			Name:      "Weekly Workshop",
+			Location:  "Room 1",
+			StartDate: target.StartDate,
+			EndDate:   target.EndDate,
+			Price:     75,
+			TotalSeat: 20,
+			Category:  "Workshop",
+		}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", series.ID.String()).Return(series, nil).Once()
+		mockRepo.On("Update", mock.MatchedBy(func(s *entity.EventSeries) bool {
+			return s.ID == series.ID && s.Price == 75 && s.RRule == "FREQ=WEEKLY;BYDAY=TU;COUNT=4"
+		}), mock.Anything).Return(nil).Once()
+
+		svc := NewService(mockRepo)
+		events, err := svc.UpdateOccurrenceService(series.ID.String(), target.ID.String(), payload)
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 4)
+		for _, event := range events {
+			assert.Equal(t, 75.0, event.Price)
+		}
+	})
+
+	t.Run("occurrence not in series", func(t *testing.T) {
+		series := newTestSeries()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", series.ID.String()).Return(series, nil).Once()
+
+		svc := NewService(mockRepo)
+		_, err := svc.UpdateOccurrenceService(series.ID.String(), uuid.NewString(), OccurrenceUpdatePayload{
+			Scope:     ScopeFollowing,
+			StartDate: series.StartDate,
+			EndDate:   series.EndDate,
+		})
+
+		assert.ErrorIs(t, err, ErrOccurrenceNotFound)
+	})
+}
+
+func TestDeleteSeriesService(t *testing.T) {
+	t.Run("deletes series without bookings", func(t *testing.T) {
+		series := newTestSeries()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", series.ID.String()).Return(series, nil).Once()
+		mockRepo.On("Delete", series).Return(nil).Once()
+
+		svc := NewService(mockRepo)
+		err := svc.DeleteSeriesService(series.ID.String())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects occurrences with bookings", func(t *testing.T) {
+		series := newTestSeries()
+		series.Events[2].AvailableSeat = series.Events[2].TotalSeat - 1
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", series.ID.String()).Return(series, nil).Once()
+
+		svc := NewService(mockRepo)
+		err := svc.DeleteSeriesService(series.ID.String())
+
+		assert.ErrorIs(t, err, ErrSeriesHasBookings)
+	})
+
+	t.Run("ignores cancelled occurrences", func(t *testing.T) {
+		series := newTestSeries()
+		series.Events[2].AvailableSeat = series.Events[2].TotalSeat - 1
+		series.Events[2].Status = entity.EventStatusCancelled
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", series.ID.String()).Return(series, nil).Once()
+		mockRepo.On("Delete", series).Return(nil).Once()
+
+		svc := NewService(mockRepo)
+		err := svc.DeleteSeriesService(series.ID.String())
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,81 @@
+package trash
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/audit"
+	"event-booking/internal/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type httpHandler struct {
+	svc   *Service
+	audit *audit.Service
+}
+
+func NewHttpHandler(svc *Service, audit *audit.Service) *httpHandler {
+	return &httpHandler{
+		svc:   svc,
+		audit: audit,
+	}
+}
+
+// auditTargets maps each kind to the target type of its audit entries.
+var auditTargets = map[string]string{
+	KindUsers:    audit.TargetUser,
+	KindSeries:   audit.TargetSeries,
+	KindEvents:   audit.TargetEvent,
+	KindBookings: audit.TargetBooking,
+	KindReviews:  audit.TargetReview,
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"deleted_at", "name"},
+	Filters:     []string{"series_id", "event_id", "user_id"},
+	DefaultSort: "-deleted_at",
+}
+
+// FindAllRecordHandler lists the soft deleted records of one kind, e.g.
+// GET /api/admin/trash/bookings?event_id=<id>.
+func (h *httpHandler) FindAllRecordHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	records, total, err := h.svc.FindAllRecordService(c.Params("kind"), opts)
+	if err != nil {
+		return err
+	}
+
+	recordResponses := []responses.TrashedRecordResponseObject{}
+	for i := range records {
+		recordResponses = append(recordResponses, newRecordResponse(&records[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Deleted records found", recordResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) RestoreRecordHandler(c *fiber.Ctx) error {
+	record, err := h.svc.RestoreRecordService(c.Params("kind"), c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	h.audit.Record(audit.FromRequest(c, audit.ActionRestore, auditTargets[record.Kind], record.ID.String()))
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Record restored successfully", newRecordResponse(record)))
+}
+
+func newRecordResponse(record *entity.TrashedRecord) responses.TrashedRecordResponseObject {
+	return responses.TrashedRecordResponseObject{
+		Kind:      record.Kind,
+		ID:        record.ID,
+		Name:      record.Name,
+		SeriesID:  record.SeriesID,
+		EventID:   record.EventID,
+		UserID:    record.UserID,
+		DeletedAt: record.DeletedAt,
+	}
+}
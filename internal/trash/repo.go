@@ -0,0 +1,247 @@
+package trash
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"event-booking/internal/review"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+func model(kind string) interface{} {
+	switch kind {
+	case KindUsers:
+		return &entity.User{}
+	case KindSeries:
+		return &entity.EventSeries{}
+	case KindEvents:
+		return &entity.Event{}
+	case KindBookings:
+		return &entity.Booking{}
+	default:
+		return &entity.Review{}
+	}
+}
+
+// trashed selects the soft deleted rows of kind as TrashedRecord columns.
+func (r *repo) trashed(kind string) *gorm.DB {
+	db := r.db.Unscoped().Model(model(kind))
+	switch kind {
+	case KindUsers:
+		return db.Select("id, email AS name, NULL::uuid AS series_id, NULL::uuid AS event_id, NULL::uuid AS user_id, deleted_at").
+			Where("deleted_at IS NOT NULL")
+	case KindSeries:
+		return db.Select("id, name, NULL::uuid AS series_id, NULL::uuid AS event_id, NULL::uuid AS user_id, deleted_at").
+			Where("deleted_at IS NOT NULL")
+	case KindEvents:
+		return db.Select("id, name, series_id, NULL::uuid AS event_id, NULL::uuid AS user_id, deleted_at").
+			Where("deleted_at IS NOT NULL")
+	case KindBookings:
+		return db.Joins("JOIN events ON events.id = bookings.event_id").
+			Select("bookings.id, events.name, NULL::uuid AS series_id, bookings.event_id, bookings.user_id, bookings.deleted_at").
+			Where("bookings.deleted_at IS NOT NULL")
+	default:
+		return db.Joins("JOIN events ON events.id = reviews.event_id").
+			Select("reviews.id, events.name, NULL::uuid AS series_id, reviews.event_id, reviews.user_id, reviews.deleted_at").
+			Where("reviews.deleted_at IS NOT NULL")
+	}
+}
+
+func (r *repo) FindAll(kind string, opts query.Options) ([]entity.TrashedRecord, int64, error) {
+	db := r.db.Table("(?) AS trash", r.trashed(kind)).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []entity.TrashedRecord
+	if err := db.Scopes(opts.Paginate).Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for i := range records {
+		records[i].Kind = kind
+	}
+
+	return records, total, nil
+}
+
+func (r *repo) Find(kind, id string) (*entity.TrashedRecord, error) {
+	var record entity.TrashedRecord
+	if err := r.db.Table("(?) AS trash", r.trashed(kind)).Where("id = ?", id).Take(&record).Error; err != nil {
+		return nil, err
+	}
+	record.Kind = kind
+
+	return &record, nil
+}
+
+func (r *repo) IsDeleted(kind string, id uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(model(kind)).Where("id = ? AND deleted_at IS NOT NULL", id).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Restore clears deleted_at of the record and of the rows deleted in the
+// same statement: the occurrences of a series and the bookings and reviews
+// of its events or of an event. Ratings are recomputed for the events that
+// get reviews back.
+func (r *repo) Restore(record *entity.TrashedRecord) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		restore := func(model interface{}, query string, args ...interface{}) error {
+			return tx.Unscoped().Model(model).
+				Where(query, args...).
+				Where("deleted_at = ?", record.DeletedAt).
+				Update("deleted_at", nil).Error
+		}
+
+		if err := restore(model(record.Kind), "id = ?", record.ID); err != nil {
+			return err
+		}
+
+		var eventIDs []uuid.UUID
+		switch record.Kind {
+		case KindReviews:
+			eventIDs = append(eventIDs, *record.EventID)
+		case KindEvents:
+			eventIDs = append(eventIDs, record.ID)
+		case KindSeries:
+			err := tx.Unscoped().Model(&entity.Event{}).
+				Where("series_id = ? AND deleted_at = ?", record.ID, record.DeletedAt).
+				Pluck("id", &eventIDs).Error
+			if err != nil {
+				return err
+			}
+
+			if err := restore(&entity.Event{}, "id IN ?", eventIDs); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+
+		if record.Kind != KindReviews && len(eventIDs) > 0 {
+			if err := restore(&entity.Booking{}, "event_id IN ?", eventIDs); err != nil {
+				return err
+			}
+
+			if err := restore(&entity.Review{}, "event_id IN ?", eventIDs); err != nil {
+				return err
+			}
+		}
+
+		for _, eventID := range eventIDs {
+			if err := review.RefreshRating(tx, eventID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Purge permanently deletes the rows soft deleted before the cutoff. The
+// foreign keys restrict deletes, so every dependent row is deleted first:
+// the rows of purged events and users are deleted whether they were soft
+// deleted or not. Ratings and vote counts touched by a purged user's live
+// reviews and votes are recomputed.
+func (r *repo) Purge(before time.Time) (map[string]int64, error) {
+	purged := map[string]int64{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		deleted := func(model interface{}) *gorm.DB {
+			return tx.Unscoped().Model(model).Select("id").Where("deleted_at < ?", before)
+		}
+
+		users := deleted(&entity.User{})
+		series := deleted(&entity.EventSeries{})
+		events := tx.Unscoped().Model(&entity.Event{}).Select("id").
+			Where("deleted_at < ? OR series_id IN (?)", before, series)
+		reviews := tx.Unscoped().Model(&entity.Review{}).Select("id").
+			Where("deleted_at < ? OR event_id IN (?) OR user_id IN (?)", before, events, users)
+		bookings := tx.Unscoped().Model(&entity.Booking{}).Select("id").
+			Where("deleted_at < ? OR event_id IN (?) OR user_id IN (?)", before, events, users)
+
+		var ratedEventIDs, votedReviewIDs []uuid.UUID
+		err := tx.Model(&entity.Review{}).Distinct("event_id").
+			Where("user_id IN (?)", users).
+			Pluck("event_id", &ratedEventIDs).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entity.ReviewVote{}).Distinct("review_id").
+			Where("user_id IN (?)", users).
+			Pluck("review_id", &votedReviewIDs).Error
+		if err != nil {
+			return err
+		}
+
+		steps := []struct {
+			kind  string
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			{"", &entity.ReviewReport{}, "review_id IN (?) OR user_id IN (?)", []interface{}{reviews, users}},
+			{"", &entity.ReviewVote{}, "review_id IN (?) OR user_id IN (?)", []interface{}{reviews, users}},
+			{"", &entity.ReviewReply{}, "review_id IN (?) OR user_id IN (?)", []interface{}{reviews, users}},
+			{KindReviews, &entity.Review{}, "id IN (?)", []interface{}{reviews}},
+			{"", &entity.BookingSeat{}, "booking_id IN (?)", []interface{}{bookings}},
+			{"", &entity.Refund{}, "booking_id IN (?)", []interface{}{bookings}},
+			{KindBookings, &entity.Booking{}, "id IN (?)", []interface{}{bookings}},
+			{"", &entity.EventReminder{}, "event_id IN (?) OR user_id IN (?)", []interface{}{events, users}},
+			{KindEvents, &entity.Event{}, "id IN (?)", []interface{}{events}},
+			{KindSeries, &entity.EventSeries{}, "id IN (?)", []interface{}{series}},
+			{"", &entity.Notification{}, "user_id IN (?)", []interface{}{users}},
+			{"", &entity.NotificationPreference{}, "user_id IN (?)", []interface{}{users}},
+			{"", &entity.CalendarFeed{}, "user_id IN (?)", []interface{}{users}},
+			{"", &entity.OrganizationMember{}, "user_id IN (?)", []interface{}{users}},
+			{KindUsers, &entity.User{}, "id IN (?)", []interface{}{users}},
+		}
+		for _, step := range steps {
+			result := tx.Unscoped().Where(step.query, step.args...).Delete(step.model)
+			if result.Error != nil {
+				return result.Error
+			}
+			if step.kind != "" {
+				purged[step.kind] = result.RowsAffected
+			}
+		}
+
+		for _, eventID := range ratedEventIDs {
+			if err := review.RefreshRating(tx, eventID); err != nil {
+				return err
+			}
+		}
+
+		for _, reviewID := range votedReviewIDs {
+			if err := review.RefreshVotes(tx, reviewID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
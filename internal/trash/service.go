@@ -0,0 +1,134 @@
+package trash
+
+import (
+	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// Kinds of soft deleted records, named after the routes listing them.
+const (
+	KindUsers    = "users"
+	KindSeries   = "series"
+	KindEvents   = "events"
+	KindBookings = "bookings"
+	KindReviews  = "reviews"
+)
+
+var Kinds = []string{KindUsers, KindSeries, KindEvents, KindBookings, KindReviews}
+
+var (
+	ErrInvalidKind     = apperror.Validation("invalid_trash_kind", "kind must be users, series, events, bookings or reviews")
+	ErrRecordNotFound  = apperror.NotFound("trashed_record_not_found", "deleted record not found")
+	ErrParentDeleted   = apperror.Conflict("parent_deleted", "the record belongs to a deleted record that has to be restored first")
+	ErrRestoreConflict = apperror.Conflict("restore_conflict", "a live record with the same unique values exists")
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	FindAll(kind string, opts query.Options) ([]entity.TrashedRecord, int64, error)
+	Find(kind, id string) (*entity.TrashedRecord, error)
+	IsDeleted(kind string, id uuid.UUID) (bool, error)
+	Restore(record *entity.TrashedRecord) error
+	Purge(before time.Time) (map[string]int64, error)
+}
+
+type Service struct {
+	repo      Repository
+	retention time.Duration
+}
+
+// NewService keeps soft deleted records restorable for retention; zero keeps
+// them forever.
+func NewService(repo Repository, retention time.Duration) *Service {
+	return &Service{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+func (s *Service) FindAllRecordService(kind string, opts query.Options) ([]entity.TrashedRecord, int64, error) {
+	if !slices.Contains(Kinds, kind) {
+		return nil, 0, ErrInvalidKind
+	}
+
+	records, total, err := s.repo.FindAll(kind, opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// RestoreRecordService brings a soft deleted record back, together with the
+// rows deleted along with it. Records whose series, event or user is still
+// deleted are refused, as are records that would clash with a live row.
+func (s *Service) RestoreRecordService(kind, id string) (*entity.TrashedRecord, error) {
+	if !slices.Contains(Kinds, kind) {
+		return nil, ErrInvalidKind
+	}
+
+	record, err := s.repo.Find(kind, id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrRecordNotFound)
+	}
+
+	parents := []struct {
+		kind string
+		id   *uuid.UUID
+	}{
+		{KindSeries, record.SeriesID},
+		{KindEvents, record.EventID},
+		{KindUsers, record.UserID},
+	}
+	for _, parent := range parents {
+		if parent.id == nil {
+			continue
+		}
+
+		deleted, err := s.repo.IsDeleted(parent.kind, *parent.id)
+		if err != nil {
+			log.Error().Err(err).Msg(err.Error())
+			return nil, err
+		}
+		if deleted {
+			return nil, fmt.Errorf("%w: restore %s %s first", ErrParentDeleted, parent.kind, parent.id)
+		}
+	}
+
+	if err := s.repo.Restore(record); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrRestoreConflict.Wrap(err)
+		}
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// PurgeService permanently deletes the records soft deleted longer than the
+// retention ago, and returns how many of each kind were deleted.
+func (s *Service) PurgeService(now time.Time) (map[string]int64, error) {
+	if s.retention <= 0 {
+		return map[string]int64{}, nil
+	}
+
+	purged, err := s.repo.Purge(now.Add(-s.retention))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,129 @@
+package trash
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"event-booking/internal/trash/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestFindAllRecordService(t *testing.T) {
+	t.Run("lists records of a kind", func(t *testing.T) {
+		records := []entity.TrashedRecord{{Kind: KindEvents, ID: uuid.New(), Name: "Jazz Night"}}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("FindAll", KindEvents, query.Options{}).Return(records, int64(1), nil).Once()
+
+		svc := NewService(mockRepo, 0)
+		found, total, err := svc.FindAllRecordService(KindEvents, query.Options{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, records, found)
+		assert.Equal(t, int64(1), total)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		svc := NewService(mocks.NewRepository(t), 0)
+		_, _, err := svc.FindAllRecordService("venues", query.Options{})
+
+		assert.ErrorIs(t, err, ErrInvalidKind)
+	})
+}
+
+func TestRestoreRecordService(t *testing.T) {
+	eventID := uuid.New()
+	userID := uuid.New()
+	newBooking := func() *entity.TrashedRecord {
+		return &entity.TrashedRecord{
+			Kind:      KindBookings,
+			ID:        uuid.New(),
+			EventID:   &eventID,
+			UserID:    &userID,
+			DeletedAt: time.Now(),
+		}
+	}
+
+	t.Run("restores record with live parents", func(t *testing.T) {
+		record := newBooking()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", KindBookings, record.ID.String()).Return(record, nil).Once()
+		mockRepo.On("IsDeleted", KindEvents, eventID).Return(false, nil).Once()
+		mockRepo.On("IsDeleted", KindUsers, userID).Return(false, nil).Once()
+		mockRepo.On("Restore", record).Return(nil).Once()
+
+		svc := NewService(mockRepo, 0)
+		restored, err := svc.RestoreRecordService(KindBookings, record.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, record, restored)
+	})
+
+	t.Run("parent still deleted", func(t *testing.T) {
+		record := newBooking()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", KindBookings, record.ID.String()).Return(record, nil).Once()
+		mockRepo.On("IsDeleted", KindEvents, eventID).Return(true, nil).Once()
+
+		svc := NewService(mockRepo, 0)
+		_, err := svc.RestoreRecordService(KindBookings, record.ID.String())
+
+		assert.ErrorIs(t, err, ErrParentDeleted)
+	})
+
+	t.Run("clashes with a live record", func(t *testing.T) {
+		record := &entity.TrashedRecord{Kind: KindUsers, ID: uuid.New(), Name: "johndoe@gmail.com"}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", KindUsers, record.ID.String()).Return(record, nil).Once()
+		mockRepo.On("Restore", record).Return(gorm.ErrDuplicatedKey).Once()
+
+		svc := NewService(mockRepo, 0)
+		_, err := svc.RestoreRecordService(KindUsers, record.ID.String())
+
+		assert.ErrorIs(t, err, ErrRestoreConflict)
+	})
+
+	t.Run("not in the trash", func(t *testing.T) {
+		id := uuid.NewString()
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", KindReviews, id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		svc := NewService(mockRepo, 0)
+		_, err := svc.RestoreRecordService(KindReviews, id)
+
+		assert.ErrorIs(t, err, ErrRecordNotFound)
+	})
+}
+
+func TestPurgeService(t *testing.T) {
+	now := time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("purges records past the retention", func(t *testing.T) {
+		purged := map[string]int64{KindEvents: 2, KindBookings: 5}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Purge", now.Add(-720*time.Hour)).Return(purged, nil).Once()
+
+		svc := NewService(mockRepo, 720*time.Hour)
+		result, err := svc.PurgeService(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, purged, result)
+	})
+
+	t.Run("zero retention keeps everything", func(t *testing.T) {
+		svc := NewService(mocks.NewRepository(t), 0)
+		result, err := svc.PurgeService(now)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
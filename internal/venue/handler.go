@@ -0,0 +1,150 @@
+package venue
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/api/validator"
+	"event-booking/internal/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type httpHandler struct {
+	svc       *Service
+	validator *validator.Validator
+}
+
+func NewHttpHandler(svc *Service, validator *validator.Validator) *httpHandler {
+	return &httpHandler{
+		svc:       svc,
+		validator: validator,
+	}
+}
+
+type VenueInputPayload struct {
+	Name     string                `json:"name" validate:"required,min=3,max=50"`
+	Address  string                `json:"address" validate:"required,min=3,max=100"`
+	Sections []SectionInputPayload `json:"sections" validate:"required,min=1,dive"`
+}
+
+type SectionInputPayload struct {
+	Name string            `json:"name" validate:"required,max=50"`
+	Rows []RowInputPayload `json:"rows" validate:"required,min=1,dive"`
+}
+
+// RowInputPayload describes a row of seats numbered 1 to Seats.
+type RowInputPayload struct {
+	Row   string `json:"row" validate:"required,max=10"`
+	Seats int    `json:"seats" validate:"required,min=1,max=500"`
+}
+
+func (h *httpHandler) CreateVenueHandler(c *fiber.Ctx) error {
+	payload := new(VenueInputPayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	newVenue := &entity.Venue{
+		Name:    payload.Name,
+		Address: payload.Address,
+	}
+	for i, section := range payload.Sections {
+		newSection := entity.Section{
+			Name:     section.Name,
+			Position: i,
+		}
+		for _, row := range section.Rows {
+			for number := 1; number <= row.Seats; number++ {
+				newSection.Seats = append(newSection.Seats, entity.Seat{
+					Row:    row.Row,
+					Number: number,
+				})
+			}
+		}
+		newVenue.Sections = append(newVenue.Sections, newSection)
+	}
+
+	venue, err := h.svc.CreateVenueService(newVenue)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Venue created successfully", newVenueResponse(venue)))
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"name", "created_at"},
+	DefaultSort: "name",
+}
+
+func (h *httpHandler) FindAllVenueHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	venues, total, err := h.svc.FindAllVenueService(opts)
+	if err != nil {
+		return err
+	}
+
+	var venueResponses []responses.VenueResponseObject
+	for _, venue := range venues {
+		venueResponses = append(venueResponses, newVenueResponse(&venue))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Venues found", venueResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) FindVenueHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	venue, err := h.svc.FindVenueService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Venue found", newVenueResponse(venue)))
+}
+
+func (h *httpHandler) DeleteVenueHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	err := h.svc.DeleteVenueService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Venue deleted successfully"))
+}
+
+func (h *httpHandler) SeatMapHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	seatMap, err := h.svc.SeatMapService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Seat map found", seatMap))
+}
+
+func newVenueResponse(venue *entity.Venue) responses.VenueResponseObject {
+	res := responses.VenueResponseObject{
+		ID:        venue.ID,
+		Name:      venue.Name,
+		Address:   venue.Address,
+		CreatedAt: venue.CreatedAt,
+		UpdatedAt: venue.UpdatedAt,
+	}
+
+	if len(venue.Sections) > 0 {
+		res.Sections = newSectionResponses(venue.Sections, nil)
+		for _, section := range venue.Sections {
+			res.TotalSeat += len(section.Seats)
+		}
+	}
+
+	return res
+}
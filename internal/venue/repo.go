@@ -0,0 +1,90 @@
+package venue
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+// Create inserts the venue with its whole layout.
+func (r *repo) Create(venue *entity.Venue) (*entity.Venue, error) {
+	if err := r.db.Create(venue).Error; err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (r *repo) FindAll(opts query.Options) ([]entity.Venue, int64, error) {
+	db := r.db.Model(&entity.Venue{}).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var venues []entity.Venue
+	if err := db.Scopes(opts.Paginate).Find(&venues).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return venues, total, nil
+}
+
+// Find returns the venue with sections and seats in display order.
+func (r *repo) Find(id string) (*entity.Venue, error) {
+	var venue entity.Venue
+	err := r.db.Preload("Sections", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position").Order("name")
+	}).Preload("Sections.Seats", func(db *gorm.DB) *gorm.DB {
+		return db.Order("row_label").Order("number")
+	}).Where("id = ?", id).First(&venue).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &venue, nil
+}
+
+func (r *repo) Delete(id string) error {
+	err := r.db.Where("id = ?", id).First(&entity.Venue{}).Delete(&entity.Venue{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repo) FindBookedSeatIDs(eventID string) ([]uuid.UUID, error) {
+	var seatIDs []uuid.UUID
+	if err := r.db.Model(&entity.BookingSeat{}).Where("event_id = ?", eventID).Pluck("seat_id", &seatIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return seatIDs, nil
+}
+
+// FindSeatsByVenueID returns the requested seats that belong to the venue.
+func (r *repo) FindSeatsByVenueID(venueID string, seatIDs []uuid.UUID) ([]entity.Seat, error) {
+	var seats []entity.Seat
+	err := r.db.Joins("JOIN sections ON sections.id = seats.section_id").
+		Where("sections.venue_id = ? AND seats.id IN ?", venueID, seatIDs).
+		Find(&seats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return seats, nil
+}
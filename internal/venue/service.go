@@ -0,0 +1,177 @@
+package venue
+
+import (
+	"errors"
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVenueNotFound = apperror.NotFound("venue_not_found", "venue not found")
+	ErrVenueExists   = apperror.Conflict("venue_exists", "venue name, section names and rows must be unique")
+	ErrVenueInUse    = apperror.Conflict("venue_in_use", "venue is used by an event")
+	ErrEventNotFound = apperror.NotFound("event_not_found", "event not found")
+	ErrNoSeatMap     = apperror.NotFound("no_seat_map", "event has no assigned seating")
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	Create(venue *entity.Venue) (*entity.Venue, error)
+	FindAll(opts query.Options) ([]entity.Venue, int64, error)
+	Find(id string) (*entity.Venue, error)
+	Delete(id string) error
+	FindBookedSeatIDs(eventID string) ([]uuid.UUID, error)
+}
+
+//go:generate mockery --case snake --name EventRepository
+type EventRepository interface {
+	Find(id string) (*entity.Event, error)
+}
+
+type Service struct {
+	repo            Repository
+	eventRepository EventRepository
+}
+
+func NewService(repo Repository, eventRepository EventRepository) *Service {
+	return &Service{
+		repo:            repo,
+		eventRepository: eventRepository,
+	}
+}
+
+func (s *Service) CreateVenueService(venue *entity.Venue) (*entity.Venue, error) {
+	venue, err := s.repo.Create(venue)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrVenueExists
+		}
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (s *Service) FindAllVenueService(opts query.Options) ([]entity.Venue, int64, error) {
+	venues, total, err := s.repo.FindAll(opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return venues, total, nil
+}
+
+func (s *Service) FindVenueService(id string) (*entity.Venue, error) {
+	venue, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrVenueNotFound)
+	}
+
+	return venue, nil
+}
+
+func (s *Service) DeleteVenueService(id string) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrVenueInUse
+		}
+		return apperror.IfNotFound(err, ErrVenueNotFound)
+	}
+
+	return nil
+}
+
+// SeatMapService lays out every seat of the event's venue by section and
+// row, flagging the ones that are already booked.
+func (s *Service) SeatMapService(eventID string) (*responses.SeatMapResponseObject, error) {
+	event, err := s.eventRepository.Find(eventID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrEventNotFound)
+	}
+
+	if event.VenueID == nil {
+		return nil, ErrNoSeatMap
+	}
+
+	venue, err := s.repo.Find(event.VenueID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	bookedSeatIDs, err := s.repo.FindBookedSeatIDs(eventID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	booked := make(map[uuid.UUID]bool, len(bookedSeatIDs))
+	for _, id := range bookedSeatIDs {
+		booked[id] = true
+	}
+
+	seatMap := &responses.SeatMapResponseObject{
+		EventID:   event.ID,
+		VenueID:   venue.ID,
+		VenueName: venue.Name,
+		Sections:  newSectionResponses(venue.Sections, booked),
+	}
+	for _, section := range venue.Sections {
+		for _, seat := range section.Seats {
+			seatMap.TotalSeat++
+			if !booked[seat.ID] {
+				seatMap.AvailableSeat++
+			}
+		}
+	}
+
+	return seatMap, nil
+}
+
+// newSectionResponses groups seats into rows. Availability is only filled
+// in when booked is not nil.
+func newSectionResponses(sections []entity.Section, booked map[uuid.UUID]bool) []responses.SectionResponseObject {
+	res := []responses.SectionResponseObject{}
+	for _, section := range sections {
+		sectionResponse := responses.SectionResponseObject{
+			ID:   section.ID,
+			Name: section.Name,
+			Rows: []responses.SeatRowResponseObject{},
+		}
+
+		for _, seat := range section.Seats {
+			rows := sectionResponse.Rows
+			if len(rows) == 0 || rows[len(rows)-1].Row != seat.Row {
+				sectionResponse.Rows = append(sectionResponse.Rows, responses.SeatRowResponseObject{Row: seat.Row})
+			}
+
+			seatResponse := responses.SeatResponseObject{
+				ID:     seat.ID,
+				Number: seat.Number,
+			}
+			if booked != nil {
+				available := !booked[seat.ID]
+				seatResponse.Available = &available
+			}
+
+			row := &sectionResponse.Rows[len(sectionResponse.Rows)-1]
+			row.Seats = append(row.Seats, seatResponse)
+		}
+
+		res = append(res, sectionResponse)
+	}
+
+	return res
+}
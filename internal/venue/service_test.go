@@ -0,0 +1,81 @@
+package venue
+
+import (
+	"event-booking/internal/entity"
+	"event-booking/internal/venue/mocks"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSeatMapService(t *testing.T) {
+	venueID := uuid.New()
+	event := &entity.Event{ID: uuid.New(), VenueID: &venueID}
+
+	a1, a2, b1 := uuid.New(), uuid.New(), uuid.New()
+	venue := &entity.Venue{
+		ID:   venueID,
+		Name: "City Theatre",
+		Sections: []entity.Section{
+			{
+				ID:   uuid.New(),
+				Name: "Stalls",
+				Seats: []entity.Seat{
+					{ID: a1, Row: "A", Number: 1},
+					{ID: a2, Row: "A", Number: 2},
+					{ID: b1, Row: "B", Number: 1},
+				},
+			},
+		},
+	}
+
+	t.Run("marks booked seats as unavailable", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockEventRepo.On("Find", event.ID.String()).Return(event, nil).Once()
+		mockRepo.On("Find", venueID.String()).Return(venue, nil).Once()
+		mockRepo.On("FindBookedSeatIDs", event.ID.String()).Return([]uuid.UUID{a2}, nil).Once()
+
+		svc := NewService(mockRepo, mockEventRepo)
+		seatMap, err := svc.SeatMapService(event.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, seatMap.TotalSeat)
+		assert.Equal(t, 2, seatMap.AvailableSeat)
+		assert.Len(t, seatMap.Sections, 1)
+
+		rows := seatMap.Sections[0].Rows
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "A", rows[0].Row)
+		assert.Len(t, rows[0].Seats, 2)
+		assert.True(t, *rows[0].Seats[0].Available)
+		assert.False(t, *rows[0].Seats[1].Available)
+		assert.Equal(t, "B", rows[1].Row)
+		assert.True(t, *rows[1].Seats[0].Available)
+	})
+
+	t.Run("event without venue", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockEventRepo := mocks.NewEventRepository(t)
+		mockEventRepo.On("Find", event.ID.String()).Return(&entity.Event{ID: event.ID}, nil).Once()
+
+		svc := NewService(mockRepo, mockEventRepo)
+		_, err := svc.SeatMapService(event.ID.String())
+
+		assert.ErrorIs(t, err, ErrNoSeatMap)
+	})
+}
+
+func TestDeleteVenueService(t *testing.T) {
+	t.Run("venue in use", func(t *testing.T) {
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Delete", "1").Return(gorm.ErrForeignKeyViolated).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.DeleteVenueService("1")
+
+		assert.ErrorIs(t, err, ErrVenueInUse)
+	})
+}
@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/api/responses"
+	"event-booking/internal/api/validator"
+	"event-booking/internal/entity"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type httpHandler struct {
+	svc       *Service
+	validator *validator.Validator
+}
+
+func NewHttpHandler(svc *Service, validator *validator.Validator) *httpHandler {
+	return &httpHandler{
+		svc:       svc,
+		validator: validator,
+	}
+}
+
+type WebhookInputPayload struct {
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret" validate:"required,min=16,max=128"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=booking.created booking.updated booking.cancelled notification.sent"`
+}
+
+func (h *httpHandler) CreateWebhookHandler(c *fiber.Ctx) error {
+	payload := new(WebhookInputPayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	newWebhook := &entity.Webhook{
+		URL:        payload.URL,
+		Secret:     payload.Secret,
+		EventTypes: strings.Join(payload.EventTypes, ","),
+	}
+
+	webhook, err := h.svc.CreateWebhookService(newWebhook)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.NewDataResponse("Webhook created successfully", newWebhookResponse(webhook)))
+}
+
+type WebhookUpdatePayload struct {
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=128"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=booking.created booking.updated booking.cancelled notification.sent"`
+	Active     bool     `json:"active"`
+}
+
+func (h *httpHandler) UpdateWebhookHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	payload := new(WebhookUpdatePayload)
+	if err := c.BodyParser(payload); err != nil {
+		return validator.ErrInvalidBody
+	}
+
+	if err := h.validator.ValidateStruct(payload); err != nil {
+		return err
+	}
+
+	webhook, err := h.svc.SaveWebhookService(id, *payload)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Webhook updated successfully", newWebhookResponse(webhook)))
+}
+
+var listQueryConfig = query.Config{
+	Sorts:       []string{"created_at", "failure_count"},
+	Filters:     []string{"active"},
+	DefaultSort: "-created_at",
+}
+
+func (h *httpHandler) FindAllWebhookHandler(c *fiber.Ctx) error {
+	opts, err := query.Parse(c, listQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	webhooks, total, err := h.svc.FindAllWebhookService(opts)
+	if err != nil {
+		return err
+	}
+
+	var webhookResponses []responses.WebhookResponseObject
+	for _, webhook := range webhooks {
+		webhookResponses = append(webhookResponses, newWebhookResponse(&webhook))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Webhooks found", webhookResponses, opts.Pagination(total)))
+}
+
+func (h *httpHandler) FindWebhookHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	webhook, err := h.svc.FindWebhookService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewDataResponse("Webhook found", newWebhookResponse(webhook)))
+}
+
+func (h *httpHandler) DeleteWebhookHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	err := h.svc.DeleteWebhookService(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewSuccessResponse("Webhook deleted successfully"))
+}
+
+var deliveryQueryConfig = query.Config{
+	Sorts:       []string{"created_at", "attempt", "status_code"},
+	Filters:     []string{"event_type", "success"},
+	DefaultSort: "-created_at",
+}
+
+func (h *httpHandler) FindDeliveriesHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	opts, err := query.Parse(c, deliveryQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	deliveries, total, err := h.svc.FindDeliveriesService(id, opts)
+	if err != nil {
+		return err
+	}
+
+	var deliveryResponses []responses.WebhookDeliveryResponseObject
+	for _, delivery := range deliveries {
+		deliveryResponses = append(deliveryResponses, responses.WebhookDeliveryResponseObject{
+			ID:            delivery.ID,
+			WebhookID:     delivery.WebhookID,
+			EventID:       delivery.EventID,
+			EventType:     delivery.EventType,
+			Attempt:       delivery.Attempt,
+			StatusCode:    delivery.StatusCode,
+			Success:       delivery.Success,
+			Error:         delivery.Error,
+			ResponseBody:  delivery.ResponseBody,
+			DurationMs:    delivery.DurationMs,
+			NextAttemptAt: delivery.NextAttemptAt,
+			CreatedAt:     delivery.CreatedAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses.NewPaginatedResponse("Webhook deliveries found", deliveryResponses, opts.Pagination(total)))
+}
+
+func newWebhookResponse(webhook *entity.Webhook) responses.WebhookResponseObject {
+	return responses.WebhookResponseObject{
+		ID:           webhook.ID,
+		URL:          webhook.URL,
+		EventTypes:   strings.Split(webhook.EventTypes, ","),
+		Active:       webhook.Active,
+		FailureCount: webhook.FailureCount,
+		DisabledAt:   webhook.DisabledAt,
+		CreatedAt:    webhook.CreatedAt,
+		UpdatedAt:    webhook.UpdatedAt,
+	}
+}
@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"event-booking/internal/api/query"
+	"event-booking/internal/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repo struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repo {
+	return &repo{
+		db: db,
+	}
+}
+
+func (r *repo) Create(webhook *entity.Webhook) (*entity.Webhook, error) {
+	if err := r.db.Create(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *repo) Save(webhook *entity.Webhook) (*entity.Webhook, error) {
+	if err := r.db.Save(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *repo) FindAll(opts query.Options) ([]entity.Webhook, int64, error) {
+	db := r.db.Model(&entity.Webhook{}).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var webhooks []entity.Webhook
+	if err := db.Scopes(opts.Paginate).Find(&webhooks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return webhooks, total, nil
+}
+
+func (r *repo) Find(id string) (*entity.Webhook, error) {
+	webhook := new(entity.Webhook)
+	if err := r.db.Where("id = ?", id).First(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *repo) FindActiveByEventType(eventType string) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+	err := r.db.Where("active = ?", true).
+		Where("? = ANY(string_to_array(event_types, ','))", eventType).
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *repo) Delete(id string) error {
+	err := r.db.Where("id = ?", id).First(&entity.Webhook{}).Delete(&entity.Webhook{}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repo) CreateDelivery(delivery *entity.WebhookDelivery) error {
+	if err := r.db.Create(delivery).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repo) SaveDelivery(delivery *entity.WebhookDelivery) error {
+	if err := r.db.Save(delivery).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries takes up to limit deliveries that are due and pushes
+// their next attempt back by lease, so other workers skip them while this one
+// sends. A worker that dies mid-send leaves the delivery to be retried once
+// the lease is over.
+func (r *repo) ClaimDueDeliveries(now time.Time, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("next_attempt_at <= ?", now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]any, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+
+		return tx.Model(&entity.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *repo) FindDeliveriesByWebhookID(webhookID string, opts query.Options) ([]entity.WebhookDelivery, int64, error) {
+	db := r.db.Model(&entity.WebhookDelivery{}).Where("webhook_id = ?", webhookID).Scopes(opts.Filter).Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []entity.WebhookDelivery
+	if err := db.Scopes(opts.Paginate).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+func (r *repo) IncrementFailureCount(id string) (int, error) {
+	var webhook entity.Webhook
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.Webhook{}).Where("id = ?", id).
+			UpdateColumn("failure_count", gorm.Expr("failure_count + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Select("failure_count").Where("id = ?", id).First(&webhook).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return webhook.FailureCount, nil
+}
+
+func (r *repo) ResetFailureCount(id string) error {
+	err := r.db.Model(&entity.Webhook{}).Where("id = ?", id).UpdateColumn("failure_count", 0).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *repo) Disable(id string) error {
+	err := r.db.Model(&entity.Webhook{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"active":      false,
+		"disabled_at": time.Now(),
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
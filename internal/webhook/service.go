@@ -0,0 +1,359 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"event-booking/internal/api/query"
+	"event-booking/internal/apperror"
+	"event-booking/internal/entity"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+//go:generate mockery --case snake --name Repository
+type Repository interface {
+	Create(webhook *entity.Webhook) (*entity.Webhook, error)
+	Save(webhook *entity.Webhook) (*entity.Webhook, error)
+	FindAll(opts query.Options) ([]entity.Webhook, int64, error)
+	Find(id string) (*entity.Webhook, error)
+	FindActiveByEventType(eventType string) ([]entity.Webhook, error)
+	Delete(id string) error
+	CreateDelivery(delivery *entity.WebhookDelivery) error
+	SaveDelivery(delivery *entity.WebhookDelivery) error
+	ClaimDueDeliveries(now time.Time, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+	FindDeliveriesByWebhookID(webhookID string, opts query.Options) ([]entity.WebhookDelivery, int64, error)
+	IncrementFailureCount(id string) (int, error)
+	ResetFailureCount(id string) error
+	Disable(id string) error
+}
+
+var ErrWebhookNotFound = apperror.NotFound("webhook_not_found", "webhook not found")
+
+// Service manages webhooks and delivers events to them. Deliveries are
+// stored before they are sent and a worker (see Run) sends the due ones, so
+// pending attempts and retries survive a restart.
+type Service struct {
+	repo         Repository
+	client       *http.Client
+	maxAttempts  int
+	baseBackoff  time.Duration
+	disableAfter int
+	pollInterval time.Duration
+	batchSize    int
+	lease        time.Duration
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo:         repo,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		maxAttempts:  5,
+		baseBackoff:  10 * time.Second,
+		disableAfter: 5,
+		pollInterval: 5 * time.Second,
+		batchSize:    20,
+		lease:        time.Minute,
+	}
+}
+
+func (s *Service) CreateWebhookService(webhook *entity.Webhook) (*entity.Webhook, error) {
+	webhook.Active = true
+
+	webhook, err := s.repo.Create(webhook)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) SaveWebhookService(id string, payload WebhookUpdatePayload) (*entity.Webhook, error) {
+	webhook, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrWebhookNotFound)
+	}
+
+	webhook.URL = payload.URL
+	webhook.EventTypes = strings.Join(payload.EventTypes, ",")
+	if payload.Secret != "" {
+		webhook.Secret = payload.Secret
+	}
+
+	// re-enabling a webhook gives it a clean slate
+	if payload.Active && !webhook.Active {
+		webhook.FailureCount = 0
+		webhook.DisabledAt = nil
+	}
+	webhook.Active = payload.Active
+
+	webhook, err = s.repo.Save(webhook)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) FindAllWebhookService(opts query.Options) ([]entity.Webhook, int64, error) {
+	webhooks, total, err := s.repo.FindAll(opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return webhooks, total, nil
+}
+
+func (s *Service) FindWebhookService(id string) (*entity.Webhook, error) {
+	webhook, err := s.repo.Find(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, apperror.IfNotFound(err, ErrWebhookNotFound)
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) DeleteWebhookService(id string) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return apperror.IfNotFound(err, ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+func (s *Service) FindDeliveriesService(webhookID string, opts query.Options) ([]entity.WebhookDelivery, int64, error) {
+	_, err := s.repo.Find(webhookID)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, apperror.IfNotFound(err, ErrWebhookNotFound)
+	}
+
+	deliveries, total, err := s.repo.FindDeliveriesByWebhookID(webhookID, opts)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+type Envelope struct {
+	ID        uuid.UUID   `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Publish fans the event out to every active webhook subscribed to eventType.
+// It only queues the deliveries, so callers are never blocked by slow endpoints.
+func (s *Service) Publish(eventType string, data interface{}) {
+	webhooks, err := s.repo.FindActiveByEventType(eventType)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return
+	}
+
+	envelope := Envelope{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: time.Now(),
+		Data:      data,
+	}
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return
+	}
+
+	for _, webhook := range webhooks {
+		err := s.repo.CreateDelivery(&entity.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       envelope.ID,
+			EventType:     envelope.Type,
+			Payload:       string(body),
+			Attempt:       1,
+			NextAttemptAt: &envelope.CreatedAt,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg(err.Error())
+		}
+	}
+}
+
+// Run sends due deliveries every pollInterval until ctx is done.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := s.ProcessDue(time.Now())
+			if err != nil {
+				log.Error().Err(err).Msg("failed to process webhook deliveries")
+			}
+			// a full batch means more deliveries are probably due
+			if err != nil || processed < s.batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue makes each delivery attempt due at now and returns how many it
+// attempted.
+func (s *Service) ProcessDue(now time.Time) (int, error) {
+	deliveries, err := s.repo.ClaimDueDeliveries(now, s.batchSize, s.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		s.deliver(&deliveries[i], now)
+	}
+
+	return len(deliveries), nil
+}
+
+// deliver makes the attempt and queues the next one with exponential backoff
+// if it failed. Once every attempt has failed the webhook's failure count goes
+// up, and the webhook is disabled after disableAfter such deliveries in a row.
+func (s *Service) deliver(delivery *entity.WebhookDelivery, now time.Time) bool {
+	webhook, err := s.repo.Find(delivery.WebhookID.String())
+	if err != nil {
+		// deliveries are deleted along with their webhook
+		log.Error().Err(err).Msg(err.Error())
+		return false
+	}
+
+	delivery.NextAttemptAt = nil
+	if !webhook.Active {
+		delivery.Error = "webhook is disabled"
+		if err := s.repo.SaveDelivery(delivery); err != nil {
+			log.Error().Err(err).Msg(err.Error())
+		}
+		return false
+	}
+
+	s.send(*webhook, delivery)
+	if err := s.repo.SaveDelivery(delivery); err != nil {
+		log.Error().Err(err).Msg(err.Error())
+	}
+
+	if delivery.Success {
+		if webhook.FailureCount > 0 {
+			if err := s.repo.ResetFailureCount(webhook.ID.String()); err != nil {
+				log.Error().Err(err).Msg(err.Error())
+			}
+		}
+		return true
+	}
+
+	if delivery.Attempt < s.maxAttempts {
+		nextAttemptAt := now.Add(s.backoff(delivery.Attempt))
+		err := s.repo.CreateDelivery(&entity.WebhookDelivery{
+			WebhookID:     delivery.WebhookID,
+			EventID:       delivery.EventID,
+			EventType:     delivery.EventType,
+			Payload:       delivery.Payload,
+			Attempt:       delivery.Attempt + 1,
+			NextAttemptAt: &nextAttemptAt,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg(err.Error())
+		}
+		return false
+	}
+
+	failures, err := s.repo.IncrementFailureCount(webhook.ID.String())
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		return false
+	}
+
+	if failures >= s.disableAfter {
+		log.Warn().Str("webhookID", webhook.ID.String()).Int("failures", failures).Msg("disabling webhook after repeated failures")
+		if err := s.repo.Disable(webhook.ID.String()); err != nil {
+			log.Error().Err(err).Msg(err.Error())
+		}
+	}
+
+	return false
+}
+
+func (s *Service) send(webhook entity.Webhook, delivery *entity.WebhookDelivery) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.EventID.String())
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+
+	start := time.Now()
+	res, err := s.client.Do(req)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+	defer res.Body.Close()
+
+	resBody, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	delivery.StatusCode = res.StatusCode
+	delivery.ResponseBody = string(resBody)
+	delivery.Success = res.StatusCode >= 200 && res.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = fmt.Sprintf("unexpected status code %d", res.StatusCode)
+	}
+}
+
+func (s *Service) backoff(attempt int) time.Duration {
+	return s.baseBackoff * time.Duration(1<<(attempt-1))
+}
+
+// Sign returns the signature receivers should compare against the
+// X-Webhook-Signature header: an HMAC-SHA256 over "<timestamp>.<body>".
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,217 @@
+package webhook
+
+import (
+	"event-booking/internal/entity"
+	"event-booking/internal/webhook/mocks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(repo Repository) *Service {
+	svc := NewService(repo)
+	svc.maxAttempts = 3
+	svc.baseBackoff = time.Millisecond
+	svc.disableAfter = 2
+	return svc
+}
+
+func newDelivery(webhook entity.Webhook, attempt int) *entity.WebhookDelivery {
+	now := time.Now()
+	return &entity.WebhookDelivery{
+		ID:            uuid.New(),
+		WebhookID:     webhook.ID,
+		EventID:       uuid.New(),
+		EventType:     entity.WebhookEventBookingCreated,
+		Payload:       `{"type":"booking.created"}`,
+		Attempt:       attempt,
+		NextAttemptAt: &now,
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	now := time.Now()
+
+	t.Run("signs the payload and records a successful delivery", func(t *testing.T) {
+		var gotSignature, gotTimestamp, gotEvent, gotDelivery string
+		var gotBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotSignature = r.Header.Get(SignatureHeader)
+			gotTimestamp = r.Header.Get(TimestampHeader)
+			gotEvent = r.Header.Get(EventHeader)
+			gotDelivery = r.Header.Get(DeliveryHeader)
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		webhook := entity.Webhook{ID: uuid.New(), URL: server.URL, Secret: "a-very-secret-key", Active: true}
+		delivery := newDelivery(webhook, 1)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", webhook.ID.String()).Return(&webhook, nil).Once()
+		mockRepo.On("SaveDelivery", mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+			return d.Success && d.Attempt == 1 && d.StatusCode == http.StatusOK && d.NextAttemptAt == nil
+		})).Return(nil).Once()
+
+		svc := newTestService(mockRepo)
+		ok := svc.deliver(delivery, now)
+
+		assert.True(t, ok)
+		assert.Equal(t, []byte(delivery.Payload), gotBody)
+		assert.Equal(t, entity.WebhookEventBookingCreated, gotEvent)
+		assert.Equal(t, delivery.EventID.String(), gotDelivery)
+		assert.Equal(t, Sign(webhook.Secret, gotTimestamp, gotBody), gotSignature)
+	})
+
+	t.Run("queues a retry with backoff after a failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		webhook := entity.Webhook{ID: uuid.New(), URL: server.URL, Secret: "a-very-secret-key", Active: true}
+		delivery := newDelivery(webhook, 2)
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", webhook.ID.String()).Return(&webhook, nil).Once()
+		mockRepo.On("SaveDelivery", mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+			return !d.Success && d.StatusCode == http.StatusInternalServerError && d.NextAttemptAt == nil
+		})).Return(nil).Once()
+		mockRepo.On("CreateDelivery", mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+			return d.Attempt == 3 && d.EventID == delivery.EventID && d.Payload == delivery.Payload &&
+				d.NextAttemptAt != nil && d.NextAttemptAt.Equal(now.Add(2*time.Millisecond))
+		})).Return(nil).Once()
+
+		svc := newTestService(mockRepo)
+		ok := svc.deliver(delivery, now)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("resets the failure count after a success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		webhook := entity.Webhook{ID: uuid.New(), URL: server.URL, Secret: "a-very-secret-key", Active: true, FailureCount: 1}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", webhook.ID.String()).Return(&webhook, nil).Once()
+		mockRepo.On("SaveDelivery", mock.Anything).Return(nil).Once()
+		mockRepo.On("ResetFailureCount", webhook.ID.String()).Return(nil).Once()
+
+		svc := newTestService(mockRepo)
+		ok := svc.deliver(newDelivery(webhook, 3), now)
+
+		assert.True(t, ok)
+	})
+
+	t.Run("disables the webhook after repeated failures", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		webhook := entity.Webhook{ID: uuid.New(), URL: server.URL, Secret: "a-very-secret-key", Active: true, FailureCount: 1}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", webhook.ID.String()).Return(&webhook, nil).Once()
+		mockRepo.On("SaveDelivery", mock.Anything).Return(nil).Once()
+		mockRepo.On("IncrementFailureCount", webhook.ID.String()).Return(2, nil).Once()
+		mockRepo.On("Disable", webhook.ID.String()).Return(nil).Once()
+
+		svc := newTestService(mockRepo)
+		ok := svc.deliver(newDelivery(webhook, 3), now)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("keeps the webhook active below the failure threshold", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		webhook := entity.Webhook{ID: uuid.New(), URL: server.URL, Secret: "a-very-secret-key", Active: true}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", webhook.ID.String()).Return(&webhook, nil).Once()
+		mockRepo.On("SaveDelivery", mock.Anything).Return(nil).Once()
+		mockRepo.On("IncrementFailureCount", webhook.ID.String()).Return(1, nil).Once()
+
+		svc := newTestService(mockRepo)
+		ok := svc.deliver(newDelivery(webhook, 3), now)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("skips disabled webhooks", func(t *testing.T) {
+		webhook := entity.Webhook{ID: uuid.New(), URL: "http://127.0.0.1:0", Active: false}
+
+		mockRepo := mocks.NewRepository(t)
+		mockRepo.On("Find", webhook.ID.String()).Return(&webhook, nil).Once()
+		mockRepo.On("SaveDelivery", mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+			return !d.Success && d.NextAttemptAt == nil && d.Error == "webhook is disabled"
+		})).Return(nil).Once()
+
+		svc := newTestService(mockRepo)
+		ok := svc.deliver(newDelivery(webhook, 1), now)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestPublish(t *testing.T) {
+	webhook := entity.Webhook{ID: uuid.New(), URL: "http://127.0.0.1:0", Secret: "a-very-secret-key", Active: true}
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("FindActiveByEventType", entity.WebhookEventBookingCancelled).Return([]entity.Webhook{webhook}, nil).Once()
+	mockRepo.On("CreateDelivery", mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+		return d.WebhookID == webhook.ID && d.Attempt == 1 && d.NextAttemptAt != nil &&
+			d.EventType == entity.WebhookEventBookingCancelled && strings.Contains(d.Payload, `"id":"1"`)
+	})).Return(nil).Once()
+
+	svc := newTestService(mockRepo)
+	svc.Publish(entity.WebhookEventBookingCancelled, map[string]string{"id": "1"})
+}
+
+func TestProcessDue(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get(EventHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhook := entity.Webhook{ID: uuid.New(), URL: server.URL, Secret: "a-very-secret-key", Active: true}
+	now := time.Now()
+
+	mockRepo := mocks.NewRepository(t)
+	mockRepo.On("ClaimDueDeliveries", now, 20, time.Minute).Return([]entity.WebhookDelivery{*newDelivery(webhook, 1)}, nil).Once()
+	mockRepo.On("Find", webhook.ID.String()).Return(&webhook, nil).Once()
+	mockRepo.On("SaveDelivery", mock.Anything).Return(nil).Once()
+
+	svc := newTestService(mockRepo)
+	processed, err := svc.ProcessDue(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, entity.WebhookEventBookingCreated, <-received)
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"hello":"world"}`)
+
+	assert.Equal(t, Sign("secret", "1700000000", body), Sign("secret", "1700000000", body))
+	assert.NotEqual(t, Sign("secret", "1700000000", body), Sign("other", "1700000000", body))
+	assert.NotEqual(t, Sign("secret", "1700000000", body), Sign("secret", "1700000001", body))
+}